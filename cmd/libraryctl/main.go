@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"library-service/internal/config"
+)
+
+const usage = `usage: libraryctl <command>
+
+commands:
+  config validate    load the configs and report every problem found
+  config print       print the effective configs with secrets masked`
+
+func main() {
+	if len(os.Args) < 3 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] + " " + os.Args[2] {
+	case "config validate":
+		os.Exit(validateConfig())
+	case "config print":
+		os.Exit(printConfig())
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func validateConfig() int {
+	configs, err := config.New()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err = configs.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Println("configs are valid")
+
+	return 0
+}
+
+func printConfig() int {
+	configs, err := config.New()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Print(configs)
+
+	return 0
+}
@@ -0,0 +1,240 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"library-service/internal/config"
+	"library-service/pkg/bulkhead"
+	"library-service/pkg/server"
+	"library-service/pkg/worker"
+)
+
+const (
+	ModeAll    = config.AppRoleAll
+	ModeHTTP   = config.AppRoleHTTP
+	ModeWorker = config.AppRoleWorker
+)
+
+// Modes lists the supported values of the -mode flag and APP_ROLE: all runs the http server and the background
+// workers, http only the server and worker only the workers. The memory store isn't shared between processes,
+// so an http and a worker process only work side by side over shared stores.
+var Modes = config.AppRoles
+
+// Hook is the lifecycle of a component, Start must not block and Stop is bounded by the shutdown deadline
+type Hook struct {
+	Name  string
+	Start func(ctx context.Context) error
+	Stop  func(ctx context.Context) error
+}
+
+// Container builds the dependencies of the application on first use, grouped by the bounded context
+// they belong to, so that a mode only builds what it runs. The components register their hooks as
+// they are built, Start runs the hooks in that order and Stop in reverse.
+type Container struct {
+	configs config.Configs
+	logger  *zap.Logger
+
+	// bulkheads and workers are cheap and shared by every context, they are made with the container
+	bulkheads *bulkhead.Group
+	workers   *worker.Group
+
+	mu      sync.Mutex
+	hooks   []Hook
+	started int
+
+	files        files
+	data         data
+	identity     identity
+	billing      billing
+	catalog      catalog
+	monitoring   monitoring
+	membership   membership
+	exports      exports
+	presentation presentation
+}
+
+// NewContainer returns a Container that builds nothing until a component is asked for
+func NewContainer(configs config.Configs, logger *zap.Logger) *Container {
+	return &Container{
+		configs:   configs,
+		logger:    logger,
+		bulkheads: bulkhead.NewGroup(),
+		workers:   worker.NewGroup(),
+	}
+}
+
+// Boot builds what the mode runs and registers the hooks of its server and workers
+func (c *Container) Boot(mode string) (err error) {
+	switch mode {
+	case ModeAll:
+		if err = c.bootWorkers(); err != nil {
+			return
+		}
+		return c.bootServer()
+	case ModeHTTP:
+		return c.bootServer()
+	case ModeWorker:
+		return c.bootWorkers()
+	default:
+		return fmt.Errorf("mode %q is not one of %s", mode, strings.Join(Modes, ", "))
+	}
+}
+
+// Start runs the Start of the hooks in the order they were registered and stops at the first error,
+// Stop then stops the hooks started up to it
+func (c *Container) Start(ctx context.Context) (err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for ; c.started < len(c.hooks); c.started++ {
+		hook := c.hooks[c.started]
+		if hook.Start == nil {
+			continue
+		}
+
+		if err = hook.Start(ctx); err != nil {
+			return fmt.Errorf("start %s: %w", hook.Name, err)
+		}
+	}
+
+	return
+}
+
+// Stop runs the Stop of the started hooks in reverse, every hook is stopped and the first error is returned
+func (c *Container) Stop(ctx context.Context) (err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for ; c.started > 0; c.started-- {
+		hook := c.hooks[c.started-1]
+		if hook.Stop == nil {
+			continue
+		}
+
+		if stopErr := hook.Stop(ctx); stopErr != nil {
+			c.logger.Error("ERR_STOP_"+strings.ToUpper(hook.Name), zap.Error(stopErr))
+			if err == nil {
+				err = fmt.Errorf("stop %s: %w", hook.Name, stopErr)
+			}
+		}
+	}
+
+	return
+}
+
+func (c *Container) hook(h Hook) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.hooks = append(c.hooks, h)
+}
+
+// background registers a hook running fn from Start until Stop cancels its context
+func (c *Container) background(name string, fn func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	c.hook(Hook{
+		Name: name,
+		Start: func(context.Context) error {
+			go func() {
+				defer close(done)
+				fn(ctx)
+			}()
+			return nil
+		},
+		Stop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	})
+}
+
+// bootWorkers registers the watchdog and the workers of the catalog, the status page and the exports
+func (c *Container) bootWorkers() (err error) {
+	libraryService, err := c.LibraryService()
+	if err != nil {
+		return
+	}
+
+	statusService, err := c.StatusService()
+	if err != nil {
+		return
+	}
+
+	exportService, err := c.ExportService()
+	if err != nil {
+		return
+	}
+
+	// Every background worker beats its heart with the group, the watchdog alerts on the ones that stop
+	c.background("watchdog", func(ctx context.Context) {
+		c.workers.Watch(ctx, c.configs.WATCHDOG.Interval, func(data worker.Stat) {
+			fields := []zap.Field{zap.String("worker", data.Name), zap.Bool("stuck", data.Stuck), zap.Bool("stalled", data.Stalled)}
+			if data.Healthy() {
+				c.logger.Info("worker is healthy again", fields...)
+				return
+			}
+			c.logger.Error("worker is stuck or stalled", fields...)
+		})
+	})
+
+	// The feeds of the member app and the related books are aggregated in the background until shutdown
+	feeds := c.workers.New("feeds", c.configs.FEED.Interval, c.configs.FEED.Interval)
+	c.background("feeds", func(ctx context.Context) {
+		libraryService.RunFeeds(ctx, c.configs.FEED.Interval, feeds)
+	})
+
+	related := c.workers.New("related", c.configs.RELATED.Interval, c.configs.RELATED.Deadline)
+	c.background("related", func(ctx context.Context) {
+		libraryService.RunRelations(ctx, c.configs.RELATED.Interval, related)
+	})
+
+	// The components on the status page are health checked in the background until shutdown
+	checks := c.workers.New("status", c.configs.STATUS.Interval, c.configs.STATUS.Interval)
+	c.background("status", func(ctx context.Context) {
+		statusService.RunChecks(ctx, c.configs.STATUS.Interval, c.configs.STATUS.Timeout, checks)
+	})
+
+	// The finished exports and their files are pruned in the background until shutdown
+	prune := c.workers.New("exports", c.configs.EXPORT.Interval, c.configs.EXPORT.Interval)
+	c.background("exports", func(ctx context.Context) {
+		exportService.RunPrune(ctx, c.configs.EXPORT.Interval, prune)
+	})
+
+	return
+}
+
+// bootServer registers the http server serving the handler
+func (c *Container) bootServer() (err error) {
+	handlers, err := c.Handler()
+	if err != nil {
+		return
+	}
+
+	servers, err := server.New(
+		server.WithHTTPServer(handlers.HTTP, c.configs.APP.Port))
+	if err != nil {
+		return fmt.Errorf("servers: %w", err)
+	}
+
+	c.hook(Hook{
+		Name: "servers",
+		Start: func(context.Context) error {
+			return servers.Run(c.logger)
+		},
+		Stop: servers.Stop,
+	})
+
+	return
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"library-service/internal/config"
+	"library-service/pkg/store"
+)
+
+const preflightTimeout = 5 * time.Second
+
+// preflight validates the configs and checks that external dependencies are reachable
+// before anything is wired, so all problems are reported at startup in one go
+func preflight(configs config.Configs) (err error) {
+	var problems config.ValidationError
+
+	if err = configs.Validate(); err != nil {
+		if !errors.As(err, &problems) {
+			return
+		}
+	}
+
+	// Skip network checks on developer machines
+	if configs.APP.Mode != "dev" && configs.CURRENCY.URL != "" {
+		if err = ping(configs.CURRENCY.URL); err != nil {
+			problems = append(problems, fmt.Sprintf("CURRENCY_URL: %s", err))
+		}
+	}
+
+	// the global token of the gateway is fetched as the payments are wired, so the OAuth endpoint has to answer
+	if configs.APP.Mode != "dev" && configs.EPAY.URL != "" {
+		if err = ping(configs.EPAY.OAuthURL); err != nil {
+			problems = append(problems, fmt.Sprintf("EPAY_OAUTH_URL: %s", err))
+		}
+	}
+
+	if configs.APP.Mode != "dev" && configs.POSTGRES.DSN != "" {
+		if err = migrated(configs.POSTGRES.DSN); err != nil {
+			problems = append(problems, fmt.Sprintf("POSTGRES_DSN: %s", err))
+		}
+	}
+
+	if len(problems) > 0 {
+		return problems
+	}
+
+	return nil
+}
+
+func ping(url string) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), preflightTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return
+	}
+	res.Body.Close()
+
+	if res.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("unhealthy response %s", res.Status)
+	}
+
+	return
+}
+
+// migrated checks that the schema of the store is at the latest migration and that none failed halfway
+func migrated(dataSourceName string) (err error) {
+	current, latest, dirty, err := store.MigrationVersion(dataSourceName)
+	switch {
+	case err != nil:
+		return
+	case dirty:
+		return fmt.Errorf("migration %d failed halfway, fix the schema and force the version", current)
+	case current != latest:
+		return fmt.Errorf("the schema is at migration %d, the latest is %d", current, latest)
+	}
+
+	return
+}
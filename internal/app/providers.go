@@ -0,0 +1,453 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"library-service/internal/cache"
+	"library-service/internal/config"
+	"library-service/internal/domain/health"
+	"library-service/internal/handler"
+	"library-service/internal/provider/currency"
+	"library-service/internal/provider/epay"
+	"library-service/internal/provider/openlibrary"
+	"library-service/internal/provider/webhook"
+	"library-service/internal/provider/wikidata"
+	"library-service/internal/repository"
+	"library-service/internal/service/auth"
+	"library-service/internal/service/export"
+	"library-service/internal/service/library"
+	"library-service/internal/service/payment"
+	"library-service/internal/service/status"
+	"library-service/internal/service/subscription"
+	"library-service/pkg/bulkhead"
+	"library-service/pkg/fault"
+	"library-service/pkg/storage"
+	"library-service/pkg/store"
+)
+
+// Every provider builds its group once, later calls return the same components or the same error
+
+type files struct {
+	once      sync.Once
+	err       error
+	storage   storage.Storage
+	urlSigner *storage.URLSigner
+}
+
+type data struct {
+	once         sync.Once
+	err          error
+	repositories *repository.Repository
+	caches       *cache.Cache
+}
+
+type identity struct {
+	once    sync.Once
+	err     error
+	service *auth.Service
+}
+
+type billing struct {
+	once    sync.Once
+	err     error
+	service *payment.Service
+}
+
+type catalog struct {
+	once    sync.Once
+	err     error
+	webhook *webhook.Client
+	service *library.Service
+}
+
+type monitoring struct {
+	once    sync.Once
+	err     error
+	service *status.Service
+}
+
+type membership struct {
+	once    sync.Once
+	err     error
+	service *subscription.Service
+}
+
+type exports struct {
+	once    sync.Once
+	err     error
+	service *export.Service
+}
+
+type presentation struct {
+	once    sync.Once
+	err     error
+	handler *handler.Handler
+}
+
+// transport puts the calls of an outbound dependency behind a bulkhead of its own, with FAULT_ENABLED
+// the faults are injected behind the bulkhead so that a slow call holds its slot like a slow dependency
+func (c *Container) transport(name string) http.RoundTripper {
+	var next http.RoundTripper
+	if c.configs.FAULT.Enabled {
+		next = fault.Transport{Injector: c.injector()}
+	}
+
+	return bulkhead.Transport{Bulkhead: c.bulkheads.New(name, c.configs.BULKHEAD.Limits[name], c.configs.BULKHEAD.Wait), Next: next}
+}
+
+func (c *Container) injector() fault.Injector {
+	return fault.Injector{
+		Latency:   c.configs.FAULT.Latency,
+		ErrorRate: c.configs.FAULT.ErrorRate,
+	}
+}
+
+// Storage returns the file storage and the signer of its urls
+func (c *Container) Storage() (storage.Storage, *storage.URLSigner, error) {
+	c.files.once.Do(func() { c.files.err = c.buildFiles() })
+	return c.files.storage, c.files.urlSigner, c.files.err
+}
+
+func (c *Container) buildFiles() (err error) {
+	if c.files.storage, err = storage.NewLocal(c.configs.STORAGE.Path); err != nil {
+		return fmt.Errorf("storage: %w", err)
+	}
+	c.files.urlSigner = storage.NewURLSigner(c.configs.STORAGE.Secret, strings.TrimSuffix(c.configs.APP.Path, "/")+"/files", c.configs.STORAGE.Expires)
+
+	return
+}
+
+// Repositories returns the repositories and the caches in front of them, both are closed on Stop
+func (c *Container) Repositories() (*repository.Repository, *cache.Cache, error) {
+	c.data.once.Do(func() { c.data.err = c.buildData() })
+	return c.data.repositories, c.data.caches, c.data.err
+}
+
+func (c *Container) buildData() (err error) {
+	if c.data.repositories, err = repository.New(repository.WithMemoryStore()); err != nil {
+		return fmt.Errorf("repositories: %w", err)
+	}
+	c.hook(Hook{Name: "repositories", Stop: func(context.Context) error {
+		c.data.repositories.Close()
+		return nil
+	}})
+
+	cacheConfigs := []cache.Configuration{cache.WithMemoryStore()}
+	if c.configs.FAULT.Enabled {
+		c.logger.Warn("fault injection is enabled", zap.Any("fault", c.configs.FAULT))
+		cacheConfigs = append(cacheConfigs, cache.WithFaultInjection(c.injector()))
+	}
+
+	c.data.caches, err = cache.New(
+		cache.Dependencies{
+			AuthorRepository: c.data.repositories.Author,
+			BookRepository:   c.data.repositories.Book,
+			CopyRepository:   c.data.repositories.Copy,
+		},
+		cacheConfigs...)
+	if err != nil {
+		return fmt.Errorf("caches: %w", err)
+	}
+	c.hook(Hook{Name: "caches", Stop: func(context.Context) error {
+		c.data.caches.Close()
+		return nil
+	}})
+
+	return
+}
+
+// AuthService returns the auth service, with the sessions of TOKEN_MODE=session when they are enabled
+func (c *Container) AuthService() (*auth.Service, error) {
+	c.identity.once.Do(func() { c.identity.err = c.buildIdentity() })
+	return c.identity.service, c.identity.err
+}
+
+func (c *Container) buildIdentity() (err error) {
+	var authConfigs []auth.Configuration
+	if c.configs.TOKEN.Mode == config.TokenModeSession {
+		sessionStore, err := store.NewRedis(c.configs.TOKEN.RedisURL)
+		if err != nil {
+			return fmt.Errorf("session store: %w", err)
+		}
+		c.hook(Hook{Name: "sessions", Stop: func(context.Context) error {
+			return sessionStore.Connection.Close()
+		}})
+
+		authConfigs = append(authConfigs, auth.WithSessions(auth.NewSessions(sessionStore.Connection, c.configs.TOKEN.Expires)))
+	}
+
+	if c.identity.service, err = auth.New(authConfigs...); err != nil {
+		return fmt.Errorf("auth service: %w", err)
+	}
+
+	return
+}
+
+// PaymentService returns the payment service, the epay gateway is only wired when EPAY_URL is set
+func (c *Container) PaymentService() (*payment.Service, error) {
+	c.billing.once.Do(func() { c.billing.err = c.buildBilling() })
+	return c.billing.service, c.billing.err
+}
+
+func (c *Container) buildBilling() (err error) {
+	// the currency client fetches the first rate as it is made, so it's only made once payments are needed
+	paymentConfigs := []payment.Configuration{
+		payment.WithCurrencyClient(currency.New(currency.Credentials{
+			URL:       c.configs.CURRENCY.URL,
+			Transport: c.transport("currency"),
+		})),
+	}
+	if c.configs.EPAY.URL != "" {
+		// with the redis token store a single replica refreshes the global token the others share
+		var tokenStore epay.TokenStore
+		if c.configs.EPAY.TokenStore == config.EpayTokenStoreRedis {
+			redisStore, err := store.NewRedis(c.configs.EPAY.RedisURL)
+			if err != nil {
+				return fmt.Errorf("epay token store: %w", err)
+			}
+			c.hook(Hook{Name: "tokens", Stop: func(context.Context) error {
+				return redisStore.Connection.Close()
+			}})
+
+			tokenStore = epay.NewRedisTokenStore(redisStore.Connection)
+		}
+
+		epayClient, err := epay.New(epay.Credentials{
+			URL:       c.configs.EPAY.URL,
+			OAuthURL:  c.configs.EPAY.OAuthURL,
+			Login:     c.configs.EPAY.Login,
+			Password:  c.configs.EPAY.Password,
+			StatusTTL: c.configs.EPAY.StatusTTL,
+			Transport: c.transport("epay"),
+
+			FailureThreshold: c.configs.EPAY.FailureThreshold,
+			RecoveryTimeout:  c.configs.EPAY.RecoveryTimeout,
+
+			TokenStore: tokenStore,
+			ClockSkew:  c.configs.EPAY.ClockSkew,
+		})
+		if err != nil {
+			return fmt.Errorf("epay client: %w", err)
+		}
+		paymentConfigs = append(paymentConfigs, payment.WithEpayClient(&epayClient))
+	}
+
+	if c.billing.service, err = payment.New(paymentConfigs...); err != nil {
+		return fmt.Errorf("payment service: %w", err)
+	}
+
+	return
+}
+
+// LibraryService returns the library service with the metadata, profile and notification providers that are enabled
+func (c *Container) LibraryService() (*library.Service, error) {
+	c.catalog.once.Do(func() { c.catalog.err = c.buildCatalog() })
+	return c.catalog.service, c.catalog.err
+}
+
+func (c *Container) buildCatalog() (err error) {
+	repositories, caches, err := c.Repositories()
+	if err != nil {
+		return
+	}
+
+	fileStorage, urlSigner, err := c.Storage()
+	if err != nil {
+		return
+	}
+
+	libraryConfigs := []library.Configuration{
+		library.WithAuthorRepository(repositories.Author),
+		library.WithBookRepository(repositories.Book),
+		library.WithCategoryRepository(repositories.Category),
+		library.WithCopyRepository(repositories.Copy),
+		library.WithCheckoutRepository(repositories.Checkout),
+		library.WithRelationRepository(repositories.Relation),
+		library.WithRevisionRepository(repositories.Revision),
+		library.WithReviewRepository(repositories.Review),
+		library.WithMemberRepository(repositories.Member),
+		library.WithLoanRepository(repositories.Loan),
+		library.WithSeriesRepository(repositories.Series),
+		library.WithSuggestionRepository(repositories.Suggestion),
+		library.WithWatchRepository(repositories.Watch),
+		library.WithShelfRepository(repositories.Shelf),
+		library.WithMergeRepository(repositories.Merge),
+		library.WithAuthorCache(caches.Author),
+		library.WithBookCache(caches.Book),
+		library.WithAvailabilityCache(caches.Availability),
+		library.WithCoverStorage(fileStorage, urlSigner),
+		library.WithFeedSize(c.configs.FEED.Size),
+	}
+	if c.configs.METADATA.Enabled {
+		libraryConfigs = append(libraryConfigs, library.WithMetadataProvider(openlibrary.New(openlibrary.Credentials{
+			URL:       c.configs.METADATA.URL,
+			Transport: c.transport("metadata"),
+		})))
+	}
+	if c.configs.WIKIDATA.Enabled {
+		libraryConfigs = append(libraryConfigs, library.WithProfileProvider(wikidata.New(wikidata.Credentials{
+			URL:       c.configs.WIKIDATA.URL,
+			Transport: c.transport("wikidata"),
+		})))
+	}
+	if c.configs.NOTIFY.URL != "" {
+		c.catalog.webhook = webhook.New(webhook.Credentials{
+			URL:       c.configs.NOTIFY.URL,
+			Secret:    c.configs.NOTIFY.Secret,
+			Transport: c.transport("notify"),
+		})
+		libraryConfigs = append(libraryConfigs, library.WithSuggestionNotifier(c.catalog.webhook), library.WithWatchNotifier(c.catalog.webhook))
+	}
+
+	if c.catalog.service, err = library.New(libraryConfigs...); err != nil {
+		return fmt.Errorf("library service: %w", err)
+	}
+
+	return
+}
+
+// StatusService returns the status service checking the store, the payments and the notifications when they are enabled
+func (c *Container) StatusService() (*status.Service, error) {
+	c.monitoring.once.Do(func() { c.monitoring.err = c.buildStatus() })
+	return c.monitoring.service, c.monitoring.err
+}
+
+func (c *Container) buildStatus() (err error) {
+	repositories, _, err := c.Repositories()
+	if err != nil {
+		return
+	}
+
+	paymentService, err := c.PaymentService()
+	if err != nil {
+		return
+	}
+
+	// the notification webhook is made with the catalog, whose notifiers send through it
+	if _, err = c.LibraryService(); err != nil {
+		return
+	}
+
+	statusConfigs := []status.Configuration{
+		status.WithIncidentRepository(repositories.Incident),
+		status.WithCheckRepository(repositories.Check),
+		status.WithCheck(health.ComponentAPI, repositories.Ping),
+		status.WithCheck(health.ComponentPayments, paymentService.Ping),
+	}
+	if paymentService.Gateway() {
+		statusConfigs = append(statusConfigs, status.WithBreaker(health.ComponentPayments, paymentService.Breaker))
+	}
+	if c.catalog.webhook != nil {
+		statusConfigs = append(statusConfigs, status.WithCheck(health.ComponentNotifications, c.catalog.webhook.Ping))
+	}
+
+	if c.monitoring.service, err = status.New(statusConfigs...); err != nil {
+		return fmt.Errorf("status service: %w", err)
+	}
+
+	return
+}
+
+// SubscriptionService returns the subscription service of the members
+func (c *Container) SubscriptionService() (*subscription.Service, error) {
+	c.membership.once.Do(func() { c.membership.err = c.buildMembership() })
+	return c.membership.service, c.membership.err
+}
+
+func (c *Container) buildMembership() (err error) {
+	repositories, _, err := c.Repositories()
+	if err != nil {
+		return
+	}
+
+	libraryService, err := c.LibraryService()
+	if err != nil {
+		return
+	}
+
+	c.membership.service, err = subscription.New(
+		subscription.WithMemberRepository(repositories.Member),
+		subscription.WithLibraryService(libraryService))
+	if err != nil {
+		return fmt.Errorf("subscription service: %w", err)
+	}
+
+	return
+}
+
+// ExportService returns the export service of the catalog
+func (c *Container) ExportService() (*export.Service, error) {
+	c.exports.once.Do(func() { c.exports.err = c.buildExports() })
+	return c.exports.service, c.exports.err
+}
+
+func (c *Container) buildExports() (err error) {
+	libraryService, err := c.LibraryService()
+	if err != nil {
+		return
+	}
+
+	fileStorage, urlSigner, err := c.Storage()
+	if err != nil {
+		return
+	}
+
+	c.exports.service, err = export.New(
+		export.WithLibraryService(libraryService),
+		export.WithStorage(fileStorage),
+		export.WithURLSigner(urlSigner),
+		export.WithTTL(c.configs.EXPORT.TTL))
+	if err != nil {
+		return fmt.Errorf("export service: %w", err)
+	}
+
+	return
+}
+
+// Handler returns the http handler of every service
+func (c *Container) Handler() (*handler.Handler, error) {
+	c.presentation.once.Do(func() { c.presentation.err = c.buildPresentation() })
+	return c.presentation.handler, c.presentation.err
+}
+
+func (c *Container) buildPresentation() (err error) {
+	d := handler.Dependencies{
+		Configs:   c.configs,
+		Bulkheads: c.bulkheads,
+		Workers:   c.workers,
+	}
+
+	if d.AuthService, err = c.AuthService(); err != nil {
+		return
+	}
+	if d.PaymentService, err = c.PaymentService(); err != nil {
+		return
+	}
+	if d.LibraryService, err = c.LibraryService(); err != nil {
+		return
+	}
+	if d.SubscriptionService, err = c.SubscriptionService(); err != nil {
+		return
+	}
+	if d.ExportService, err = c.ExportService(); err != nil {
+		return
+	}
+	if d.StatusService, err = c.StatusService(); err != nil {
+		return
+	}
+	if d.Storage, d.URLSigner, err = c.Storage(); err != nil {
+		return
+	}
+
+	if c.presentation.handler, err = handler.New(d, handler.WithHTTPHandler()); err != nil {
+		return fmt.Errorf("handlers: %w", err)
+	}
+
+	return
+}
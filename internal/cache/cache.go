@@ -1,16 +1,19 @@
 package cache
 
 import (
+	"library-service/internal/cache/fault"
 	"library-service/internal/cache/memory"
 	"library-service/internal/cache/redis"
 	"library-service/internal/domain/author"
 	"library-service/internal/domain/book"
+	faults "library-service/pkg/fault"
 	"library-service/pkg/store"
 )
 
 type Dependencies struct {
 	AuthorRepository author.Repository
 	BookRepository   book.Repository
+	CopyRepository   book.CopyRepository
 }
 
 // Configuration is an alias for a function that will take in a pointer to a Cache and modify it
@@ -20,9 +23,11 @@ type Configuration func(r *Cache) error
 type Cache struct {
 	dependencies Dependencies
 	redis        store.Redis
+	memory       []interface{ Close() }
 
-	Author author.Cache
-	Book   book.Cache
+	Author       author.Cache
+	Book         book.Cache
+	Availability book.AvailabilityCache
 }
 
 // New takes a variable amount of Configuration functions and returns a new Cache
@@ -47,6 +52,10 @@ func New(d Dependencies, configs ...Configuration) (s *Cache, err error) {
 // Close closes the cache and prevents new queries from starting.
 // Close then waits for all queries that have started processing on the server to finish.
 func (r *Cache) Close() {
+	for _, c := range r.memory {
+		c.Close()
+	}
+
 	if r.redis.Connection != nil {
 		r.redis.Connection.Close()
 	}
@@ -56,8 +65,14 @@ func (r *Cache) Close() {
 func WithMemoryStore() Configuration {
 	return func(s *Cache) (err error) {
 		// Create the memory database, if we needed parameters, such as connection strings they could be inputted here
-		s.Author = memory.NewAuthorCache(s.dependencies.AuthorRepository)
-		s.Book = memory.NewBookCache(s.dependencies.BookRepository)
+		authorCache := memory.NewAuthorCache(s.dependencies.AuthorRepository)
+		bookCache := memory.NewBookCache(s.dependencies.BookRepository)
+		availabilityCache := memory.NewAvailabilityCache(s.dependencies.CopyRepository)
+		s.memory = append(s.memory, authorCache, bookCache, availabilityCache)
+
+		s.Author = authorCache
+		s.Book = bookCache
+		s.Availability = availabilityCache
 
 		return
 	}
@@ -74,6 +89,18 @@ func WithRedisStore(url string) Configuration {
 
 		s.Author = redis.NewAuthorCache(s.redis.Connection, s.dependencies.AuthorRepository)
 		s.Book = redis.NewBookCache(s.redis.Connection, s.dependencies.BookRepository)
+		s.Availability = redis.NewAvailabilityCache(s.redis.Connection, s.dependencies.CopyRepository)
+
+		return
+	}
+}
+
+// WithFaultInjection wraps the caches applied so far with the fault injector, it must come after a store
+func WithFaultInjection(injector faults.Injector) Configuration {
+	return func(s *Cache) (err error) {
+		s.Author = fault.NewAuthorCache(s.Author, injector)
+		s.Book = fault.NewBookCache(s.Book, injector)
+		s.Availability = fault.NewAvailabilityCache(s.Availability, injector)
 
 		return
 	}
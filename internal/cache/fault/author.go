@@ -0,0 +1,29 @@
+package fault
+
+import (
+	"context"
+
+	"library-service/internal/domain/author"
+	"library-service/pkg/fault"
+)
+
+type AuthorCache struct {
+	cache    author.Cache
+	injector fault.Injector
+}
+
+func NewAuthorCache(c author.Cache, i fault.Injector) *AuthorCache {
+	return &AuthorCache{
+		cache:    c,
+		injector: i,
+	}
+}
+
+func (c *AuthorCache) Get(ctx context.Context, id string) (dest author.Entity, err error) {
+	// Fail or delay the call before it reaches the underlying cache
+	if err = c.injector.Inject(ctx); err != nil {
+		return
+	}
+
+	return c.cache.Get(ctx, id)
+}
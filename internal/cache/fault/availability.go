@@ -0,0 +1,34 @@
+package fault
+
+import (
+	"context"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/fault"
+)
+
+type AvailabilityCache struct {
+	cache    book.AvailabilityCache
+	injector fault.Injector
+}
+
+func NewAvailabilityCache(c book.AvailabilityCache, i fault.Injector) *AvailabilityCache {
+	return &AvailabilityCache{
+		cache:    c,
+		injector: i,
+	}
+}
+
+func (c *AvailabilityCache) GetMany(ctx context.Context, ids []string) (dest map[string]book.Availability, err error) {
+	// Fail or delay the call before it reaches the underlying cache
+	if err = c.injector.Inject(ctx); err != nil {
+		return
+	}
+
+	return c.cache.GetMany(ctx, ids)
+}
+
+// Delete isn't failed, a dropped delete would only leave the cache stale instead of making the call fail
+func (c *AvailabilityCache) Delete(ctx context.Context, id string) (err error) {
+	return c.cache.Delete(ctx, id)
+}
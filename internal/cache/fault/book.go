@@ -0,0 +1,29 @@
+package fault
+
+import (
+	"context"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/fault"
+)
+
+type BookCache struct {
+	cache    book.Cache
+	injector fault.Injector
+}
+
+func NewBookCache(c book.Cache, i fault.Injector) *BookCache {
+	return &BookCache{
+		cache:    c,
+		injector: i,
+	}
+}
+
+func (c *BookCache) Get(ctx context.Context, id string) (dest book.Entity, err error) {
+	// Fail or delay the call before it reaches the underlying cache
+	if err = c.injector.Inject(ctx); err != nil {
+		return
+	}
+
+	return c.cache.Get(ctx, id)
+}
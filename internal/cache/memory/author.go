@@ -4,18 +4,17 @@ import (
 	"context"
 	"time"
 
-	"github.com/patrickmn/go-cache"
-
 	"library-service/internal/domain/author"
+	"library-service/pkg/lru"
 )
 
 type AuthorCache struct {
-	cache      *cache.Cache
+	cache      *lru.Cache
 	repository author.Repository
 }
 
 func NewAuthorCache(r author.Repository) *AuthorCache {
-	c := cache.New(5*time.Minute, 10*time.Minute) // Cache with 5 minutes expiration and 10 minutes cleanup interval
+	c := lru.New(capacity, 5*time.Minute) // Cache with 5 minutes expiration
 	return &AuthorCache{
 		cache:      c,
 		repository: r,
@@ -36,7 +35,12 @@ func (c *AuthorCache) Get(ctx context.Context, id string) (dest author.Entity, e
 	}
 
 	// Store the retrieved data in the cache for future use
-	c.cache.Set(id, dest, cache.DefaultExpiration)
+	c.cache.Set(id, dest)
 
 	return
 }
+
+// Close stops expiring the entries of the cache
+func (c *AuthorCache) Close() {
+	c.cache.Close()
+}
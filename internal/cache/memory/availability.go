@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/lru"
+)
+
+type AvailabilityCache struct {
+	cache      *lru.Cache
+	repository book.CopyRepository
+}
+
+func NewAvailabilityCache(r book.CopyRepository) *AvailabilityCache {
+	c := lru.New(capacity, 30*time.Second) // Availability changes with every loan, so it is only kept for 30 seconds
+	return &AvailabilityCache{
+		cache:      c,
+		repository: r,
+	}
+}
+
+func (r *AvailabilityCache) GetMany(ctx context.Context, ids []string) (dest map[string]book.Availability, err error) {
+	dest = make(map[string]book.Availability, len(ids))
+
+	// Collect the books missing from the cache
+	var missing []string
+	for _, id := range ids {
+		if data, found := r.cache.Get(id); found {
+			dest[id] = data.(book.Availability)
+			continue
+		}
+		missing = append(missing, id)
+	}
+
+	if len(missing) == 0 {
+		return
+	}
+
+	// Count the copies of the missing books at once
+	copies, err := r.repository.ListByBooks(ctx, missing)
+	if err != nil {
+		return
+	}
+
+	for id, data := range book.ParseAvailabilities(missing, copies) {
+		dest[id] = data
+		r.cache.Set(id, data)
+	}
+
+	return
+}
+
+func (r *AvailabilityCache) Delete(ctx context.Context, id string) (err error) {
+	r.cache.Delete(id)
+	return
+}
+
+// Close stops expiring the entries of the cache
+func (r *AvailabilityCache) Close() {
+	r.cache.Close()
+}
@@ -4,18 +4,20 @@ import (
 	"context"
 	"time"
 
-	"github.com/patrickmn/go-cache"
-
 	"library-service/internal/domain/book"
+	"library-service/pkg/lru"
 )
 
+// capacity is the most entries a memory cache keeps, the least recently used are evicted first
+const capacity = 10000
+
 type BookCache struct {
-	cache      *cache.Cache
+	cache      *lru.Cache
 	repository book.Repository
 }
 
 func NewBookCache(r book.Repository) *BookCache {
-	c := cache.New(5*time.Minute, 10*time.Minute) // Cache with 5 minutes expiration and 10 minutes cleanup interval
+	c := lru.New(capacity, 5*time.Minute) // Cache with 5 minutes expiration
 	return &BookCache{
 		cache:      c,
 		repository: r,
@@ -36,7 +38,12 @@ func (r *BookCache) Get(ctx context.Context, id string) (dest book.Entity, err e
 	}
 
 	// Store the retrieved data in the cache for future use
-	r.cache.Set(id, dest, cache.DefaultExpiration)
+	r.cache.Set(id, dest)
 
 	return
 }
+
+// Close stops expiring the entries of the cache
+func (r *BookCache) Close() {
+	r.cache.Close()
+}
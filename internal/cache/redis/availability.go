@@ -0,0 +1,80 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"library-service/internal/domain/book"
+)
+
+const availabilityKeyPrefix = "availability:"
+
+type AvailabilityCache struct {
+	cache      *redis.Client
+	repository book.CopyRepository
+}
+
+func NewAvailabilityCache(c *redis.Client, r book.CopyRepository) *AvailabilityCache {
+	return &AvailabilityCache{
+		cache:      c,
+		repository: r,
+	}
+}
+
+func (c *AvailabilityCache) GetMany(ctx context.Context, ids []string) (dest map[string]book.Availability, err error) {
+	dest = make(map[string]book.Availability, len(ids))
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = availabilityKeyPrefix + id
+	}
+
+	// Read every book from Redis in one round trip, a failed read counts the books again
+	values, err := c.cache.MGet(ctx, keys...).Result()
+	if err != nil {
+		values = make([]any, len(ids))
+	}
+
+	var missing []string
+	for i, id := range ids {
+		var data book.Availability
+		if value, ok := values[i].(string); ok && json.Unmarshal([]byte(value), &data) == nil {
+			dest[id] = data
+			continue
+		}
+		missing = append(missing, id)
+	}
+
+	if len(missing) == 0 {
+		return dest, nil
+	}
+
+	// Count the copies of the missing books at once
+	copies, err := c.repository.ListByBooks(ctx, missing)
+	if err != nil {
+		return
+	}
+
+	// Store them with a single pipeline, availability changes with every loan so it is only kept for 30 seconds
+	pipe := c.cache.Pipeline()
+	for id, data := range book.ParseAvailabilities(missing, copies) {
+		dest[id] = data
+
+		payload, err := json.Marshal(data)
+		if err != nil {
+			return dest, err
+		}
+		pipe.Set(ctx, availabilityKeyPrefix+id, payload, 30*time.Second)
+	}
+
+	_, err = pipe.Exec(ctx)
+
+	return
+}
+
+func (c *AvailabilityCache) Delete(ctx context.Context, id string) (err error) {
+	return c.cache.Del(ctx, availabilityKeyPrefix+id).Err()
+}
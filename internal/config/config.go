@@ -1,57 +1,268 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
+	"gopkg.in/yaml.v2"
 )
 
 const (
 	defaultAppMode    = "dev"
+	defaultAppRole    = AppRoleAll
 	defaultAppPort    = "8080"
 	defaultAppPath    = "/"
 	defaultAppTimeout = 60 * time.Second
 
+	// exportBudgetPrefix is the route of the catalog export, it streams for as long as a large catalog takes
+	exportBudgetPrefix = "/admin/books/export"
+
 	defaultTokenSalt    = "IP03O5Ekg91g5jw=="
 	defaultTokenExpires = 3600 * time.Second
+	defaultTokenMode    = TokenModeStateless
+
+	defaultEpayTokenStore = EpayTokenStoreLocal
+
+	defaultCaptureLimit = 4 << 10
+
+	defaultBulkheadWait = 100 * time.Millisecond
+
+	defaultShedTarget     = 500 * time.Millisecond
+	defaultShedRetryAfter = 5 * time.Second
+
+	defaultFeedInterval = 5 * time.Minute
+	defaultFeedSize     = 200
+
+	defaultRelatedInterval = 24 * time.Hour
+	defaultRelatedDeadline = time.Hour
+
+	defaultWatchdogInterval = time.Minute
+
+	defaultStatusInterval = time.Minute
+	defaultStatusTimeout  = 5 * time.Second
+
+	defaultStoragePath    = "storage"
+	defaultStorageSecret  = "c3RvcmFnZS1zZWNyZXQ=="
+	defaultStorageExpires = 15 * time.Minute
+
+	defaultExportInterval = time.Hour
+	defaultExportTTL      = 24 * time.Hour
+
+	defaultConfigDir  = "configs"
+	defaultConfigFile = "config.yaml"
+	baseProfile       = "base"
+
+	secretMask = "xxxxx"
 )
 
 type (
 	Configs struct {
-		APP      AppConfig
-		TOKEN    TokenConfig
-		CURRENCY ClientConfig
-		POSTGRES StoreConfig
+		APP      AppConfig     `yaml:"app"`
+		TOKEN    TokenConfig   `yaml:"token"`
+		CURRENCY ClientConfig  `yaml:"currency"`
+		EPAY     GateConfig    `yaml:"epay"`
+		POSTGRES StoreConfig   `yaml:"postgres"`
+		FAULT    FaultConfig   `yaml:"fault"`
+		STORAGE  FileConfig    `yaml:"storage"`
+		EXPORT   ExportConfig  `yaml:"export"`
+		METADATA LookupConfig  `yaml:"metadata"`
+		WIKIDATA LookupConfig  `yaml:"wikidata"`
+		NOTIFY   HookConfig    `yaml:"notify"`
+		LOG      LogConfig     `yaml:"log"`
+		BULKHEAD BulkConfig    `yaml:"bulkhead"`
+		SHED     ShedConfig    `yaml:"shed"`
+		FEED     FeedConfig    `yaml:"feed"`
+		RELATED  RelatedConfig `yaml:"related"`
+		WATCHDOG WatchConfig   `yaml:"watchdog"`
+		STATUS   CheckConfig   `yaml:"status"`
 	}
 
+	// AppConfig.Mode is the profile of the deployment, see Profiles, while AppConfig.Role is what the
+	// process runs, see AppRoles, and the -mode flag overrides it.
+	// AppConfig.Budgets overrides the Timeout per path prefix, e.g. APP_BUDGETS='/exports:5s,/books:2s',
+	// /admin/books/export has no budget by default
 	AppConfig struct {
-		Mode    string `required:"true"`
-		Port    string
-		Path    string
-		Timeout time.Duration
+		Mode    string                   `yaml:"mode"`
+		Role    string                   `yaml:"role"`
+		Port    string                   `yaml:"port"`
+		Path    string                   `yaml:"path"`
+		Timeout time.Duration            `yaml:"timeout"`
+		Budgets map[string]time.Duration `yaml:"budgets"`
 	}
 
+	// TokenConfig.Mode picks stateless encrypted tokens or opaque session tokens kept at RedisURL,
+	// sessions slide their expiry on every use and can be revoked
 	TokenConfig struct {
-		Salt    string
-		Expires time.Duration
+		Salt     string        `yaml:"salt"`
+		Expires  time.Duration `yaml:"expires"`
+		Mode     string        `yaml:"mode"`
+		RedisURL string        `yaml:"redis_url" split_words:"true"`
 	}
 
 	ClientConfig struct {
-		URL      string
-		Login    string
-		Password string
+		URL      string `yaml:"url"`
+		Login    string `yaml:"login"`
+		Password string `yaml:"password"`
+	}
+
+	// GateConfig enables the epay payment gateway at URL with the OAuth client of Login and Password,
+	// a blank URL disables the payments. The status of an invoice is cached for StatusTTL. The breaker
+	// of the gateway opens after FailureThreshold consecutive failures and lets a probe through once
+	// it was open for RecoveryTimeout, 5 failures and 30s when they are zero.
+	// TokenStore picks whether every replica fetches its own global token or they share the one kept
+	// at RedisURL, which a single replica refreshes. The token is refreshed ClockSkew earlier than it expires.
+	GateConfig struct {
+		URL              string        `yaml:"url"`
+		OAuthURL         string        `yaml:"oauth_url" envconfig:"OAUTH_URL"`
+		Login            string        `yaml:"login"`
+		Password         string        `yaml:"password"`
+		StatusTTL        time.Duration `yaml:"status_ttl" split_words:"true"`
+		FailureThreshold int           `yaml:"failure_threshold" split_words:"true"`
+		RecoveryTimeout  time.Duration `yaml:"recovery_timeout" split_words:"true"`
+		TokenStore       string        `yaml:"token_store" split_words:"true"`
+		RedisURL         string        `yaml:"redis_url" split_words:"true"`
+		ClockSkew        time.Duration `yaml:"clock_skew" split_words:"true"`
 	}
 
 	StoreConfig struct {
-		DSN string
+		DSN string `yaml:"dsn"`
+	}
+
+	// FileConfig locates the stored files and how long their signed urls stay valid, a url is the same
+	// for a whole Expires period and stays valid for up to two of them. The urls are signed with Secret,
+	// which is kept apart from the salt of the tokens.
+	FileConfig struct {
+		Path    string        `yaml:"path"`
+		Secret  string        `yaml:"secret"`
+		Expires time.Duration `yaml:"expires"`
+	}
+
+	// ExportConfig sets how long the finished exports and their files are kept, they are pruned every interval
+	ExportConfig struct {
+		Interval time.Duration `yaml:"interval"`
+		TTL      time.Duration `yaml:"ttl"`
+	}
+
+	// LookupConfig enables an external catalog api at URL, METADATA fills in books by ISBN from
+	// OpenLibrary and WIKIDATA enriches authors by their identifiers
+	LookupConfig struct {
+		Enabled bool   `yaml:"enabled"`
+		URL     string `yaml:"url"`
+	}
+
+	// HookConfig posts the notifications of the members to a webhook at URL, a blank URL disables them.
+	// The body is signed with an HMAC-SHA256 of the Secret when it is set.
+	HookConfig struct {
+		URL    string `yaml:"url"`
+		Secret string `yaml:"secret"`
+	}
+
+	// LogConfig.Capture lists the path prefixes whose request and response bodies are logged at debug level,
+	// e.g. LOG_CAPTURE='/members,/books', cut at CaptureLimit bytes and with the Redact fields masked
+	LogConfig struct {
+		Capture      []string `yaml:"capture"`
+		CaptureLimit int      `yaml:"capture_limit" split_words:"true"`
+		Redact       []string `yaml:"redact"`
+	}
+
+	// BulkConfig.Limits caps the concurrent calls per outbound dependency, e.g. BULKHEAD_LIMITS='metadata:8,currency:4',
+	// a call waits up to Wait for a free slot before it is refused and a limit of zero lifts the cap
+	BulkConfig struct {
+		Limits map[string]int `yaml:"limits"`
+		Wait   time.Duration  `yaml:"wait"`
+	}
+
+	// ShedConfig.Limit caps the requests in flight, a limit of zero disables shedding. The limit adapts
+	// down while the latency is above Target and the requests are shed by the priority of their route,
+	// e.g. SHED_PRIORITIES='GET /books:low,/mobile/v1/members:high', the rest are of normal priority
+	ShedConfig struct {
+		Limit      int               `yaml:"limit"`
+		Target     time.Duration     `yaml:"target"`
+		RetryAfter time.Duration     `yaml:"retry_after" split_words:"true"`
+		Priorities map[string]string `yaml:"priorities"`
+	}
+
+	// FeedConfig sets how often the new arrivals and trending books are aggregated in the background
+	// and how many books each feed keeps
+	FeedConfig struct {
+		Interval time.Duration `yaml:"interval"`
+		Size     int           `yaml:"size"`
+	}
+
+	// RelatedConfig sets how often the books borrowed by the same members are related in the background
+	// and how long relating them may take before the watchdog reports the worker as stuck
+	RelatedConfig struct {
+		Interval time.Duration `yaml:"interval"`
+		Deadline time.Duration `yaml:"deadline"`
 	}
+
+	// WatchConfig sets how often the watchdog looks for background workers that got stuck or stopped,
+	// the feeds and status workers are stuck once a run takes longer than their interval
+	WatchConfig struct {
+		Interval time.Duration `yaml:"interval"`
+	}
+
+	// CheckConfig sets how often the components on the status page are health checked
+	// and how long a check may take before it fails
+	CheckConfig struct {
+		Interval time.Duration `yaml:"interval"`
+		Timeout  time.Duration `yaml:"timeout"`
+	}
+
+	// FaultConfig enables fault injection into outbound adapters, it is refused in prod
+	FaultConfig struct {
+		Enabled   bool          `yaml:"enabled"`
+		Latency   time.Duration `yaml:"latency"`
+		ErrorRate float64       `yaml:"error_rate" split_words:"true"`
+	}
+)
+
+const (
+	AppRoleAll    = "all"
+	AppRoleHTTP   = "http"
+	AppRoleWorker = "worker"
+)
+
+// AppRoles lists the supported values of APP_ROLE
+var AppRoles = []string{AppRoleAll, AppRoleHTTP, AppRoleWorker}
+
+const (
+	TokenModeStateless = "stateless"
+	TokenModeSession   = "session"
+)
+
+// TokenModes lists the supported values of TOKEN_MODE
+var TokenModes = []string{TokenModeStateless, TokenModeSession}
+
+const (
+	EpayTokenStoreLocal = "local"
+	EpayTokenStoreRedis = "redis"
 )
 
-// New populates Configs struct with values from config file
-// located at filepath and environment variables.
+// EpayTokenStores lists the supported values of EPAY_TOKEN_STORE
+var EpayTokenStores = []string{EpayTokenStoreLocal, EpayTokenStoreRedis}
+
+// ShedPriorities lists the supported values of SHED_PRIORITIES, from the first shed
+var ShedPriorities = []string{"low", "normal", "high"}
+
+// Profiles lists the supported values of APP_MODE, each has an optional
+// CONFIG_DIR/<mode>.yaml profile layered over CONFIG_DIR/base.yaml
+var Profiles = []string{"dev", "staging", "prod"}
+
+// New populates Configs struct, later sources overriding earlier ones:
+//  1. defaults
+//  2. CONFIG_DIR/base.yaml (configs/base.yaml by default)
+//  3. CONFIG_DIR/<mode>.yaml, where mode is APP_MODE or the base profile's app.mode
+//  4. the yaml file named by CONFIG_FILE (config.yaml by default)
+//  5. .env and environment variables
 func New() (cfg Configs, err error) {
 	root, err := os.Getwd()
 	if err != nil {
@@ -61,6 +272,7 @@ func New() (cfg Configs, err error) {
 
 	cfg.APP = AppConfig{
 		Mode:    defaultAppMode,
+		Role:    defaultAppRole,
 		Port:    defaultAppPort,
 		Path:    defaultAppPath,
 		Timeout: defaultAppTimeout,
@@ -69,19 +281,470 @@ func New() (cfg Configs, err error) {
 	cfg.TOKEN = TokenConfig{
 		Salt:    defaultTokenSalt,
 		Expires: defaultTokenExpires,
+		Mode:    defaultTokenMode,
+	}
+
+	cfg.EPAY = GateConfig{
+		TokenStore: defaultEpayTokenStore,
+	}
+
+	cfg.LOG = LogConfig{
+		CaptureLimit: defaultCaptureLimit,
+		Redact:       []string{"password", "client_secret", "access_token", "refresh_token", "secret", "token", "card", "cvv"},
+	}
+
+	cfg.BULKHEAD = BulkConfig{
+		Limits: map[string]int{"currency": 4, "epay": 4, "metadata": 8, "wikidata": 4, "notify": 4},
+		Wait:   defaultBulkheadWait,
+	}
+
+	cfg.SHED = ShedConfig{
+		Target:     defaultShedTarget,
+		RetryAfter: defaultShedRetryAfter,
+		Priorities: map[string]string{
+			"GET /authors":        "low",
+			"GET /books":          "low",
+			"GET /series":         "low",
+			"GET /mobile/v1/home": "low",
+			"/mobile/v1/members":  "high",
+			"/callbacks/epay":     "high",
+			"/revoke":             "high",
+			"/token":              "high",
+		},
+	}
+
+	cfg.FEED = FeedConfig{
+		Interval: defaultFeedInterval,
+		Size:     defaultFeedSize,
+	}
+
+	cfg.RELATED = RelatedConfig{
+		Interval: defaultRelatedInterval,
+		Deadline: defaultRelatedDeadline,
+	}
+
+	cfg.WATCHDOG = WatchConfig{
+		Interval: defaultWatchdogInterval,
+	}
+
+	cfg.STATUS = CheckConfig{
+		Interval: defaultStatusInterval,
+		Timeout:  defaultStatusTimeout,
+	}
+
+	cfg.STORAGE = FileConfig{
+		Path:    defaultStoragePath,
+		Secret:  defaultStorageSecret,
+		Expires: defaultStorageExpires,
+	}
+
+	cfg.EXPORT = ExportConfig{
+		Interval: defaultExportInterval,
+		TTL:      defaultExportTTL,
+	}
+
+	dir, ok := os.LookupEnv("CONFIG_DIR")
+	if !ok {
+		dir = filepath.Join(root, defaultConfigDir)
+	}
+
+	if err = cfg.loadFile(filepath.Join(dir, baseProfile+".yaml"), false); err != nil {
+		return
+	}
+
+	mode, ok := os.LookupEnv("APP_MODE")
+	if !ok {
+		mode = cfg.APP.Mode
+	}
+
+	if err = cfg.loadFile(filepath.Join(dir, mode+".yaml"), false); err != nil {
+		return
+	}
+
+	path, ok := os.LookupEnv("CONFIG_FILE")
+	if !ok {
+		path = filepath.Join(root, defaultConfigFile)
+	}
+
+	if err = cfg.loadFile(path, ok); err != nil {
+		return
 	}
 
 	if err = envconfig.Process("APP", &cfg.APP); err != nil {
 		return
 	}
 
+	// the export has no budget unless one is set for it, also when APP_BUDGETS replaced the budgets of the files
+	if _, ok := cfg.APP.Budgets[exportBudgetPrefix]; !ok {
+		if cfg.APP.Budgets == nil {
+			cfg.APP.Budgets = make(map[string]time.Duration)
+		}
+		cfg.APP.Budgets[exportBudgetPrefix] = 0
+	}
+
+	if err = envconfig.Process("TOKEN", &cfg.TOKEN); err != nil {
+		return
+	}
+
 	if err = envconfig.Process("CURRENCY", &cfg.CURRENCY); err != nil {
 		return
 	}
 
+	if err = envconfig.Process("EPAY", &cfg.EPAY); err != nil {
+		return
+	}
+
 	if err = envconfig.Process("POSTGRES", &cfg.POSTGRES); err != nil {
 		return
 	}
 
+	if err = envconfig.Process("FAULT", &cfg.FAULT); err != nil {
+		return
+	}
+
+	if err = envconfig.Process("STORAGE", &cfg.STORAGE); err != nil {
+		return
+	}
+
+	if err = envconfig.Process("EXPORT", &cfg.EXPORT); err != nil {
+		return
+	}
+
+	if err = envconfig.Process("METADATA", &cfg.METADATA); err != nil {
+		return
+	}
+
+	if err = envconfig.Process("WIKIDATA", &cfg.WIKIDATA); err != nil {
+		return
+	}
+
+	if err = envconfig.Process("NOTIFY", &cfg.NOTIFY); err != nil {
+		return
+	}
+
+	if err = envconfig.Process("LOG", &cfg.LOG); err != nil {
+		return
+	}
+
+	if err = envconfig.Process("BULKHEAD", &cfg.BULKHEAD); err != nil {
+		return
+	}
+
+	if err = envconfig.Process("SHED", &cfg.SHED); err != nil {
+		return
+	}
+
+	if err = envconfig.Process("FEED", &cfg.FEED); err != nil {
+		return
+	}
+
+	if err = envconfig.Process("RELATED", &cfg.RELATED); err != nil {
+		return
+	}
+
+	if err = envconfig.Process("WATCHDOG", &cfg.WATCHDOG); err != nil {
+		return
+	}
+
+	if err = envconfig.Process("STATUS", &cfg.STATUS); err != nil {
+		return
+	}
+
+	return
+}
+
+// loadFile overlays the configs with the yaml file, a missing file is only an error if it is required
+func (c *Configs) loadFile(path string, required bool) (err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !required && errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return
+	}
+
+	if err = yaml.UnmarshalStrict(data, c); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return
+}
+
+// String returns the configs in yaml with secrets masked
+func (c Configs) String() string {
+	if c.TOKEN.Salt != "" {
+		c.TOKEN.Salt = secretMask
+	}
+
+	if c.CURRENCY.Password != "" {
+		c.CURRENCY.Password = secretMask
+	}
+
+	if c.EPAY.Password != "" {
+		c.EPAY.Password = secretMask
+	}
+
+	if c.NOTIFY.Secret != "" {
+		c.NOTIFY.Secret = secretMask
+	}
+
+	if c.STORAGE.Secret != "" {
+		c.STORAGE.Secret = secretMask
+	}
+
+	if u, err := url.Parse(c.TOKEN.RedisURL); err == nil {
+		c.TOKEN.RedisURL = u.Redacted()
+	}
+
+	if u, err := url.Parse(c.EPAY.RedisURL); err == nil {
+		c.EPAY.RedisURL = u.Redacted()
+	}
+
+	if u, err := url.Parse(c.POSTGRES.DSN); err == nil {
+		c.POSTGRES.DSN = u.Redacted()
+	}
+
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err.Error()
+	}
+
+	return string(data)
+}
+
+// ValidationError lists every problem found in the configs
+type ValidationError []string
+
+func (e ValidationError) Error() string {
+	return "invalid configs:\n - " + strings.Join(e, "\n - ")
+}
+
+// Validate checks the configs and reports all problems at once
+func (c Configs) Validate() (err error) {
+	var problems ValidationError
+
+	if !contains(Profiles, c.APP.Mode) {
+		problems = append(problems, fmt.Sprintf("APP_MODE: %q must be one of %s", c.APP.Mode, strings.Join(Profiles, ", ")))
+	}
+
+	if !contains(AppRoles, c.APP.Role) {
+		problems = append(problems, fmt.Sprintf("APP_ROLE: %q must be one of %s", c.APP.Role, strings.Join(AppRoles, ", ")))
+	}
+
+	if port, err := strconv.Atoi(c.APP.Port); err != nil || port < 1 || port > 65535 {
+		problems = append(problems, fmt.Sprintf("APP_PORT: %q is not a valid port", c.APP.Port))
+	}
+
+	if c.APP.Timeout <= 0 {
+		problems = append(problems, "APP_TIMEOUT: must be positive")
+	}
+
+	for prefix, budget := range c.APP.Budgets {
+		if !strings.HasPrefix(prefix, "/") || budget < 0 {
+			problems = append(problems, fmt.Sprintf("APP_BUDGETS: %q must be a path prefix with a non-negative budget", prefix))
+		}
+	}
+
+	// the defaults are published in the repository, they only protect a dev setup
+	if c.TOKEN.Salt == "" {
+		problems = append(problems, "TOKEN_SALT: cannot be blank")
+	} else if c.TOKEN.Salt == defaultTokenSalt && c.APP.Mode != defaultAppMode {
+		problems = append(problems, fmt.Sprintf("TOKEN_SALT: cannot be the default outside of %s", defaultAppMode))
+	}
+
+	if c.TOKEN.Expires <= 0 {
+		problems = append(problems, "TOKEN_EXPIRES: must be positive")
+	}
+
+	if !contains(TokenModes, c.TOKEN.Mode) {
+		problems = append(problems, fmt.Sprintf("TOKEN_MODE: %q must be one of %s", c.TOKEN.Mode, strings.Join(TokenModes, ", ")))
+	}
+
+	if c.TOKEN.Mode == TokenModeSession && !strings.HasPrefix(c.TOKEN.RedisURL, "redis://") && !strings.HasPrefix(c.TOKEN.RedisURL, "rediss://") {
+		problems = append(problems, fmt.Sprintf("TOKEN_REDIS_URL: %q is not a redis url, it is required in the session mode", c.TOKEN.RedisURL))
+	}
+
+	if c.CURRENCY.URL == "" {
+		problems = append(problems, "CURRENCY_URL: cannot be blank")
+	} else if u, err := url.Parse(c.CURRENCY.URL); err != nil || u.Scheme == "" || u.Host == "" {
+		problems = append(problems, fmt.Sprintf("CURRENCY_URL: %q is not an absolute url", c.CURRENCY.URL))
+	}
+
+	if c.EPAY.URL != "" {
+		if u, err := url.Parse(c.EPAY.URL); err != nil || u.Scheme == "" || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("EPAY_URL: %q is not an absolute url", c.EPAY.URL))
+		}
+
+		if u, err := url.Parse(c.EPAY.OAuthURL); err != nil || u.Scheme == "" || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("EPAY_OAUTH_URL: %q is not an absolute url", c.EPAY.OAuthURL))
+		}
+
+		if c.EPAY.Login == "" {
+			problems = append(problems, "EPAY_LOGIN: cannot be blank")
+		}
+
+		if c.EPAY.Password == "" {
+			problems = append(problems, "EPAY_PASSWORD: cannot be blank")
+		}
+
+		if c.EPAY.StatusTTL < 0 {
+			problems = append(problems, "EPAY_STATUS_TTL: cannot be negative")
+		}
+
+		if c.EPAY.FailureThreshold < 0 {
+			problems = append(problems, "EPAY_FAILURE_THRESHOLD: cannot be negative")
+		}
+
+		if c.EPAY.RecoveryTimeout < 0 {
+			problems = append(problems, "EPAY_RECOVERY_TIMEOUT: cannot be negative")
+		}
+
+		if !contains(EpayTokenStores, c.EPAY.TokenStore) {
+			problems = append(problems, fmt.Sprintf("EPAY_TOKEN_STORE: %q must be one of %s", c.EPAY.TokenStore, strings.Join(EpayTokenStores, ", ")))
+		}
+
+		if c.EPAY.TokenStore == EpayTokenStoreRedis && !strings.HasPrefix(c.EPAY.RedisURL, "redis://") && !strings.HasPrefix(c.EPAY.RedisURL, "rediss://") {
+			problems = append(problems, fmt.Sprintf("EPAY_REDIS_URL: %q is not a redis url, it is required with the redis token store", c.EPAY.RedisURL))
+		}
+
+		if c.EPAY.ClockSkew < 0 {
+			problems = append(problems, "EPAY_CLOCK_SKEW: cannot be negative")
+		}
+	}
+
+	if c.POSTGRES.DSN != "" && !strings.Contains(c.POSTGRES.DSN, "://") {
+		problems = append(problems, "POSTGRES_DSN: undefined data source name")
+	}
+
+	if c.STORAGE.Path == "" {
+		problems = append(problems, "STORAGE_PATH: cannot be blank")
+	}
+
+	if c.STORAGE.Secret == "" {
+		problems = append(problems, "STORAGE_SECRET: cannot be blank")
+	} else if c.STORAGE.Secret == c.TOKEN.Salt {
+		problems = append(problems, "STORAGE_SECRET: cannot be the same as TOKEN_SALT")
+	} else if c.STORAGE.Secret == defaultStorageSecret && c.APP.Mode != defaultAppMode {
+		problems = append(problems, fmt.Sprintf("STORAGE_SECRET: cannot be the default outside of %s", defaultAppMode))
+	}
+
+	if c.STORAGE.Expires <= 0 {
+		problems = append(problems, "STORAGE_EXPIRES: must be positive")
+	}
+
+	if c.EXPORT.Interval <= 0 {
+		problems = append(problems, "EXPORT_INTERVAL: must be positive")
+	}
+
+	if c.EXPORT.TTL <= 0 {
+		problems = append(problems, "EXPORT_TTL: must be positive")
+	}
+
+	if c.METADATA.Enabled {
+		if u, err := url.Parse(c.METADATA.URL); err != nil || u.Scheme == "" || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("METADATA_URL: %q is not an absolute url", c.METADATA.URL))
+		}
+	}
+
+	if c.WIKIDATA.Enabled {
+		if u, err := url.Parse(c.WIKIDATA.URL); err != nil || u.Scheme == "" || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("WIKIDATA_URL: %q is not an absolute url", c.WIKIDATA.URL))
+		}
+	}
+
+	if c.NOTIFY.URL != "" {
+		if u, err := url.Parse(c.NOTIFY.URL); err != nil || u.Scheme == "" || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("NOTIFY_URL: %q is not an absolute url", c.NOTIFY.URL))
+		}
+	}
+
+	for _, prefix := range c.LOG.Capture {
+		if !strings.HasPrefix(prefix, "/") {
+			problems = append(problems, fmt.Sprintf("LOG_CAPTURE: %q must be a path prefix", prefix))
+		}
+	}
+
+	if len(c.LOG.Capture) > 0 && c.LOG.CaptureLimit <= 0 {
+		problems = append(problems, "LOG_CAPTURE_LIMIT: must be positive")
+	}
+
+	for name, limit := range c.BULKHEAD.Limits {
+		if limit < 0 {
+			problems = append(problems, fmt.Sprintf("BULKHEAD_LIMITS: %q cannot have a negative limit", name))
+		}
+	}
+
+	if c.BULKHEAD.Wait < 0 {
+		problems = append(problems, "BULKHEAD_WAIT: cannot be negative")
+	}
+
+	if c.SHED.Limit < 0 {
+		problems = append(problems, "SHED_LIMIT: cannot be negative")
+	}
+
+	if c.SHED.Limit > 0 && c.SHED.Target <= 0 {
+		problems = append(problems, "SHED_TARGET: must be positive")
+	}
+
+	for route, priority := range c.SHED.Priorities {
+		if !contains(ShedPriorities, priority) {
+			problems = append(problems, fmt.Sprintf("SHED_PRIORITIES: %q of %q must be one of %s", priority, route, strings.Join(ShedPriorities, ", ")))
+		}
+	}
+
+	if c.FEED.Interval <= 0 {
+		problems = append(problems, "FEED_INTERVAL: must be positive")
+	}
+
+	if c.FEED.Size <= 0 {
+		problems = append(problems, "FEED_SIZE: must be positive")
+	}
+
+	if c.RELATED.Interval <= 0 {
+		problems = append(problems, "RELATED_INTERVAL: must be positive")
+	}
+
+	if c.RELATED.Deadline < 0 {
+		problems = append(problems, "RELATED_DEADLINE: cannot be negative")
+	}
+
+	if c.WATCHDOG.Interval <= 0 {
+		problems = append(problems, "WATCHDOG_INTERVAL: must be positive")
+	}
+
+	if c.STATUS.Interval <= 0 {
+		problems = append(problems, "STATUS_INTERVAL: must be positive")
+	}
+
+	if c.STATUS.Timeout <= 0 || c.STATUS.Timeout > c.STATUS.Interval {
+		problems = append(problems, "STATUS_TIMEOUT: must be positive and no longer than STATUS_INTERVAL")
+	}
+
+	if c.FAULT.Enabled && c.APP.Mode == "prod" {
+		problems = append(problems, "FAULT_ENABLED: fault injection is not allowed in prod")
+	}
+
+	if c.FAULT.Latency < 0 {
+		problems = append(problems, "FAULT_LATENCY: cannot be negative")
+	}
+
+	if c.FAULT.ErrorRate < 0 || c.FAULT.ErrorRate > 1 {
+		problems = append(problems, "FAULT_ERROR_RATE: must be between 0 and 1")
+	}
+
+	if len(problems) > 0 {
+		err = problems
+	}
+
 	return
 }
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -3,12 +3,27 @@ package author
 import (
 	"errors"
 	"net/http"
+	"net/url"
+	"regexp"
 )
 
+var (
+	orcidPattern = regexp.MustCompile(`^\d{4}-\d{4}-\d{4}-\d{3}[\dX]$`)
+	viafPattern  = regexp.MustCompile(`^\d+$`)
+)
+
+// Request marks the identifiers, biography and photo it changes as edited by hand,
+// setting one of them blank hands it back to the enrichment
 type Request struct {
 	FullName  string `json:"fullName"`
 	Pseudonym string `json:"pseudonym"`
 	Specialty string `json:"specialty"`
+
+	ORCID     string `json:"orcid"`
+	VIAF      string `json:"viaf"`
+	Wikipedia string `json:"wikipedia"`
+	Biography string `json:"biography"`
+	Photo     string `json:"photo"`
 }
 
 func (s *Request) Bind(r *http.Request) error {
@@ -24,14 +39,35 @@ func (s *Request) Bind(r *http.Request) error {
 		return errors.New("specialty: cannot be blank")
 	}
 
+	if s.ORCID != "" && !orcidPattern.MatchString(s.ORCID) {
+		return errors.New("orcid: must look like 0000-0002-1825-0097")
+	}
+
+	if s.VIAF != "" && !viafPattern.MatchString(s.VIAF) {
+		return errors.New("viaf: must be digits")
+	}
+
+	if s.Photo != "" {
+		if u, err := url.Parse(s.Photo); err != nil || u.Scheme == "" || u.Host == "" {
+			return errors.New("photo: must be an absolute url")
+		}
+	}
+
 	return nil
 }
 
 type Response struct {
-	ID        string `json:"id"`
-	FullName  string `json:"fullName"`
-	Pseudonym string `json:"pseudonym"`
-	Specialty string `json:"specialty"`
+	ID        string `json:"id" validate:"required"`
+	FullName  string `json:"fullName" validate:"required"`
+	Pseudonym string `json:"pseudonym" validate:"required"`
+	Specialty string `json:"specialty" validate:"required"`
+
+	ORCID     string   `json:"orcid,omitempty"`
+	VIAF      string   `json:"viaf,omitempty"`
+	Wikipedia string   `json:"wikipedia,omitempty"`
+	Biography string   `json:"biography,omitempty"`
+	Photo     string   `json:"photo,omitempty"`
+	Edited    []string `json:"edited,omitempty"`
 }
 
 func ParseFromEntity(data Entity) (res Response) {
@@ -40,6 +76,12 @@ func ParseFromEntity(data Entity) (res Response) {
 		FullName:  *data.FullName,
 		Pseudonym: *data.Pseudonym,
 		Specialty: *data.Specialty,
+		ORCID:     valueOf(data.ORCID),
+		VIAF:      valueOf(data.VIAF),
+		Wikipedia: valueOf(data.Wikipedia),
+		Biography: valueOf(data.Biography),
+		Photo:     valueOf(data.Photo),
+		Edited:    data.Edited,
 	}
 	return
 }
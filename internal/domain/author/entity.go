@@ -5,4 +5,15 @@ type Entity struct {
 	FullName  *string `db:"full_name" bson:"full_name"`
 	Pseudonym *string `db:"pseudonym" bson:"pseudonym"`
 	Specialty *string `db:"specialty" bson:"specialty"`
+
+	// ORCID, VIAF and Wikipedia identify the author in external catalogs, Wikipedia is the title of the english article
+	ORCID     *string `db:"orcid" bson:"orcid"`
+	VIAF      *string `db:"viaf" bson:"viaf"`
+	Wikipedia *string `db:"wikipedia" bson:"wikipedia"`
+
+	Biography *string `db:"biography" bson:"biography"`
+	Photo     *string `db:"photo_url" bson:"photo_url"`
+
+	// Edited lists the EnrichedFields edited by hand, the enrichment leaves them as they are
+	Edited []string `db:"edited" bson:"edited"`
 }
@@ -0,0 +1,132 @@
+package author
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	FieldORCID     = "orcid"
+	FieldVIAF      = "viaf"
+	FieldWikipedia = "wikipedia"
+	FieldBiography = "biography"
+	FieldPhoto     = "photo"
+)
+
+// EnrichedFields lists the fields the enrichment fills in from the profile of the author
+var EnrichedFields = []string{FieldORCID, FieldVIAF, FieldWikipedia, FieldBiography, FieldPhoto}
+
+var (
+	// ErrorNoIdentifier is returned when an author without any identifier is enriched
+	ErrorNoIdentifier = errors.New("orcid, viaf, wikipedia: the author has no identifier to enrich by")
+	// ErrorNoProfile is returned when no profile is found by the identifiers of the author
+	ErrorNoProfile = errors.New("orcid, viaf, wikipedia: no profile is found by the identifiers of the author")
+	// ErrorEnrichmentDisabled is returned when the service has no profile provider
+	ErrorEnrichmentDisabled = errors.New("enrichment: is disabled")
+)
+
+// Identifiers are the external identifiers of the author, the blank ones are unknown
+type Identifiers struct {
+	ORCID     string
+	VIAF      string
+	Wikipedia string
+}
+
+func (i Identifiers) Empty() bool {
+	return i.ORCID == "" && i.VIAF == "" && i.Wikipedia == ""
+}
+
+// Profile is what an external catalog knows about an author
+type Profile struct {
+	Identifiers
+
+	Biography string
+	Photo     string
+}
+
+// ProfileProvider looks up the profile of the author by its identifiers, it returns
+// store.ErrorNotFound when none of them is known
+type ProfileProvider interface {
+	Lookup(ctx context.Context, ids Identifiers) (dest Profile, err error)
+}
+
+func IdentifiersOf(data Entity) Identifiers {
+	return Identifiers{
+		ORCID:     valueOf(data.ORCID),
+		VIAF:      valueOf(data.VIAF),
+		Wikipedia: valueOf(data.Wikipedia),
+	}
+}
+
+// Enrich returns the fields of the profile that differ from the author and were not edited by hand
+func Enrich(data Entity, profile Profile) (changes Entity, changed bool) {
+	values := map[string]string{
+		FieldORCID:     profile.ORCID,
+		FieldVIAF:      profile.VIAF,
+		FieldWikipedia: profile.Wikipedia,
+		FieldBiography: profile.Biography,
+		FieldPhoto:     profile.Photo,
+	}
+
+	for _, field := range EnrichedFields {
+		value := values[field]
+		if value == "" || value == valueOf(*fieldOf(&data, field)) || contains(data.Edited, field) {
+			continue
+		}
+		*fieldOf(&changes, field) = &value
+		changed = true
+	}
+
+	return
+}
+
+// EditedFields updates the Edited list of the author with the fields the data changes by hand,
+// a field set blank is no longer edited so that the enrichment fills it in again
+func EditedFields(current, data Entity) (edited []string) {
+	edited = make([]string, 0)
+	for _, field := range EnrichedFields {
+		before, after := *fieldOf(&current, field), *fieldOf(&data, field)
+
+		switch {
+		case after == nil || valueOf(after) == valueOf(before):
+			if contains(current.Edited, field) {
+				edited = append(edited, field)
+			}
+		case valueOf(after) != "":
+			edited = append(edited, field)
+		}
+	}
+
+	return
+}
+
+func fieldOf(data *Entity, field string) **string {
+	switch field {
+	case FieldORCID:
+		return &data.ORCID
+	case FieldVIAF:
+		return &data.VIAF
+	case FieldWikipedia:
+		return &data.Wikipedia
+	case FieldBiography:
+		return &data.Biography
+	default:
+		return &data.Photo
+	}
+}
+
+func valueOf(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -5,3 +5,11 @@ import "context"
 type Cache interface {
 	Get(ctx context.Context, id string) (dest Entity, err error)
 }
+
+// AvailabilityCache keeps the availability of the books for a short while,
+// the books it misses are counted together with a single batched query
+type AvailabilityCache interface {
+	GetMany(ctx context.Context, ids []string) (dest map[string]Availability, err error)
+	// Delete drops the availability of the book once its copies changed, e.g. one was checked out
+	Delete(ctx context.Context, id string) (err error)
+}
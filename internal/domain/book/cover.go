@@ -0,0 +1,46 @@
+package book
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+// MaxCoverSize limits the size of an uploaded cover image
+const MaxCoverSize = 5 << 20
+
+// MaxCoverWidth and MaxCoverHeight limit the pixels of an uploaded cover, a small file can claim
+// a size that takes gigabytes to decode
+const (
+	MaxCoverWidth  = 6000
+	MaxCoverHeight = 6000
+)
+
+// CoverTypes lists the content types accepted for cover images
+var CoverTypes = []string{"image/jpeg", "image/png"}
+
+// CoverVariant is a size the uploaded cover is resized to
+type CoverVariant struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+var (
+	CoverThumbnail = CoverVariant{Name: "thumbnail", Width: 160, Height: 240}
+	CoverDetail    = CoverVariant{Name: "detail", Width: 640, Height: 960}
+)
+
+var ErrorInvalidCover = errors.New("cover: must be a jpeg or png image of at most 5MB and 6000x6000 pixels")
+
+// CoverPrefix is the storage key prefix of the variants of an uploaded cover, it is derived from the content
+// of the upload so that the variants are never overwritten under their keys and their urls can be cached for good
+func CoverPrefix(id string, content []byte) string {
+	sum := sha256.Sum256(content)
+	return "covers/" + id + "/" + hex.EncodeToString(sum[:8])
+}
+
+// CoverKey is the storage key of the variant of an uploaded cover
+func CoverKey(prefix string, variant CoverVariant) string {
+	return prefix + "/" + variant.Name + ".jpg"
+}
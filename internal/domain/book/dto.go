@@ -1,40 +1,178 @@
 package book
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"time"
 )
 
+// ErrorIncomplete is returned when a book is created without a name or genre
+// and they can't be looked up by its ISBN
+var ErrorIncomplete = errors.New("name, genre: cannot be blank unless found by isbn")
+
+// Request leaves the name, genre, authors, year and cover blank to have
+// them filled in from the ISBN metadata, a blank description is left out
 type Request struct {
 	ID      string   `json:"id"`
 	Name    string   `json:"name"`
 	Genre   string   `json:"genre"`
-	ISBN    string   `json:"isbn"`
+	ISBN    ISBN     `json:"isbn"`
 	Authors []string `json:"authors"`
+	Year    int      `json:"year"`
+	Cover   string   `json:"cover"`
+
+	Description string `json:"description"`
+
+	Categories []string `json:"categories"`
+	Tags       []string `json:"tags"`
+
+	SeriesID string `json:"seriesId"`
+	Volume   int    `json:"volume"`
 }
 
 func (s *Request) Bind(r *http.Request) error {
-	if s.Name == "" {
+	if s.ISBN == "" {
+		return errors.New("isbn: cannot be blank")
+	}
+
+	isbn, err := ParseISBN(string(s.ISBN))
+	if err != nil {
+		return err
+	}
+	s.ISBN = isbn
+
+	if s.Year < 0 {
+		return errors.New("year: cannot be negative")
+	}
+
+	if s.Volume < 0 {
+		return errors.New("volume: cannot be negative")
+	}
+
+	if s.SeriesID == "" && s.Volume > 0 {
+		return errors.New("seriesId: cannot be blank for a volume")
+	}
+
+	return nil
+}
+
+// PatchRequest is a JSON merge patch for a book: fields that are absent are left untouched,
+// null removes the authors, categories or tags like an empty list does and leaves the other fields untouched.
+type PatchRequest struct {
+	Name    *string   `json:"name"`
+	Genre   *string   `json:"genre"`
+	ISBN    *ISBN     `json:"isbn"`
+	Authors *[]string `json:"authors"`
+	Year    *int      `json:"year"`
+	Cover   *string   `json:"cover"`
+
+	Description *string `json:"description"`
+
+	Categories *[]string `json:"categories"`
+	Tags       *[]string `json:"tags"`
+
+	// a blank seriesId takes the book out of its series
+	SeriesID *string `json:"seriesId"`
+	Volume   *int    `json:"volume"`
+}
+
+func (s *PatchRequest) UnmarshalJSON(data []byte) error {
+	type patch PatchRequest
+	if err := json.Unmarshal(data, (*patch)(s)); err != nil {
+		return err
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if _, ok := fields["authors"]; ok && s.Authors == nil {
+		s.Authors = &[]string{}
+	}
+
+	if _, ok := fields["categories"]; ok && s.Categories == nil {
+		s.Categories = &[]string{}
+	}
+
+	if _, ok := fields["tags"]; ok && s.Tags == nil {
+		s.Tags = &[]string{}
+	}
+
+	return nil
+}
+
+func (s *PatchRequest) Bind(r *http.Request) error {
+	if s.Name != nil && *s.Name == "" {
 		return errors.New("name: cannot be blank")
 	}
 
-	if s.Genre == "" {
+	if s.Genre != nil && *s.Genre == "" {
 		return errors.New("genre: cannot be blank")
 	}
 
-	if s.ISBN == "" {
-		return errors.New("isbn: cannot be blank")
+	if s.ISBN != nil {
+		if *s.ISBN == "" {
+			return errors.New("isbn: cannot be blank")
+		}
+
+		isbn, err := ParseISBN(string(*s.ISBN))
+		if err != nil {
+			return err
+		}
+		s.ISBN = &isbn
+	}
+
+	if s.Year != nil && *s.Year < 0 {
+		return errors.New("year: cannot be negative")
+	}
+
+	if s.Volume != nil && *s.Volume <= 0 {
+		return errors.New("volume: must be positive")
 	}
 
 	return nil
 }
 
 type Response struct {
-	ID      string   `json:"id"`
-	Name    string   `json:"name"`
-	Genre   string   `json:"genre"`
-	ISBN    string   `json:"isbn"`
-	Authors []string `json:"authors"`
+	ID      string   `json:"id" validate:"required"`
+	Name    string   `json:"name" validate:"required"`
+	Genre   string   `json:"genre" validate:"required"`
+	ISBN    ISBN     `json:"isbn" validate:"required"`
+	Authors []string `json:"authors" validate:"required"`
+	Year    int      `json:"year,omitempty"`
+
+	// ISBN13 is the ISBN-13 of the book with hyphens, the ISBN is kept in the edition it was entered in
+	ISBN13 string `json:"isbn13,omitempty"`
+	Cover  string `json:"cover,omitempty"`
+
+	Description string `json:"description,omitempty"`
+
+	Categories []string `json:"categories,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+
+	SeriesID string `json:"seriesId,omitempty"`
+	Volume   int    `json:"volume,omitempty"`
+
+	// Prev and Next are the neighbouring volumes of the series, they are only set on a single book
+	Prev *VolumeRef `json:"prev,omitempty"`
+	Next *VolumeRef `json:"next,omitempty"`
+
+	// Related are the books its readers also borrowed, they are only set on a single book
+	Related []RelatedBook `json:"related,omitempty"`
+
+	// Thumbnail is only set for uploaded covers
+	Thumbnail string `json:"thumbnail,omitempty"`
+
+	Rating      float64 `json:"rating,omitempty"`
+	ReviewCount int     `json:"reviewCount,omitempty"`
+
+	CreatedAt *time.Time `json:"createdAt,omitempty" format:"date-time"`
+
+	// DeletedAt is only set on deleted books listed with include_deleted
+	DeletedAt *time.Time `json:"deletedAt,omitempty" format:"date-time"`
 }
 
 func ParseFromEntity(data Entity) (res Response) {
@@ -43,8 +181,47 @@ func ParseFromEntity(data Entity) (res Response) {
 		Name:    *data.Name,
 		Genre:   *data.Genre,
 		ISBN:    *data.ISBN,
+		ISBN13:  data.ISBN.Hyphenated(),
 		Authors: data.Authors,
+
+		Categories: data.Categories,
+		Tags:       data.Tags,
+	}
+
+	// a book without authors lists none instead of null
+	if res.Authors == nil {
+		res.Authors = []string{}
+	}
+
+	if data.Year != nil {
+		res.Year = *data.Year
+	}
+
+	if data.Cover != nil {
+		res.Cover = *data.Cover
+	}
+
+	if data.Description != nil {
+		res.Description = *data.Description
+	}
+
+	if data.SeriesID != nil {
+		res.SeriesID = *data.SeriesID
+	}
+
+	if data.Volume != nil {
+		res.Volume = *data.Volume
+	}
+
+	if data.Rating != nil {
+		res.Rating = *data.Rating
 	}
+
+	if data.ReviewCount != nil {
+		res.ReviewCount = *data.ReviewCount
+	}
+	res.CreatedAt = data.CreatedAt
+	res.DeletedAt = data.DeletedAt
 	return
 }
 
@@ -55,3 +232,152 @@ func ParseFromEntities(data []Entity) (res []Response) {
 	}
 	return
 }
+
+// ErrorCheckedOut is returned when a copy that is out on loan is deleted, it has to be returned first
+var ErrorCheckedOut = errors.New("copy: is checked out and cannot be deleted until it is returned")
+
+type CopyRequest struct {
+	Barcode   string        `json:"barcode"`
+	Condition CopyCondition `json:"condition"`
+	Location  string        `json:"location"`
+	Status    CopyStatus    `json:"status"`
+}
+
+// Bind defaults the status of a new copy to available
+func (s *CopyRequest) Bind(r *http.Request) error {
+	if s.Barcode == "" {
+		return errors.New("barcode: cannot be blank")
+	}
+
+	if s.Location == "" {
+		return errors.New("location: cannot be blank")
+	}
+
+	if s.Condition == "" {
+		s.Condition = ConditionGood
+	}
+
+	if !containsCondition(s.Condition) {
+		return errors.New("condition: must be one of new, good, fair, poor")
+	}
+
+	if s.Status == "" {
+		s.Status = CopyAvailable
+	}
+
+	if !containsStatus(s.Status) {
+		return errors.New("status: must be one of available, checked_out, lost, withdrawn")
+	}
+
+	return nil
+}
+
+type CopyResponse struct {
+	ID        string        `json:"id" validate:"required"`
+	BookID    string        `json:"bookId" validate:"required"`
+	Barcode   string        `json:"barcode" validate:"required"`
+	Condition CopyCondition `json:"condition" validate:"required"`
+	Location  string        `json:"location" validate:"required"`
+	Status    CopyStatus    `json:"status" validate:"required"`
+}
+
+func ParseFromCopy(data Copy) (res CopyResponse) {
+	res = CopyResponse{
+		ID:        data.ID,
+		BookID:    data.BookID,
+		Barcode:   *data.Barcode,
+		Condition: *data.Condition,
+		Location:  *data.Location,
+		Status:    *data.Status,
+	}
+	return
+}
+
+func ParseFromCopies(data []Copy) (res []CopyResponse) {
+	res = make([]CopyResponse, 0)
+	for _, object := range data {
+		res = append(res, ParseFromCopy(object))
+	}
+	return
+}
+
+// Availability counts the copies of the book by status
+type Availability struct {
+	BookID    string `json:"bookId" validate:"required"`
+	Total     int    `json:"total" validate:"required"`
+	Available int    `json:"available" validate:"required"`
+	OnLoan    int    `json:"onLoan" validate:"required"`
+}
+
+// MaxAvailabilityBatch is the most books an AvailabilityRequest may ask for
+const MaxAvailabilityBatch = 200
+
+type AvailabilityRequest struct {
+	IDs []string `json:"ids"`
+}
+
+func (s *AvailabilityRequest) Bind(r *http.Request) error {
+	if len(s.IDs) == 0 {
+		return errors.New("ids: cannot be blank")
+	}
+
+	if len(s.IDs) > MaxAvailabilityBatch {
+		return fmt.Errorf("ids: cannot have more than %d books", MaxAvailabilityBatch)
+	}
+
+	for _, id := range s.IDs {
+		if id == "" {
+			return errors.New("ids: cannot have a blank id")
+		}
+	}
+
+	return nil
+}
+
+// ParseAvailabilities counts the copies of every book, a book without copies is not available
+func ParseAvailabilities(bookIDs []string, data []Copy) (res map[string]Availability) {
+	copies := make(map[string][]Copy, len(bookIDs))
+	for _, object := range data {
+		copies[object.BookID] = append(copies[object.BookID], object)
+	}
+
+	res = make(map[string]Availability, len(bookIDs))
+	for _, id := range bookIDs {
+		res[id] = ParseAvailability(id, copies[id])
+	}
+	return
+}
+
+func ParseAvailability(bookID string, data []Copy) (res Availability) {
+	res = Availability{BookID: bookID}
+	for _, object := range data {
+		switch *object.Status {
+		case CopyAvailable:
+			res.Available++
+		case CopyCheckedOut:
+			res.OnLoan++
+		case CopyLost, CopyWithdrawn:
+			continue
+		}
+		res.Total++
+	}
+	return
+}
+
+func containsCondition(value CopyCondition) bool {
+	for _, v := range CopyConditions {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsStatus(value CopyStatus) bool {
+	for _, v := range CopyStatuses {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,235 @@
+package book
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DuplicateTitleSimilarity is how alike, from 0 to 1, the normalized titles of two books
+// sharing an author must be for them to be taken as duplicates
+const DuplicateTitleSimilarity = 0.85
+
+// ErrorSelfMerge is returned when a book is merged into itself
+var ErrorSelfMerge = errors.New("duplicateId: cannot be the book itself")
+
+type DuplicateReason string
+
+const (
+	DuplicateISBN  DuplicateReason = "isbn"
+	DuplicateTitle DuplicateReason = "title"
+)
+
+type MergeRepository interface {
+	// Merge moves the copies, checkouts, reviews, watches and loans of the duplicate to the book and deletes the duplicate,
+	// either all of it happens or none. A review or a watch of the duplicate by a member who has one on the book too is dropped.
+	Merge(ctx context.Context, id, duplicateID string) (err error)
+}
+
+// Duplicates is a group of books that are likely the same one entered more than once, Reason is
+// DuplicateISBN when every book of the group has the same ISBN
+type Duplicates struct {
+	Reason DuplicateReason
+	Books  []Entity
+}
+
+type DuplicatesResponse struct {
+	Reason DuplicateReason `json:"reason" validate:"required"`
+	Books  []Response      `json:"books" validate:"required"`
+}
+
+type MergeRequest struct {
+	DuplicateID string `json:"duplicateId"`
+}
+
+func (s *MergeRequest) Bind(r *http.Request) error {
+	if s.DuplicateID == "" {
+		return errors.New("duplicateId: cannot be blank")
+	}
+
+	return nil
+}
+
+// FindDuplicates groups the books with the same ISBN-13 together with the books whose titles
+// are alike and that share an author. The groups and the books in them are ordered by id.
+func FindDuplicates(data []Entity) (dest []Duplicates) {
+	parent := make(map[string]string, len(data))
+	var root func(id string) string
+	root = func(id string) string {
+		if parent[id] != id {
+			parent[id] = root(parent[id])
+		}
+		return parent[id]
+	}
+
+	isbns := make(map[ISBN]string, len(data))
+	titles := make([]string, len(data))
+	for i, object := range data {
+		parent[object.ID] = object.ID
+		titles[i] = NormalizeTitle(valueOf(object.Name))
+
+		if object.ISBN == nil || *object.ISBN == "" {
+			continue
+		}
+		isbn := object.ISBN.ISBN13()
+
+		if id, ok := isbns[isbn]; ok {
+			parent[root(object.ID)] = root(id)
+			continue
+		}
+		isbns[isbn] = object.ID
+	}
+
+	byTitle := make(map[string]bool)
+	for i := range data {
+		for j := i + 1; j < len(data); j++ {
+			if root(data[i].ID) == root(data[j].ID) || !alike(data[i], data[j], titles[i], titles[j]) {
+				continue
+			}
+			parent[root(data[j].ID)] = root(data[i].ID)
+			byTitle[data[i].ID], byTitle[data[j].ID] = true, true
+		}
+	}
+
+	groups := make(map[string]*Duplicates)
+	for _, object := range data {
+		id := root(object.ID)
+		group, ok := groups[id]
+		if !ok {
+			group = &Duplicates{Reason: DuplicateISBN}
+			groups[id] = group
+		}
+		group.Books = append(group.Books, object)
+
+		if byTitle[object.ID] {
+			group.Reason = DuplicateTitle
+		}
+	}
+
+	for _, group := range groups {
+		if len(group.Books) < 2 {
+			continue
+		}
+
+		sort.Slice(group.Books, func(i, j int) bool {
+			return group.Books[i].ID < group.Books[j].ID
+		})
+		dest = append(dest, *group)
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].Books[0].ID < dest[j].Books[0].ID
+	})
+
+	return
+}
+
+func ParseFromDuplicates(data Duplicates, books []Response) DuplicatesResponse {
+	return DuplicatesResponse{
+		Reason: data.Reason,
+		Books:  books,
+	}
+}
+
+// NormalizeTitle lower-cases the title and drops its punctuation and leading article
+func NormalizeTitle(title string) string {
+	words := SearchTerms(title)
+	if len(words) > 1 {
+		switch words[0] {
+		case "a", "an", "the":
+			words = words[1:]
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// TitleSimilarity is one minus the edit distance between the titles relative to the longer one
+func TitleSimilarity(a, b string) float64 {
+	x, y := []rune(a), []rune(b)
+	if len(x) < len(y) {
+		x, y = y, x
+	}
+
+	if len(x) == 0 {
+		return 1
+	}
+
+	row := make([]int, len(y)+1)
+	for j := range row {
+		row[j] = j
+	}
+
+	for i := 1; i <= len(x); i++ {
+		prev := row[0]
+		row[0] = i
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+
+			next := min3(row[j]+1, row[j-1]+1, prev+cost)
+			prev, row[j] = row[j], next
+		}
+	}
+
+	return 1 - float64(row[len(y)])/float64(len(x))
+}
+
+// alike tells apart the books that only differ by their number, e.g. the volumes of a series
+func alike(a, b Entity, titleA, titleB string) bool {
+	if !shareAuthor(a.Authors, b.Authors) {
+		return false
+	}
+
+	if a.SeriesID != nil && b.SeriesID != nil && *a.SeriesID == *b.SeriesID && volumeOf(a) != volumeOf(b) {
+		return false
+	}
+
+	if numbersOf(titleA) != numbersOf(titleB) {
+		return false
+	}
+
+	return TitleSimilarity(titleA, titleB) >= DuplicateTitleSimilarity
+}
+
+func shareAuthor(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func numbersOf(title string) string {
+	return strings.Join(strings.FieldsFunc(title, func(r rune) bool {
+		return !unicode.IsDigit(r)
+	}), " ")
+}
+
+func valueOf(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+
+	if c < a {
+		a = c
+	}
+
+	return a
+}
@@ -1,9 +1,72 @@
 package book
 
+import (
+	"time"
+)
+
 type Entity struct {
 	ID      string   `db:"id" bson:"_id"`
 	Name    *string  `db:"name" bson:"name"`
 	Genre   *string  `db:"genre" bson:"genre"`
-	ISBN    *string  `db:"isbn" bson:"isbn"`
+	ISBN    *ISBN    `db:"isbn" bson:"isbn"`
 	Authors []string `db:"authors" bson:"authors"`
+	Year    *int     `db:"year" bson:"year"`
+	Cover   *string  `db:"cover_url" bson:"cover_url"`
+
+	// Description is searched along with the name, the genre and the names of the authors
+	Description *string `db:"description" bson:"description"`
+
+	// Categories are ids of the category tree, Tags are free-form lowercase words
+	Categories []string `db:"categories" bson:"categories"`
+	Tags       []string `db:"tags" bson:"tags"`
+
+	// SeriesID and Volume place the book in a series, they are updated together
+	// and a blank SeriesID takes the book out of its series
+	SeriesID *string `db:"series_id" bson:"series_id"`
+	Volume   *int    `db:"volume" bson:"volume"`
+
+	// CoverKey is the storage key prefix of an uploaded cover, it takes precedence over the Cover url
+	CoverKey *string `db:"cover_key" bson:"cover_key"`
+
+	// Rating and ReviewCount summarize the reviews that are not hidden
+	Rating      *float64 `db:"rating" bson:"rating"`
+	ReviewCount *int     `db:"review_count" bson:"review_count"`
+
+	// CreatedAt is when the book was added to the catalog, the new arrivals are the latest ones
+	CreatedAt *time.Time `db:"created_at" bson:"created_at"`
+
+	// DeletedAt is set once the book is deleted, deleted books are left out of every read but ListWithDeleted
+	DeletedAt *time.Time `db:"deleted_at" bson:"deleted_at"`
+}
+
+type CopyStatus string
+
+const (
+	CopyAvailable  CopyStatus = "available"
+	CopyCheckedOut CopyStatus = "checked_out"
+	CopyLost       CopyStatus = "lost"
+	CopyWithdrawn  CopyStatus = "withdrawn"
+)
+
+var CopyStatuses = []CopyStatus{CopyAvailable, CopyCheckedOut, CopyLost, CopyWithdrawn}
+
+type CopyCondition string
+
+const (
+	ConditionNew  CopyCondition = "new"
+	ConditionGood CopyCondition = "good"
+	ConditionFair CopyCondition = "fair"
+	ConditionPoor CopyCondition = "poor"
+)
+
+var CopyConditions = []CopyCondition{ConditionNew, ConditionGood, ConditionFair, ConditionPoor}
+
+// Copy is a physical copy of the book on the shelves, identified by its barcode
+type Copy struct {
+	ID        string         `db:"id" bson:"_id"`
+	BookID    string         `db:"book_id" bson:"book_id"`
+	Barcode   *string        `db:"barcode" bson:"barcode"`
+	Condition *CopyCondition `db:"condition" bson:"condition"`
+	Location  *string        `db:"location" bson:"location"`
+	Status    *CopyStatus    `db:"status" bson:"status"`
 }
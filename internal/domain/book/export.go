@@ -0,0 +1,237 @@
+package book
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportColumns are the columns of a book export, a blank selection exports all of them in this order
+var ExportColumns = []string{
+	"id", "name", "genre", "isbn", "isbn13", "authors", "year", "cover", "description",
+	"categories", "tags", "seriesId", "volume", "rating", "reviewCount", "createdAt",
+}
+
+type ExportFormat string
+
+const (
+	ExportCSV   ExportFormat = "csv"
+	ExportJSONL ExportFormat = "jsonl"
+)
+
+var (
+	ErrorExportFormat = errors.New("format: must be one of csv, jsonl")
+	ErrorExportColumn = errors.New("columns: must be among " + strings.Join(ExportColumns, ", "))
+)
+
+// ParseExportFormat defaults to CSV, the format of the export jobs
+func ParseExportFormat(value string) (ExportFormat, error) {
+	switch ExportFormat(value) {
+	case "", ExportCSV:
+		return ExportCSV, nil
+	case ExportJSONL:
+		return ExportJSONL, nil
+	}
+
+	return "", ErrorExportFormat
+}
+
+// ContentType is the media type of the export
+func (f ExportFormat) ContentType() string {
+	if f == ExportJSONL {
+		return "application/x-ndjson"
+	}
+	return "text/csv"
+}
+
+// ParseExportColumns splits the comma separated columns, a column selected twice is exported once
+func ParseExportColumns(value string) (columns []string, err error) {
+	if value == "" {
+		return ExportColumns, nil
+	}
+
+	known := make(map[string]bool, len(ExportColumns))
+	for _, column := range ExportColumns {
+		known[column] = true
+	}
+
+	selected := make(map[string]bool)
+	for _, column := range strings.Split(value, ",") {
+		column = strings.TrimSpace(column)
+		if !known[column] {
+			return nil, ErrorExportColumn
+		}
+
+		if !selected[column] {
+			selected[column] = true
+			columns = append(columns, column)
+		}
+	}
+
+	return
+}
+
+// Exporter writes the books one at a time as the rows of a CSV with a header or as JSON Lines,
+// the lists of a CSV row are joined with semicolons
+type Exporter struct {
+	format  ExportFormat
+	columns []string
+
+	w      io.Writer
+	csv    *csv.Writer
+	header bool
+}
+
+func NewExporter(w io.Writer, format ExportFormat, columns []string) *Exporter {
+	return &Exporter{
+		format:  format,
+		columns: columns,
+		w:       w,
+		csv:     csv.NewWriter(w),
+	}
+}
+
+func (e *Exporter) Write(data Response) error {
+	if e.format == ExportJSONL {
+		return e.writeJSON(data)
+	}
+
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	row := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		row[i] = textOf(exportValue(data, column))
+	}
+
+	return e.csv.Write(row)
+}
+
+// Flush writes out the buffered rows, a CSV export of no books still has its header
+func (e *Exporter) Flush() error {
+	if e.format == ExportJSONL {
+		return nil
+	}
+
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.csv.Flush()
+
+	return e.csv.Error()
+}
+
+func (e *Exporter) writeHeader() error {
+	if e.header {
+		return nil
+	}
+	e.header = true
+
+	return e.csv.Write(e.columns)
+}
+
+// writeJSON keeps the keys of the line in the order of the columns
+func (e *Exporter) writeJSON(data Response) error {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, column := range e.columns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, _ := json.Marshal(column)
+		value, err := json.Marshal(exportValue(data, column))
+		if err != nil {
+			return err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteString("}\n")
+
+	_, err := e.w.Write(buf.Bytes())
+	return err
+}
+
+func exportValue(data Response, column string) any {
+	switch column {
+	case "id":
+		return data.ID
+	case "name":
+		return data.Name
+	case "genre":
+		return data.Genre
+	case "isbn":
+		return data.ISBN
+	case "isbn13":
+		return data.ISBN13
+	case "authors":
+		return listOf(data.Authors)
+	case "year":
+		return data.Year
+	case "cover":
+		return data.Cover
+	case "description":
+		return data.Description
+	case "categories":
+		return listOf(data.Categories)
+	case "tags":
+		return listOf(data.Tags)
+	case "seriesId":
+		return data.SeriesID
+	case "volume":
+		return data.Volume
+	case "rating":
+		return data.Rating
+	case "reviewCount":
+		return data.ReviewCount
+	case "createdAt":
+		return data.CreatedAt
+	}
+
+	return nil
+}
+
+// textOf is the CSV cell of the value, a zero number or a missing time is left blank
+func textOf(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case ISBN:
+		return v.String()
+	case []string:
+		return strings.Join(v, ";")
+	case int:
+		if v == 0 {
+			return ""
+		}
+		return strconv.Itoa(v)
+	case float64:
+		if v == 0 {
+			return ""
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	}
+
+	return ""
+}
+
+func listOf(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,83 @@
+package book
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultFeedLimit = 20
+	MaxFeedLimit     = 100
+
+	// DefaultFeedSize is how many books each feed keeps, the pages past it are empty
+	DefaultFeedSize = 200
+
+	DefaultTrendingWindow = "7d"
+)
+
+// TrendingWindows are the periods the checkouts of the trending books are counted over, by their name
+var TrendingWindows = map[string]time.Duration{
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+}
+
+// ErrorTrendingWindow is returned for a window that is not one of the TrendingWindows
+var ErrorTrendingWindow = errors.New("window: must be one of 7d, 30d")
+
+// Checkout records a copy of the book being checked out, the trending books are counted from them
+type Checkout struct {
+	ID        string    `db:"id" bson:"_id"`
+	BookID    string    `db:"book_id" bson:"book_id"`
+	CopyID    string    `db:"copy_id" bson:"copy_id"`
+	CreatedAt time.Time `db:"created_at" bson:"created_at"`
+}
+
+// NewArrivals orders the books by when they were added to the catalog, the latest first,
+// and keeps at most size of them. Books without a creation time are left out.
+func NewArrivals(data []Entity, size int) (dest []Entity) {
+	dest = make([]Entity, 0, len(data))
+	for _, object := range data {
+		if object.CreatedAt != nil {
+			dest = append(dest, object)
+		}
+	}
+
+	sort.SliceStable(dest, func(i, j int) bool {
+		if !dest[i].CreatedAt.Equal(*dest[j].CreatedAt) {
+			return dest[i].CreatedAt.After(*dest[j].CreatedAt)
+		}
+		return dest[i].ID < dest[j].ID
+	})
+
+	if len(dest) > size {
+		dest = dest[:size]
+	}
+
+	return
+}
+
+// Trending orders the books by their number of checkouts in counts, the most checked out first,
+// and keeps at most size of them. Books that were not checked out are left out.
+func Trending(data []Entity, counts map[string]int, size int) (dest []Entity) {
+	dest = make([]Entity, 0, len(counts))
+	for _, object := range data {
+		if counts[object.ID] > 0 {
+			dest = append(dest, object)
+		}
+	}
+
+	sort.SliceStable(dest, func(i, j int) bool {
+		if counts[dest[i].ID] != counts[dest[j].ID] {
+			return counts[dest[i].ID] > counts[dest[j].ID]
+		}
+		return strings.ToLower(valueOf(dest[i].Name)) < strings.ToLower(valueOf(dest[j].Name))
+	})
+
+	if len(dest) > size {
+		dest = dest[:size]
+	}
+
+	return
+}
@@ -0,0 +1,217 @@
+package book
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrorISBN is returned for a value that is neither an ISBN-10 nor an ISBN-13 with a valid check digit
+var ErrorISBN = errors.New("isbn: must be a valid ISBN-10 or ISBN-13")
+
+// ISBN is the number of a book in either edition, kept in its compact form without separators.
+// Two ISBNs are the same book when their ISBN-13 is, whichever edition either was entered in.
+type ISBN string
+
+// isbnRange is a run of the digits after a prefix whose leading digits, Length of them, form the next
+// part of the number, e.g. the registrant within the group
+type isbnRange struct {
+	From, To string
+	Length   int
+}
+
+// isbnGroups splits the registration groups of the 978 and 979 prefixes
+var isbnGroups = map[string][]isbnRange{
+	"978": {
+		{"0000000", "5999999", 1},
+		{"6000000", "6499999", 3},
+		{"6500000", "6599999", 2},
+		{"7000000", "7999999", 1},
+		{"8000000", "9499999", 2},
+		{"9500000", "9899999", 3},
+		{"9900000", "9989999", 4},
+		{"9990000", "9999999", 5},
+	},
+	"979": {
+		{"1000000", "1299999", 2},
+		{"8000000", "8999999", 1},
+	},
+}
+
+// isbnRegistrants splits the registrants of the English language groups, the registrant and the
+// publication of the other groups are formatted together
+var isbnRegistrants = map[string][]isbnRange{
+	"978-0": {
+		{"000000", "199999", 2},
+		{"200000", "699999", 3},
+		{"700000", "849999", 4},
+		{"850000", "899999", 5},
+		{"900000", "949999", 6},
+		{"950000", "999999", 7},
+	},
+	"978-1": {
+		{"000000", "099999", 2},
+		{"100000", "399999", 3},
+		{"400000", "549999", 4},
+		{"550000", "869799", 5},
+		{"869800", "998999", 6},
+		{"999000", "999999", 7},
+	},
+}
+
+// ParseISBN strips the hyphens and spaces of the value and checks the check digit of its edition
+func ParseISBN(value string) (ISBN, error) {
+	isbn := ISBN(value).compact()
+
+	if !isbn.Valid() {
+		return "", ErrorISBN
+	}
+
+	return isbn, nil
+}
+
+// Valid reports whether the ISBN is in its compact form with the check digit of its edition
+func (i ISBN) Valid() bool {
+	s := string(i)
+	switch len(s) {
+	case 10:
+		if !digits(s[:9]) || !digits(s[9:]) && s[9] != 'X' {
+			return false
+		}
+		return checkDigit10(s[:9]) == s[9]
+	case 13:
+		if !digits(s) || !strings.HasPrefix(s, "978") && !strings.HasPrefix(s, "979") {
+			return false
+		}
+		return checkDigit13(s[:12]) == s[12]
+	}
+
+	return false
+}
+
+// ISBN13 converts an ISBN-10 to its ISBN-13, any other value is only stripped of its separators
+func (i ISBN) ISBN13() ISBN {
+	isbn := i.compact()
+	if len(isbn) != 10 || !digits(string(isbn[:9])) {
+		return isbn
+	}
+
+	s := "978" + string(isbn[:9])
+	return ISBN(s + string(checkDigit13(s)))
+}
+
+// ISBN10 converts an ISBN-13 to its ISBN-10, only the ISBNs with the 978 prefix have one
+func (i ISBN) ISBN10() (ISBN, bool) {
+	isbn := i.ISBN13()
+	if !isbn.Valid() || !strings.HasPrefix(string(isbn), "978") {
+		return "", false
+	}
+
+	s := string(isbn[3:12])
+	return ISBN(s + string(checkDigit10(s))), true
+}
+
+// Equal reports whether both ISBNs are the same book, whichever edition they are in
+func (i ISBN) Equal(other ISBN) bool {
+	isbn := i.ISBN13()
+	return isbn != "" && isbn == other.ISBN13()
+}
+
+// Hyphenated formats the ISBN-13 of a valid ISBN with hyphens between the prefix, the group,
+// the registrant, the publication and the check digit, an invalid one is returned as is
+func (i ISBN) Hyphenated() string {
+	isbn := i.ISBN13()
+	if !isbn.Valid() {
+		return string(i)
+	}
+	s := string(isbn)
+
+	prefix, rest, check := s[:3], s[3:12], s[12:]
+
+	n := lengthOf(isbnGroups[prefix], rest)
+	if n == 0 {
+		return strings.Join([]string{prefix, rest, check}, "-")
+	}
+	group, rest := rest[:n], rest[n:]
+
+	n = lengthOf(isbnRegistrants[prefix+"-"+group], rest)
+	if n == 0 || n >= len(rest) {
+		return strings.Join([]string{prefix, group, rest, check}, "-")
+	}
+
+	return strings.Join([]string{prefix, group, rest[:n], rest[n:], check}, "-")
+}
+
+func (i ISBN) String() string {
+	return string(i)
+}
+
+// compact strips the hyphens and spaces and uppercases the X of the check digit of an ISBN-10,
+// any other character is kept so that the ISBN isn't valid
+func (i ISBN) compact() ISBN {
+	return ISBN(strings.Map(func(r rune) rune {
+		switch r {
+		case '-', ' ':
+			return -1
+		case 'x':
+			return 'X'
+		}
+		return r
+	}, string(i)))
+}
+
+// lengthOf is the length of the range the digits are in, zero when none of the ranges has them
+func lengthOf(ranges []isbnRange, s string) int {
+	for _, object := range ranges {
+		key := s
+		if len(key) > len(object.From) {
+			key = key[:len(object.From)]
+		}
+
+		for len(key) < len(object.From) {
+			key += "0"
+		}
+
+		if key >= object.From && key <= object.To {
+			return object.Length
+		}
+	}
+
+	return 0
+}
+
+func checkDigit10(s string) byte {
+	sum := 0
+	for i, r := range s {
+		sum += int(r-'0') * (10 - i)
+	}
+
+	switch check := (11 - sum%11) % 11; check {
+	case 10:
+		return 'X'
+	default:
+		return byte('0' + check)
+	}
+}
+
+func checkDigit13(s string) byte {
+	sum := 0
+	for i, r := range s {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += int(r-'0') * weight
+	}
+
+	return byte('0' + (10-sum%10)%10)
+}
+
+func digits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return s != ""
+}
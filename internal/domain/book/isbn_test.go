@@ -0,0 +1,149 @@
+package book
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseISBN(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  ISBN
+		err   error
+	}{
+		{"isbn-10", "0306406152", "0306406152", nil},
+		{"isbn-13", "9780306406157", "9780306406157", nil},
+		{"hyphens", "978-0-306-40615-7", "9780306406157", nil},
+		{"spaces", "0 306 40615 2", "0306406152", nil},
+		{"lowercase x", "080442957x", "080442957X", nil},
+		{"979 prefix", "979-10-90636-07-1", "9791090636071", nil},
+		{"wrong check digit of isbn-10", "0306406153", "", ErrorISBN},
+		{"wrong check digit of isbn-13", "9780306406158", "", ErrorISBN},
+		{"x of isbn-13", "978030640615X", "", ErrorISBN},
+		{"x inside isbn-10", "03064X6152", "", ErrorISBN},
+		{"unknown prefix", "9770306406150", "", ErrorISBN},
+		{"letters around", "foo0306406152bar", "", ErrorISBN},
+		{"letters inside", "0306a406152", "", ErrorISBN},
+		{"other separators", "978.0.306.40615.7", "", ErrorISBN},
+		{"too short", "030640615", "", ErrorISBN},
+		{"blank", "", "", ErrorISBN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseISBN(tt.value)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ParseISBN(%q) error = %v, want %v", tt.value, err, tt.err)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseISBN(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestISBNEditions(t *testing.T) {
+	tests := []struct {
+		name   string
+		isbn   ISBN
+		isbn13 ISBN
+		isbn10 ISBN
+		ok     bool
+	}{
+		{"isbn-10", "0306406152", "9780306406157", "0306406152", true},
+		{"isbn-13", "9780306406157", "9780306406157", "0306406152", true},
+		{"check digit x", "080442957X", "9780804429573", "080442957X", true},
+		{"isbn-13 to check digit x", "9780804429573", "9780804429573", "080442957X", true},
+		{"979 has no isbn-10", "9791090636071", "9791090636071", "", false},
+		{"invalid", "9780306406158", "9780306406158", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.isbn.ISBN13(); got != tt.isbn13 {
+				t.Errorf("ISBN13() = %q, want %q", got, tt.isbn13)
+			}
+
+			got, ok := tt.isbn.ISBN10()
+			if got != tt.isbn10 || ok != tt.ok {
+				t.Errorf("ISBN10() = %q, %v, want %q, %v", got, ok, tt.isbn10, tt.ok)
+			}
+		})
+	}
+}
+
+func TestISBNEqual(t *testing.T) {
+	tests := []struct {
+		name        string
+		isbn, other ISBN
+		want        bool
+	}{
+		{"same edition", "9780306406157", "9780306406157", true},
+		{"other edition", "0306406152", "9780306406157", true},
+		{"check digit x", "080442957X", "9780804429573", true},
+		{"other book", "0306406152", "9781861972712", false},
+		{"blank", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.isbn.Equal(tt.other); got != tt.want {
+				t.Errorf("%q.Equal(%q) = %v, want %v", tt.isbn, tt.other, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckDigit(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   byte
+	}{
+		{"030640615", '2'},
+		{"080442957", 'X'},
+		{"186197271", '7'},
+		{"978030640615", '7'},
+		{"978186197271", '2'},
+		{"979109063607", '1'},
+		{"978517000000", '5'},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.digits, func(t *testing.T) {
+			check := checkDigit13
+			if len(tt.digits) == 9 {
+				check = checkDigit10
+			}
+
+			if got := check(tt.digits); got != tt.want {
+				t.Errorf("check digit of %s = %c, want %c", tt.digits, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestISBNHyphenated(t *testing.T) {
+	tests := []struct {
+		name string
+		isbn ISBN
+		want string
+	}{
+		{"english group", "9780306406157", "978-0-306-40615-7"},
+		{"english group of isbn-10", "0306406152", "978-0-306-40615-7"},
+		{"second english group", "9781861972712", "978-1-86197-271-2"},
+		{"group without registrants", "9785170000005", "978-5-17000000-5"},
+		{"five digit group", "9789993701231", "978-99937-0123-1"},
+		{"979 prefix", "9791090636071", "979-10-9063607-1"},
+		{"invalid", "9780306406158", "9780306406158"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.isbn.Hyphenated(); got != tt.want {
+				t.Errorf("Hyphenated() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package book
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// MaxLabelBatch is the most copies a LabelRequest may print labels for
+const MaxLabelBatch = 200
+
+// Symbology is how the barcode of a copy is printed on its label, both encode the barcode itself
+// so the checkout desk scanner looks up the copy the same way
+type Symbology string
+
+const (
+	SymbologyCode128 Symbology = "code128"
+	SymbologyQR      Symbology = "qr"
+)
+
+type LabelFormat string
+
+const (
+	LabelPNG LabelFormat = "png"
+	LabelPDF LabelFormat = "pdf"
+)
+
+var (
+	ErrorSymbology   = errors.New("symbology: must be one of code128, qr")
+	ErrorLabelFormat = errors.New("format: must be one of png, pdf")
+	// ErrorBarcode is returned when the barcode of the copy cannot be printed with the symbology
+	ErrorBarcode = errors.New("barcode: cannot be encoded with the symbology")
+)
+
+// LabelRequest asks for the labels of many copies, one per page of a pdf sized for a label printer
+type LabelRequest struct {
+	IDs       []string  `json:"ids"`
+	Symbology Symbology `json:"symbology"`
+}
+
+func (s *LabelRequest) Bind(r *http.Request) (err error) {
+	if len(s.IDs) == 0 {
+		return errors.New("ids: cannot be blank")
+	}
+
+	if len(s.IDs) > MaxLabelBatch {
+		return fmt.Errorf("ids: cannot have more than %d copies", MaxLabelBatch)
+	}
+
+	for _, id := range s.IDs {
+		if id == "" {
+			return errors.New("ids: cannot have a blank id")
+		}
+	}
+
+	s.Symbology, err = ParseSymbology(string(s.Symbology))
+
+	return
+}
+
+// ParseSymbology defaults to Code128, the symbology of the existing copy barcodes
+func ParseSymbology(value string) (Symbology, error) {
+	switch Symbology(value) {
+	case "", SymbologyCode128:
+		return SymbologyCode128, nil
+	case SymbologyQR:
+		return SymbologyQR, nil
+	}
+
+	return "", ErrorSymbology
+}
+
+func ParseLabelFormat(value string) (LabelFormat, error) {
+	switch LabelFormat(value) {
+	case "", LabelPNG:
+		return LabelPNG, nil
+	case LabelPDF:
+		return LabelPDF, nil
+	}
+
+	return "", ErrorLabelFormat
+}
+
+// ContentType is the media type of the rendered label
+func (f LabelFormat) ContentType() string {
+	if f == LabelPDF {
+		return "application/pdf"
+	}
+	return "image/png"
+}
@@ -0,0 +1,20 @@
+package book
+
+import (
+	"context"
+)
+
+// Metadata is what an external catalog knows about an ISBN
+type Metadata struct {
+	Title    string
+	Genre    string
+	Authors  []string
+	Year     int
+	CoverURL string
+}
+
+// MetadataProvider looks up the metadata of a book by its ISBN, it returns
+// store.ErrorNotFound for unknown ISBNs
+type MetadataProvider interface {
+	Lookup(ctx context.Context, isbn ISBN) (dest Metadata, err error)
+}
@@ -0,0 +1,97 @@
+package book
+
+import (
+	"sort"
+)
+
+// MaxRelated is how many related books are kept for each book
+const MaxRelated = 10
+
+// Relation is a book the readers of another one borrowed as well, Borrowers counts the members who borrowed both
+type Relation struct {
+	BookID    string `db:"book_id" bson:"book_id"`
+	RelatedID string `db:"related_id" bson:"related_id"`
+	Borrowers int    `db:"borrowers" bson:"borrowers"`
+}
+
+// RelatedBook is a book the readers of the book also borrowed
+type RelatedBook struct {
+	ID        string   `json:"id" validate:"required"`
+	Name      string   `json:"name" validate:"required"`
+	Authors   []string `json:"authors" validate:"required"`
+	Cover     string   `json:"cover,omitempty"`
+	Borrowers int      `json:"borrowers" validate:"required"`
+}
+
+func ParseFromRelation(data Response, relation Relation) RelatedBook {
+	return RelatedBook{
+		ID:        data.ID,
+		Name:      data.Name,
+		Authors:   data.Authors,
+		Cover:     data.Cover,
+		Borrowers: relation.Borrowers,
+	}
+}
+
+// Relate pairs up the books borrowed by the same member, borrowed has the books of each member.
+// Every book keeps at most size of the books borrowed together with it, the ones with the most
+// borrowers first. The relations are ordered by book id and then by that rank.
+func Relate(borrowed [][]string, size int) (dest []Relation) {
+	counts := make(map[string]map[string]int)
+	for _, books := range borrowed {
+		ids := distinct(books)
+		for _, id := range ids {
+			for _, related := range ids {
+				if id == related {
+					continue
+				}
+
+				if counts[id] == nil {
+					counts[id] = make(map[string]int)
+				}
+				counts[id][related]++
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(counts))
+	for id := range counts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		relations := make([]Relation, 0, len(counts[id]))
+		for related, borrowers := range counts[id] {
+			relations = append(relations, Relation{BookID: id, RelatedID: related, Borrowers: borrowers})
+		}
+
+		sort.Slice(relations, func(i, j int) bool {
+			if relations[i].Borrowers != relations[j].Borrowers {
+				return relations[i].Borrowers > relations[j].Borrowers
+			}
+			return relations[i].RelatedID < relations[j].RelatedID
+		})
+
+		if len(relations) > size {
+			relations = relations[:size]
+		}
+		dest = append(dest, relations...)
+	}
+
+	return
+}
+
+// distinct drops the blank ids and the ones that appear more than once
+func distinct(ids []string) (dest []string) {
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		dest = append(dest, id)
+	}
+
+	return
+}
@@ -1,6 +1,9 @@
 package book
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Repository interface {
 	List(ctx context.Context) (dest []Entity, err error)
@@ -8,4 +11,54 @@ type Repository interface {
 	Get(ctx context.Context, id string) (dest Entity, err error)
 	Update(ctx context.Context, id string, data Entity) (err error)
 	Delete(ctx context.Context, id string) (err error)
+
+	// ListByIDs returns the books of the ids that aren't deleted, the ids of no book are left out
+	ListByIDs(ctx context.Context, ids []string) (dest []Entity, err error)
+	// ListWithDeleted returns the deleted books along with the rest
+	ListWithDeleted(ctx context.Context) (dest []Entity, err error)
+	// Restore undoes the delete of the book, a book that isn't deleted is not found
+	Restore(ctx context.Context, id string) (err error)
+
+	// Filter returns a page of the books of the filter in the order of their ids, the volumes of a series
+	// in the order of their volume numbers
+	Filter(ctx context.Context, filter Filter, limit, offset int) (dest []Entity, err error)
+
+	// Search returns the books matching every term of the query, the most relevant first
+	Search(ctx context.Context, query string, limit, offset int) (dest []Entity, err error)
+
+	// Each calls fn with the books that aren't deleted in the order of their ids, reading them through a cursor
+	// so that the catalog is never held in memory whole. An error of fn stops the iteration and is returned.
+	Each(ctx context.Context, fn func(data Entity) error) (err error)
+}
+
+type CopyRepository interface {
+	List(ctx context.Context, bookID string) (dest []Copy, err error)
+	// ListByBooks returns the copies of all the books in a single query
+	ListByBooks(ctx context.Context, bookIDs []string) (dest []Copy, err error)
+	Add(ctx context.Context, data Copy) (id string, err error)
+	Get(ctx context.Context, id string) (dest Copy, err error)
+	// GetByBarcode returns the copy the barcode is printed on, the barcodes are unique
+	GetByBarcode(ctx context.Context, barcode string) (dest Copy, err error)
+	Update(ctx context.Context, id string, data Copy) (err error)
+	Delete(ctx context.Context, id string) (err error)
+}
+
+type RevisionRepository interface {
+	// List returns the revisions of the book, the newest first
+	List(ctx context.Context, bookID string) (dest []Revision, err error)
+	Add(ctx context.Context, data Revision) (id string, err error)
+	Get(ctx context.Context, id string) (dest Revision, err error)
+}
+
+type CheckoutRepository interface {
+	Add(ctx context.Context, data Checkout) (id string, err error)
+	// Count returns the number of checkouts of each book since the time, books without any are left out
+	Count(ctx context.Context, since time.Time) (dest map[string]int, err error)
+}
+
+type RelationRepository interface {
+	// List returns the books related to the book, the ones with the most borrowers first
+	List(ctx context.Context, bookID string) (dest []Relation, err error)
+	// Replace swaps all the relations for the data at once, the related books are never read half built
+	Replace(ctx context.Context, data []Relation) (err error)
 }
@@ -0,0 +1,164 @@
+package book
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+type Action string
+
+const (
+	ActionCreate   Action = "create"
+	ActionUpdate   Action = "update"
+	ActionDelete   Action = "delete"
+	ActionRestore  Action = "restore"
+	ActionRollback Action = "rollback"
+)
+
+// ErrorRollback is returned when the revision has no previous state of the book to go back to
+var ErrorRollback = errors.New("revision: there is no previous state of the book to roll back to")
+
+// Revision records a change of the book with its state before and after it, Before is nil
+// for a created book and After for a deleted one
+type Revision struct {
+	ID        string    `db:"id" bson:"_id"`
+	BookID    string    `db:"book_id" bson:"book_id"`
+	Action    Action    `db:"action" bson:"action"`
+	Actor     string    `db:"actor" bson:"actor"`
+	Before    *Snapshot `db:"before" bson:"before"`
+	After     *Snapshot `db:"after" bson:"after"`
+	CreatedAt time.Time `db:"created_at" bson:"created_at"`
+}
+
+// Snapshot is the state of the book a revision keeps, it is stored as jsonb in postgres
+type Snapshot struct {
+	Name    string   `json:"name" bson:"name" validate:"required"`
+	Genre   string   `json:"genre" bson:"genre" validate:"required"`
+	ISBN    ISBN     `json:"isbn" bson:"isbn" validate:"required"`
+	Authors []string `json:"authors" bson:"authors" validate:"required"`
+	Year    int      `json:"year,omitempty" bson:"year"`
+	Cover   string   `json:"cover,omitempty" bson:"cover"`
+
+	Description string `json:"description,omitempty" bson:"description"`
+
+	Categories []string `json:"categories,omitempty" bson:"categories"`
+	Tags       []string `json:"tags,omitempty" bson:"tags"`
+
+	SeriesID string `json:"seriesId,omitempty" bson:"series_id"`
+	Volume   int    `json:"volume,omitempty" bson:"volume"`
+}
+
+func NewSnapshot(data Entity) *Snapshot {
+	res := ParseFromEntity(data)
+
+	return &Snapshot{
+		Name:    res.Name,
+		Genre:   res.Genre,
+		ISBN:    res.ISBN,
+		Authors: res.Authors,
+		Year:    res.Year,
+		Cover:   res.Cover,
+
+		Description: res.Description,
+
+		Categories: res.Categories,
+		Tags:       res.Tags,
+
+		SeriesID: res.SeriesID,
+		Volume:   res.Volume,
+	}
+}
+
+// Entity returns the book with the state of the snapshot, a blank year, cover or description is left untouched on update
+// while a blank series takes the book out of its series
+func (s Snapshot) Entity() (data Entity) {
+	data = Entity{
+		Name:    &s.Name,
+		Genre:   &s.Genre,
+		ISBN:    &s.ISBN,
+		Authors: s.Authors,
+
+		Categories: s.Categories,
+		Tags:       s.Tags,
+
+		SeriesID: &s.SeriesID,
+	}
+
+	// a snapshot without authors, categories or tags clears them
+	if data.Authors == nil {
+		data.Authors = []string{}
+	}
+
+	if data.Categories == nil {
+		data.Categories = []string{}
+	}
+
+	if data.Tags == nil {
+		data.Tags = []string{}
+	}
+
+	if s.Year > 0 {
+		data.Year = &s.Year
+	}
+
+	if s.Cover != "" {
+		data.Cover = &s.Cover
+	}
+
+	if s.Description != "" {
+		data.Description = &s.Description
+	}
+
+	if s.Volume > 0 {
+		data.Volume = &s.Volume
+	}
+	return
+}
+
+func (s Snapshot) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+func (s *Snapshot) Scan(src any) error {
+	switch data := src.(type) {
+	case []byte:
+		return json.Unmarshal(data, s)
+	case string:
+		return json.Unmarshal([]byte(data), s)
+	default:
+		return errors.New("revision: unsupported snapshot type")
+	}
+}
+
+type RevisionResponse struct {
+	ID        string    `json:"id" validate:"required"`
+	BookID    string    `json:"bookId" validate:"required"`
+	Action    Action    `json:"action" validate:"required"`
+	Actor     string    `json:"actor" validate:"required"`
+	Before    *Snapshot `json:"before,omitempty"`
+	After     *Snapshot `json:"after,omitempty"`
+	CreatedAt time.Time `json:"createdAt" validate:"required" format:"date-time"`
+}
+
+func ParseFromRevision(data Revision) (res RevisionResponse) {
+	res = RevisionResponse{
+		ID:        data.ID,
+		BookID:    data.BookID,
+		Action:    data.Action,
+		Actor:     data.Actor,
+		Before:    data.Before,
+		After:     data.After,
+		CreatedAt: data.CreatedAt,
+	}
+	return
+}
+
+func ParseFromRevisions(data []Revision) (res []RevisionResponse) {
+	res = make([]RevisionResponse, 0)
+	for _, object := range data {
+		res = append(res, ParseFromRevision(object))
+	}
+	return
+}
@@ -0,0 +1,64 @@
+package book
+
+import (
+	"sort"
+)
+
+// VolumeRef points to a volume of a series
+type VolumeRef struct {
+	ID     string `json:"id" validate:"required"`
+	Name   string `json:"name" validate:"required"`
+	Volume int    `json:"volume" validate:"required"`
+}
+
+func ParseVolumeRef(data Entity) (res VolumeRef) {
+	res = VolumeRef{
+		ID:   data.ID,
+		Name: *data.Name,
+	}
+
+	if data.Volume != nil {
+		res.Volume = *data.Volume
+	}
+	return
+}
+
+// ReadingOrder sorts the volumes of a series by their volume number
+func ReadingOrder(data []Entity) []Entity {
+	sort.SliceStable(data, func(i, j int) bool {
+		return volumeOf(data[i]) < volumeOf(data[j])
+	})
+
+	return data
+}
+
+// Neighbours finds the volumes right before and after the book among the volumes of its series
+func Neighbours(data []Entity, id string) (prev, next *VolumeRef) {
+	data = ReadingOrder(data)
+	for i, object := range data {
+		if object.ID != id {
+			continue
+		}
+
+		if i > 0 {
+			ref := ParseVolumeRef(data[i-1])
+			prev = &ref
+		}
+
+		if i < len(data)-1 {
+			ref := ParseVolumeRef(data[i+1])
+			next = &ref
+		}
+		break
+	}
+
+	return
+}
+
+func volumeOf(data Entity) int {
+	if data.Volume == nil {
+		return 0
+	}
+
+	return *data.Volume
+}
@@ -0,0 +1,94 @@
+package book
+
+import (
+	"strings"
+)
+
+const (
+	DefaultListLimit = 100
+	MaxListLimit     = 1000
+)
+
+// Filter narrows a list of books down to a category, with its subcategories, a set of tags, a series
+// and an ISBN in either edition
+type Filter struct {
+	Category string
+	Tags     []string
+	Series   string
+	ISBN     ISBN
+
+	// Categories are the ids of the category and its subcategories, they are set from the Category
+	// before the filter reaches a repository
+	Categories []string
+}
+
+// ISBNs are the ISBN of the filter in both of its editions, a book is stored in the edition it was entered in
+func (f Filter) ISBNs() (res []string) {
+	if f.ISBN == "" {
+		return nil
+	}
+
+	res = []string{f.ISBN.ISBN13().String()}
+	if isbn, ok := f.ISBN.ISBN10(); ok {
+		res = append(res, isbn.String())
+	}
+
+	return
+}
+
+// Match reports whether the book is in one of the categories, has every tag, belongs to the series and has the ISBN of the filter
+func (f Filter) Match(data Entity) bool {
+	if f.ISBN != "" && (data.ISBN == nil || !data.ISBN.Equal(f.ISBN)) {
+		return false
+	}
+
+	if f.Series != "" && (data.SeriesID == nil || *data.SeriesID != f.Series) {
+		return false
+	}
+
+	if len(f.Categories) > 0 {
+		found := false
+		for _, id := range data.Categories {
+			for _, category := range f.Categories {
+				found = found || id == category
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	tags := make(map[string]bool, len(data.Tags))
+	for _, tag := range data.Tags {
+		tags[tag] = true
+	}
+
+	for _, tag := range f.Tags {
+		if !tags[tag] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NormalizeTags lowercases and trims the tags, dropping blank and repeated ones
+func NormalizeTags(tags []string) (res []string) {
+	if tags == nil {
+		return nil
+	}
+
+	res = make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		res = append(res, tag)
+	}
+
+	return
+}
@@ -0,0 +1,62 @@
+package category
+
+import (
+	"errors"
+	"net/http"
+)
+
+var (
+	ErrorUnknown = errors.New("category: unknown category")
+	ErrorCycle   = errors.New("category: cannot be moved under itself or its subcategories")
+	ErrorInUse   = errors.New("category: cannot delete a category with subcategories or books")
+	ErrorExists  = errors.New("category: the parent already has a subcategory with the name")
+)
+
+type Request struct {
+	Name     string `json:"name"`
+	ParentID string `json:"parentId"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.Name == "" {
+		return errors.New("name: cannot be blank")
+	}
+
+	if Slugify(s.Name) == "" {
+		return errors.New("name: must contain a letter or digit")
+	}
+
+	return nil
+}
+
+type Response struct {
+	ID       string `json:"id" validate:"required"`
+	Name     string `json:"name" validate:"required"`
+	Slug     string `json:"slug" validate:"required"`
+	ParentID string `json:"parentId,omitempty"`
+	Path     string `json:"path" validate:"required"`
+}
+
+func ParseFromEntity(data Entity, tree Tree) (res Response) {
+	res = Response{
+		ID:   data.ID,
+		Name: *data.Name,
+		Slug: *data.Slug,
+		Path: tree.Path(data.ID),
+	}
+
+	if data.ParentID != nil {
+		res.ParentID = *data.ParentID
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	tree := NewTree(data)
+
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object, tree))
+	}
+	return
+}
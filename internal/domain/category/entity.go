@@ -0,0 +1,9 @@
+package category
+
+// Entity is a node of the category tree, a category without a parent is a root
+type Entity struct {
+	ID       string  `db:"id" bson:"_id"`
+	Name     *string `db:"name" bson:"name"`
+	Slug     *string `db:"slug" bson:"slug"`
+	ParentID *string `db:"parent_id" bson:"parent_id"`
+}
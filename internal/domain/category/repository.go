@@ -0,0 +1,14 @@
+package category
+
+import (
+	"context"
+)
+
+// Repository updates the parent only when ParentID is set, a blank ParentID makes the category a root
+type Repository interface {
+	List(ctx context.Context) (dest []Entity, err error)
+	Add(ctx context.Context, data Entity) (id string, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	Update(ctx context.Context, id string, data Entity) (err error)
+	Delete(ctx context.Context, id string) (err error)
+}
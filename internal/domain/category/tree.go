@@ -0,0 +1,96 @@
+package category
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tree indexes the categories to resolve their paths, e.g. fiction/fantasy
+type Tree struct {
+	nodes    map[string]Entity
+	children map[string][]string
+}
+
+func NewTree(data []Entity) Tree {
+	tree := Tree{
+		nodes:    make(map[string]Entity, len(data)),
+		children: make(map[string][]string),
+	}
+
+	for _, object := range data {
+		tree.nodes[object.ID] = object
+		if object.ParentID != nil && *object.ParentID != "" {
+			tree.children[*object.ParentID] = append(tree.children[*object.ParentID], object.ID)
+		}
+	}
+
+	return tree
+}
+
+// Path joins the slugs from the root down to the category
+func (t Tree) Path(id string) string {
+	var slugs []string
+	for seen := make(map[string]bool); id != "" && !seen[id]; {
+		seen[id] = true
+
+		node, ok := t.nodes[id]
+		if !ok {
+			break
+		}
+		slugs = append([]string{*node.Slug}, slugs...)
+
+		id = ""
+		if node.ParentID != nil {
+			id = *node.ParentID
+		}
+	}
+
+	return strings.Join(slugs, "/")
+}
+
+// Find returns the id of the category at the path
+func (t Tree) Find(path string) (id string, ok bool) {
+	path = strings.Trim(strings.ToLower(path), "/")
+	for id := range t.nodes {
+		if t.Path(id) == path {
+			return id, true
+		}
+	}
+
+	return "", false
+}
+
+// Subtree returns the category with all of its subcategories
+func (t Tree) Subtree(id string) (ids map[string]bool) {
+	ids = make(map[string]bool)
+
+	queue := []string{id}
+	for len(queue) > 0 {
+		id, queue = queue[0], queue[1:]
+		if ids[id] {
+			continue
+		}
+		ids[id] = true
+		queue = append(queue, t.children[id]...)
+	}
+
+	return
+}
+
+func (t Tree) Has(id string) bool {
+	_, ok := t.nodes[id]
+	return ok
+}
+
+func (t Tree) HasChildren(id string) bool {
+	return len(t.children[id]) > 0
+}
+
+// Slugify lowercases the name and joins its words with dashes
+func Slugify(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return strings.Join(words, "-")
+}
@@ -0,0 +1,52 @@
+package export
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type Request struct {
+	Kind string `json:"kind"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	for _, kind := range Kinds {
+		if s.Kind == kind {
+			return nil
+		}
+	}
+
+	return errors.New("kind: must be one of " + strings.Join(Kinds, ", "))
+}
+
+type Response struct {
+	ID          string     `json:"id" validate:"required"`
+	Kind        string     `json:"kind" validate:"required"`
+	Status      Status     `json:"status" validate:"required"`
+	Processed   int        `json:"processed" validate:"required"`
+	Error       string     `json:"error,omitempty"`
+	URL         string     `json:"url,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt" validate:"required" format:"date-time"`
+	CompletedAt *time.Time `json:"completedAt,omitempty" format:"date-time"`
+}
+
+// ParseFromEntity reports the progress as the number of rows exported so far, the catalog is streamed
+// so its total isn't known until the job completes. The download url is left to the caller.
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:        data.ID,
+		Kind:      data.Kind,
+		Status:    data.Status,
+		Processed: data.Processed,
+		Error:     data.Error,
+		CreatedAt: data.CreatedAt,
+	}
+
+	if !data.CompletedAt.IsZero() {
+		res.CompletedAt = &data.CompletedAt
+	}
+
+	return
+}
@@ -0,0 +1,30 @@
+package export
+
+import (
+	"time"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+// KindCatalog exports every book of the library as csv
+const KindCatalog = "catalog"
+
+var Kinds = []string{KindCatalog}
+
+type Entity struct {
+	ID          string
+	Kind        string
+	Status      Status
+	Processed   int
+	Error       string
+	Key         string
+	CreatedAt   time.Time
+	CompletedAt time.Time
+}
@@ -0,0 +1,203 @@
+package health
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"time"
+)
+
+// UptimeWindow is the period the uptime of the components is reported over
+const UptimeWindow = 30 * 24 * time.Hour
+
+// ErrorResolved is returned when a resolved incident is moved back to another state
+var ErrorResolved = errors.New("state: a resolved incident cannot be reopened")
+
+type IncidentRequest struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Impact      Impact   `json:"impact"`
+	State       State    `json:"state"`
+	Components  []string `json:"components"`
+}
+
+func (s *IncidentRequest) Bind(r *http.Request) error {
+	if s.Title == "" {
+		return errors.New("title: cannot be blank")
+	}
+
+	if s.Impact == "" {
+		s.Impact = ImpactMinor
+	}
+
+	if !validImpact(s.Impact) {
+		return errors.New("impact: must be one of minor, major, critical")
+	}
+
+	if s.State == "" {
+		s.State = StateInvestigating
+	}
+
+	if !validState(s.State) {
+		return errors.New("state: must be one of investigating, identified, monitoring, resolved")
+	}
+
+	if len(s.Components) == 0 {
+		return errors.New("components: cannot be empty")
+	}
+
+	for _, component := range s.Components {
+		if !contains(Components, component) {
+			return errors.New("components: must be some of api, payments, notifications")
+		}
+	}
+
+	return nil
+}
+
+type IncidentResponse struct {
+	ID          string     `json:"id" validate:"required"`
+	Title       string     `json:"title" validate:"required"`
+	Description string     `json:"description,omitempty"`
+	Impact      Impact     `json:"impact" validate:"required"`
+	State       State      `json:"state" validate:"required"`
+	Components  []string   `json:"components" validate:"required"`
+	CreatedAt   time.Time  `json:"createdAt" validate:"required" format:"date-time"`
+	ResolvedAt  *time.Time `json:"resolvedAt,omitempty" format:"date-time"`
+}
+
+func ParseFromIncident(data Incident) (res IncidentResponse) {
+	res = IncidentResponse{
+		ID:         data.ID,
+		Title:      *data.Title,
+		Impact:     *data.Impact,
+		State:      *data.State,
+		Components: data.Components,
+		CreatedAt:  data.CreatedAt,
+		ResolvedAt: data.ResolvedAt,
+	}
+
+	if data.Description != nil {
+		res.Description = *data.Description
+	}
+	return
+}
+
+func ParseFromIncidents(data []Incident) (res []IncidentResponse) {
+	res = make([]IncidentResponse, 0)
+	for _, object := range data {
+		res = append(res, ParseFromIncident(object))
+	}
+	return
+}
+
+// ComponentResponse is the status of the component, Uptime is the percent of its checks
+// that passed over the UptimeWindow and is left out until it was checked once. Breaker is the
+// state of the circuit breaker in front of the component, if it has one.
+type ComponentResponse struct {
+	Name    string   `json:"name" validate:"required"`
+	Status  Status   `json:"status" validate:"required"`
+	Uptime  *float64 `json:"uptime,omitempty"`
+	Breaker string   `json:"breaker,omitempty" enums:"closed,open,half-open"`
+}
+
+// Page is what the public status page shows, Status is the worst status of the components
+type Page struct {
+	Status     Status              `json:"status" validate:"required"`
+	Components []ComponentResponse `json:"components" validate:"required"`
+	Incidents  []IncidentResponse  `json:"incidents" validate:"required"`
+	UpdatedAt  time.Time           `json:"updatedAt" validate:"required" format:"date-time"`
+}
+
+// ComponentStatus is an outage when the last check of the component failed, it is degraded while a current
+// incident affects it or its checks didn't run and operational otherwise
+func ComponentStatus(component string, checked, passed bool, incidents []Incident) Status {
+	switch {
+	case checked && !passed:
+		return StatusOutage
+	case affected(component, incidents):
+		return StatusDegraded
+	case !checked:
+		return StatusUnknown
+	}
+
+	return StatusOperational
+}
+
+// Overall is the worst of the statuses, unknown ones only count while nothing else is known
+func Overall(statuses []Status) (dest Status) {
+	rank := map[Status]int{StatusUnknown: 0, StatusOperational: 1, StatusDegraded: 2, StatusOutage: 3}
+
+	dest = StatusUnknown
+	for _, status := range statuses {
+		if rank[status] > rank[dest] {
+			dest = status
+		}
+	}
+
+	return
+}
+
+// Uptime sums the checks per component into the percent that passed, rounded to two decimals
+func Uptime(data []Day) (dest map[string]float64) {
+	total, passed := make(map[string]int), make(map[string]int)
+	for _, object := range data {
+		total[object.Component] += object.Total
+		passed[object.Component] += object.Passed
+	}
+
+	dest = make(map[string]float64, len(total))
+	for component, count := range total {
+		if count > 0 {
+			dest[component] = math.Round(float64(passed[component])/float64(count)*10000) / 100
+		}
+	}
+
+	return
+}
+
+func affected(component string, incidents []Incident) bool {
+	for _, object := range incidents {
+		if object.State != nil && *object.State == StateResolved {
+			continue
+		}
+
+		for _, name := range object.Components {
+			if name == component {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func validImpact(impact Impact) bool {
+	for _, object := range Impacts {
+		if object == impact {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validState(state State) bool {
+	for _, object := range States {
+		if object == state {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, object := range values {
+		if object == value {
+			return true
+		}
+	}
+
+	return false
+}
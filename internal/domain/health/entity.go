@@ -0,0 +1,66 @@
+package health
+
+import (
+	"time"
+)
+
+const (
+	ComponentAPI           = "api"
+	ComponentPayments      = "payments"
+	ComponentNotifications = "notifications"
+)
+
+// Components lists the parts of the service the status page reports on, in the order it shows them
+var Components = []string{ComponentAPI, ComponentPayments, ComponentNotifications}
+
+type Status string
+
+const (
+	// StatusUnknown is the status of a component that wasn't checked yet
+	StatusUnknown     Status = "unknown"
+	StatusOperational Status = "operational"
+	StatusDegraded    Status = "degraded"
+	StatusOutage      Status = "outage"
+)
+
+type State string
+
+const (
+	StateInvestigating State = "investigating"
+	StateIdentified    State = "identified"
+	StateMonitoring    State = "monitoring"
+	StateResolved      State = "resolved"
+)
+
+// States lists the states an incident goes through, it is current until it is resolved
+var States = []State{StateInvestigating, StateIdentified, StateMonitoring, StateResolved}
+
+type Impact string
+
+const (
+	ImpactMinor    Impact = "minor"
+	ImpactMajor    Impact = "major"
+	ImpactCritical Impact = "critical"
+)
+
+var Impacts = []Impact{ImpactMinor, ImpactMajor, ImpactCritical}
+
+// Incident is a problem the admins report on the status page, it affects the Components
+type Incident struct {
+	ID          string     `db:"id" bson:"_id"`
+	Title       *string    `db:"title" bson:"title"`
+	Description *string    `db:"description" bson:"description"`
+	Impact      *Impact    `db:"impact" bson:"impact"`
+	State       *State     `db:"state" bson:"state"`
+	Components  []string   `db:"components" bson:"components"`
+	CreatedAt   time.Time  `db:"created_at" bson:"created_at"`
+	ResolvedAt  *time.Time `db:"resolved_at" bson:"resolved_at"`
+}
+
+// Day counts the health checks of the component that ran and passed on the day, the uptime is derived from them
+type Day struct {
+	Component string    `db:"component" bson:"component"`
+	Day       time.Time `db:"day" bson:"day"`
+	Total     int       `db:"total" bson:"total"`
+	Passed    int       `db:"passed" bson:"passed"`
+}
@@ -0,0 +1,28 @@
+package health
+
+import (
+	"context"
+	"time"
+)
+
+type IncidentRepository interface {
+	// List returns the incidents, the newest first, only the ones not resolved yet when current is set
+	List(ctx context.Context, current bool) (dest []Incident, err error)
+	Add(ctx context.Context, data Incident) (id string, err error)
+	Get(ctx context.Context, id string) (dest Incident, err error)
+	Update(ctx context.Context, id string, data Incident) (err error)
+	Delete(ctx context.Context, id string) (err error)
+}
+
+type CheckRepository interface {
+	// Record counts a health check of the component on the day it ran
+	Record(ctx context.Context, component string, day time.Time, passed bool) (err error)
+	// List returns the counts of every component since the day
+	List(ctx context.Context, since time.Time) (dest []Day, err error)
+}
+
+// Check tells whether a component is healthy, it returns the reason when it is not
+type Check func(ctx context.Context) error
+
+// Breaker returns the state of the circuit breaker in front of a component
+type Breaker func() string
@@ -0,0 +1,64 @@
+package loan
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+var (
+	// ErrorLimit is returned when the member has as many books on loan as their tier allows
+	ErrorLimit = errors.New("member: has reached the limit of books on loan")
+	// ErrorUnavailable is returned for a copy that is lent out, lost or withdrawn
+	ErrorUnavailable = errors.New("copyId: is not available to check out")
+	ErrorReturned    = errors.New("loan: is returned already")
+	ErrorOverdue     = errors.New("loan: is overdue and cannot be renewed")
+	ErrorRenewals    = errors.New("loan: has been renewed as many times as the tier allows")
+)
+
+type Request struct {
+	CopyID string `json:"copyId"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.CopyID == "" {
+		return errors.New("copyId: cannot be blank")
+	}
+
+	return nil
+}
+
+type Response struct {
+	ID         string     `json:"id" validate:"required"`
+	MemberID   string     `json:"memberId" validate:"required"`
+	BookID     string     `json:"bookId" validate:"required"`
+	CopyID     string     `json:"copyId" validate:"required"`
+	DueAt      time.Time  `json:"dueAt" validate:"required" format:"date-time"`
+	Renewals   int        `json:"renewals" validate:"required"`
+	Overdue    bool       `json:"overdue" validate:"required"`
+	ReturnedAt *time.Time `json:"returnedAt,omitempty" format:"date-time"`
+	CreatedAt  time.Time  `json:"createdAt" validate:"required" format:"date-time"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:         data.ID,
+		MemberID:   data.MemberID,
+		BookID:     data.BookID,
+		CopyID:     data.CopyID,
+		DueAt:      data.DueAt,
+		Renewals:   data.Renewals,
+		Overdue:    data.Overdue(time.Now()),
+		ReturnedAt: data.ReturnedAt,
+		CreatedAt:  data.CreatedAt,
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
@@ -0,0 +1,28 @@
+package loan
+
+import (
+	"time"
+)
+
+// Entity is a copy of a book lent to a member, the loan is kept once the copy is returned
+// so that the member has a history of what they borrowed
+type Entity struct {
+	ID         string     `db:"id" bson:"_id"`
+	MemberID   string     `db:"member_id" bson:"member_id"`
+	BookID     string     `db:"book_id" bson:"book_id"`
+	CopyID     string     `db:"copy_id" bson:"copy_id"`
+	DueAt      time.Time  `db:"due_at" bson:"due_at"`
+	Renewals   int        `db:"renewals" bson:"renewals"`
+	ReturnedAt *time.Time `db:"returned_at" bson:"returned_at"`
+	CreatedAt  time.Time  `db:"created_at" bson:"created_at"`
+}
+
+// Active reports whether the copy is still out with the member
+func (e Entity) Active() bool {
+	return e.ReturnedAt == nil
+}
+
+// Overdue reports whether the copy is still out past the due date
+func (e Entity) Overdue(now time.Time) bool {
+	return e.Active() && now.After(e.DueAt)
+}
@@ -0,0 +1,39 @@
+package loan
+
+import (
+	"time"
+
+	"library-service/internal/domain/member"
+)
+
+// Policy is how long a member of a tier keeps a book, how many books they may have at once
+// and how many times a loan may be renewed
+type Policy struct {
+	Period   time.Duration
+	Limit    int
+	Renewals int
+}
+
+// Policies are the lending rules of each subscription tier
+var Policies = map[member.Tier]Policy{
+	member.TierBasic:   {Period: 14 * 24 * time.Hour, Limit: 3, Renewals: 1},
+	member.TierPremium: {Period: 28 * 24 * time.Hour, Limit: 10, Renewals: 3},
+}
+
+// PolicyOf returns the lending rules of the tier, an unknown tier lends as the basic one
+func PolicyOf(tier member.Tier) Policy {
+	if policy, ok := Policies[tier]; ok {
+		return policy
+	}
+
+	return Policies[member.TierBasic]
+}
+
+// DueDate is the end of the day the period of the policy runs out on, counted from the time,
+// so that a book is due back by closing time rather than at the hour it was lent
+func (p Policy) DueDate(from time.Time) time.Time {
+	due := from.Add(p.Period)
+	year, month, day := due.Date()
+
+	return time.Date(year, month, day, 23, 59, 59, 0, due.Location())
+}
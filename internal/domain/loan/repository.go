@@ -0,0 +1,18 @@
+package loan
+
+import (
+	"context"
+)
+
+type Repository interface {
+	// List returns the loans of the member, the latest first
+	List(ctx context.Context, memberID string) (dest []Entity, err error)
+	// Add reports a copy that is out on another loan as ErrorUnavailable and a member who has
+	// limit books on loan already as ErrorLimit, both are checked with the loan added at once
+	Add(ctx context.Context, data Entity, limit int) (id string, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	// Update changes the due date, the renewals and the return of the loan
+	Update(ctx context.Context, id string, data Entity) (err error)
+	// Delete removes a loan that was never lent out, e.g. when the copy couldn't be checked out
+	Delete(ctx context.Context, id string) (err error)
+}
@@ -1,14 +1,20 @@
 package member
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 )
 
+// ErrorTier is returned for a tier that is neither basic nor premium
+var ErrorTier = errors.New("tier: must be one of basic, premium")
+
+// Request leaves the tier blank for a basic subscription
 type Request struct {
 	ID       string   `json:"id"`
 	FullName string   `json:"fullName"`
 	Books    []string `json:"books"`
+	Tier     Tier     `json:"tier"`
 }
 
 func (s *Request) Bind(r *http.Request) error {
@@ -16,13 +22,60 @@ func (s *Request) Bind(r *http.Request) error {
 		return errors.New("fullName: cannot be blank")
 	}
 
+	if s.Tier == "" {
+		s.Tier = TierBasic
+	}
+
+	if !containsTier(s.Tier) {
+		return ErrorTier
+	}
+
+	return nil
+}
+
+// PatchRequest is a JSON merge patch for a member: fields that are absent are left untouched,
+// null removes the books like an empty list does and leaves the other fields untouched.
+type PatchRequest struct {
+	FullName *string   `json:"fullName"`
+	Books    *[]string `json:"books"`
+	Tier     *Tier     `json:"tier"`
+}
+
+func (s *PatchRequest) UnmarshalJSON(data []byte) error {
+	type patch PatchRequest
+	if err := json.Unmarshal(data, (*patch)(s)); err != nil {
+		return err
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if _, ok := fields["books"]; ok && s.Books == nil {
+		s.Books = &[]string{}
+	}
+
+	return nil
+}
+
+func (s *PatchRequest) Bind(r *http.Request) error {
+	if s.FullName != nil && *s.FullName == "" {
+		return errors.New("fullName: cannot be blank")
+	}
+
+	if s.Tier != nil && !containsTier(*s.Tier) {
+		return ErrorTier
+	}
+
 	return nil
 }
 
 type Response struct {
-	ID       string   `json:"id"`
-	FullName string   `json:"fullName"`
-	Books    []string `json:"books"`
+	ID       string   `json:"id" validate:"required"`
+	FullName string   `json:"fullName" validate:"required"`
+	Books    []string `json:"books" validate:"required"`
+	Tier     Tier     `json:"tier" validate:"required"`
 }
 
 func ParseFromEntity(data Entity) (res Response) {
@@ -30,6 +83,7 @@ func ParseFromEntity(data Entity) (res Response) {
 		ID:       data.ID,
 		FullName: *data.FullName,
 		Books:    data.Books,
+		Tier:     data.Subscription(),
 	}
 	return
 }
@@ -41,3 +95,7 @@ func ParseFromEntities(data []Entity) (res []Response) {
 	}
 	return
 }
+
+func containsTier(value Tier) bool {
+	return value == TierBasic || value == TierPremium
+}
@@ -1,7 +1,25 @@
 package member
 
+// Tier is the subscription of the member, it sets how many books they may borrow and for how long
+type Tier string
+
+const (
+	TierBasic   Tier = "basic"
+	TierPremium Tier = "premium"
+)
+
 type Entity struct {
 	ID       string   `db:"id" db:"_id"`
 	FullName *string  `db:"full_name" db:"full_name"`
 	Books    []string `db:"books" db:"books"`
+	Tier     *Tier    `db:"tier" bson:"tier"`
+}
+
+// Subscription is the tier of the member, the members added before there were tiers are on the basic one
+func (e Entity) Subscription() Tier {
+	if e.Tier == nil || *e.Tier == "" {
+		return TierBasic
+	}
+
+	return *e.Tier
 }
@@ -0,0 +1,104 @@
+package review
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"time"
+)
+
+var (
+	// ErrorExists is returned when the member already reviewed the book
+	ErrorExists = errors.New("review: the member already reviewed the book")
+
+	// ErrorNotAuthor is returned when a member edits the review of another member
+	ErrorNotAuthor = errors.New("review: only the member who wrote the review can edit it")
+)
+
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+type Request struct {
+	MemberID string `json:"memberId"`
+	Rating   int    `json:"rating"`
+	Text     string `json:"text"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.MemberID == "" {
+		return errors.New("memberId: cannot be blank")
+	}
+
+	if s.Rating < MinRating || s.Rating > MaxRating {
+		return errors.New("rating: must be between 1 and 5")
+	}
+
+	return nil
+}
+
+type ModerationRequest struct {
+	Status Status `json:"status"`
+}
+
+func (s *ModerationRequest) Bind(r *http.Request) error {
+	for _, status := range Statuses {
+		if s.Status == status {
+			return nil
+		}
+	}
+
+	return errors.New("status: must be one of visible, flagged, hidden")
+}
+
+type Response struct {
+	ID        string    `json:"id" validate:"required"`
+	BookID    string    `json:"bookId" validate:"required"`
+	MemberID  string    `json:"memberId" validate:"required"`
+	Rating    int       `json:"rating" validate:"required"`
+	Text      string    `json:"text,omitempty"`
+	Status    Status    `json:"status" validate:"required"`
+	CreatedAt time.Time `json:"createdAt" validate:"required" format:"date-time"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:        data.ID,
+		BookID:    data.BookID,
+		MemberID:  data.MemberID,
+		Rating:    *data.Rating,
+		Status:    *data.Status,
+		CreatedAt: data.CreatedAt,
+	}
+
+	if data.Text != nil {
+		res.Text = *data.Text
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
+
+// Summarize averages the ratings of the reviews that are not hidden
+func Summarize(data []Entity) (average float64, count int) {
+	var total int
+	for _, object := range data {
+		if *object.Status == StatusHidden {
+			continue
+		}
+		total += *object.Rating
+		count++
+	}
+
+	if count > 0 {
+		average = math.Round(float64(total)/float64(count)*100) / 100
+	}
+	return
+}
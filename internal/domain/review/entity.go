@@ -0,0 +1,27 @@
+package review
+
+import (
+	"time"
+)
+
+type Status string
+
+const (
+	StatusVisible Status = "visible"
+	StatusFlagged Status = "flagged"
+	StatusHidden  Status = "hidden"
+)
+
+// Statuses lists the moderation states, flagged reviews stay visible until they are hidden
+var Statuses = []Status{StatusVisible, StatusFlagged, StatusHidden}
+
+// Entity is the rating and review a member left for a book, one per member and book
+type Entity struct {
+	ID        string    `db:"id" bson:"_id"`
+	BookID    string    `db:"book_id" bson:"book_id"`
+	MemberID  string    `db:"member_id" bson:"member_id"`
+	Rating    *int      `db:"rating" bson:"rating"`
+	Text      *string   `db:"text" bson:"text"`
+	Status    *Status   `db:"status" bson:"status"`
+	CreatedAt time.Time `db:"created_at" bson:"created_at"`
+}
@@ -0,0 +1,13 @@
+package review
+
+import "context"
+
+type Repository interface {
+	// List returns the reviews of the book, the newest first
+	List(ctx context.Context, bookID string) (dest []Entity, err error)
+	// ListByStatus returns the reviews of every book in the moderation state, the newest first
+	ListByStatus(ctx context.Context, status Status) (dest []Entity, err error)
+	Add(ctx context.Context, data Entity) (id string, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	Update(ctx context.Context, id string, data Entity) (err error)
+}
@@ -0,0 +1,69 @@
+package series
+
+import (
+	"errors"
+	"net/http"
+
+	"library-service/internal/domain/book"
+)
+
+var (
+	ErrorUnknown     = errors.New("series: unknown series")
+	ErrorNoVolume    = errors.New("series: a book in a series needs a positive volume")
+	ErrorVolumeTaken = errors.New("series: the volume belongs to another book of the series")
+	ErrorInUse       = errors.New("series: cannot delete a series with volumes")
+)
+
+type Request struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.Name == "" {
+		return errors.New("name: cannot be blank")
+	}
+
+	return nil
+}
+
+// Response lists the volumes of the series in reading order
+type Response struct {
+	ID          string           `json:"id" validate:"required"`
+	Name        string           `json:"name" validate:"required"`
+	Description string           `json:"description,omitempty"`
+	Volumes     []book.VolumeRef `json:"volumes" validate:"required"`
+}
+
+func ParseFromEntity(data Entity, volumes []book.Entity) (res Response) {
+	res = Response{
+		ID:      data.ID,
+		Name:    *data.Name,
+		Volumes: make([]book.VolumeRef, 0, len(volumes)),
+	}
+
+	if data.Description != nil {
+		res.Description = *data.Description
+	}
+
+	for _, object := range book.ReadingOrder(volumes) {
+		res.Volumes = append(res.Volumes, book.ParseVolumeRef(object))
+	}
+	return
+}
+
+// ParseFromEntities groups the volumes by series, books outside of the series are skipped
+func ParseFromEntities(data []Entity, books []book.Entity) (res []Response) {
+	volumes := make(map[string][]book.Entity, len(data))
+	for _, object := range books {
+		if object.SeriesID != nil {
+			volumes[*object.SeriesID] = append(volumes[*object.SeriesID], object)
+		}
+	}
+
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object, volumes[object.ID]))
+	}
+	return
+}
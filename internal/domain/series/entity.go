@@ -0,0 +1,8 @@
+package series
+
+// Entity is a multi-part work, its volumes are the books that reference it with their volume number
+type Entity struct {
+	ID          string  `db:"id" bson:"_id"`
+	Name        *string `db:"name" bson:"name"`
+	Description *string `db:"description" bson:"description"`
+}
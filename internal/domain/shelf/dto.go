@@ -0,0 +1,96 @@
+package shelf
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"library-service/internal/domain/book"
+)
+
+// MaxNameLength is the most characters the name of a shelf may have
+const MaxNameLength = 100
+
+var (
+	ErrorExists = errors.New("name: the member already has a list with the name")
+	ErrorListed = errors.New("bookId: the book is already on the list")
+	// ErrorOrder is returned when the books of a reorder are not exactly the books on the shelf
+	ErrorOrder = errors.New("books: must be the books of the list in their new order")
+)
+
+type Request struct {
+	Name string `json:"name"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	s.Name = strings.TrimSpace(s.Name)
+	if s.Name == "" {
+		return errors.New("name: cannot be blank")
+	}
+
+	if len([]rune(s.Name)) > MaxNameLength {
+		return fmt.Errorf("name: cannot be longer than %d characters", MaxNameLength)
+	}
+
+	return nil
+}
+
+type BookRequest struct {
+	BookID string `json:"bookId"`
+}
+
+func (s *BookRequest) Bind(r *http.Request) error {
+	if s.BookID == "" {
+		return errors.New("bookId: cannot be blank")
+	}
+
+	return nil
+}
+
+// OrderRequest puts the books of the shelf in a new order, it has every book of the shelf once
+type OrderRequest struct {
+	Books []string `json:"books"`
+}
+
+func (s *OrderRequest) Bind(r *http.Request) error {
+	if s.Books == nil {
+		return errors.New("books: cannot be blank")
+	}
+
+	return nil
+}
+
+// Response lists the books of the shelf in its order, a book deleted from the catalog is left out.
+// The member and the share token are only shown to the member the shelf belongs to.
+type Response struct {
+	ID         string          `json:"id" validate:"required"`
+	MemberID   string          `json:"memberId,omitempty"`
+	Name       string          `json:"name" validate:"required"`
+	Books      []book.Response `json:"books" validate:"required"`
+	ShareToken string          `json:"shareToken,omitempty"`
+	CreatedAt  time.Time       `json:"createdAt" validate:"required" format:"date-time"`
+}
+
+func ParseFromEntity(data Entity, books []book.Response) (res Response) {
+	res = Response{
+		ID:        data.ID,
+		MemberID:  data.MemberID,
+		Name:      *data.Name,
+		Books:     books,
+		CreatedAt: data.CreatedAt,
+	}
+
+	if data.ShareToken != nil {
+		res.ShareToken = *data.ShareToken
+	}
+
+	return
+}
+
+// Shared hides the member and the share token of the shelf from the readers of its public link
+func (r Response) Shared() Response {
+	r.MemberID, r.ShareToken = "", ""
+	return r
+}
@@ -0,0 +1,27 @@
+package shelf
+
+import (
+	"time"
+)
+
+// Entity is a named reading list of a member, e.g. "Want to read". Books are the ids of the books on it
+// in the order the member put them in, and a shelf with a ShareToken can be read by anyone holding it.
+type Entity struct {
+	ID         string    `db:"id" bson:"_id"`
+	MemberID   string    `db:"member_id" bson:"member_id"`
+	Name       *string   `db:"name" bson:"name"`
+	Books      []string  `db:"books" bson:"books"`
+	ShareToken *string   `db:"share_token" bson:"share_token"`
+	CreatedAt  time.Time `db:"created_at" bson:"created_at"`
+}
+
+// Has tells whether the book is on the shelf
+func (e Entity) Has(bookID string) bool {
+	for _, id := range e.Books {
+		if id == bookID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,16 @@
+package shelf
+
+import "context"
+
+type Repository interface {
+	// List returns the shelves of the member in the order they were created
+	List(ctx context.Context, memberID string) (dest []Entity, err error)
+	// Add returns ErrorExists when the member already has a shelf with the name
+	Add(ctx context.Context, data Entity) (id string, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	// GetByToken returns the shelf shared with the token
+	GetByToken(ctx context.Context, token string) (dest Entity, err error)
+	// Update sets the name, the books and the share token of the shelf, a blank token stops sharing it
+	Update(ctx context.Context, id string, data Entity) (err error)
+	Delete(ctx context.Context, id string) (err error)
+}
@@ -0,0 +1,170 @@
+package suggestion
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"library-service/internal/domain/book"
+)
+
+var (
+	// ErrorOwned is returned when the library already has a book with the ISBN of the suggestion
+	ErrorOwned = errors.New("isbn: the library already owns the book")
+
+	// ErrorExists is returned when an open suggestion has the same ISBN, it can be voted for instead
+	ErrorExists = errors.New("isbn: the book is already suggested, vote for the suggestion instead")
+
+	// ErrorVoted is returned when the member already voted for the suggestion
+	ErrorVoted = errors.New("suggestion: the member already voted for it")
+
+	// ErrorSelfVote is returned when the member votes for their own suggestion
+	ErrorSelfVote = errors.New("suggestion: the member cannot vote for their own suggestion")
+
+	// ErrorClosed is returned when the suggestion was already added or rejected
+	ErrorClosed = errors.New("suggestion: it was already added or rejected")
+
+	// ErrorBook is returned when the book of an added suggestion is not valid, the reason is wrapped with it
+	ErrorBook = errors.New("book: the book of the added suggestion is not valid")
+
+	// ErrorTransition is returned when the suggestion cannot move to the status from its current one
+	ErrorTransition = errors.New("status: the suggestion cannot move to it from its current status")
+)
+
+type Request struct {
+	MemberID string    `json:"memberId"`
+	Title    string    `json:"title"`
+	Author   string    `json:"author"`
+	ISBN     book.ISBN `json:"isbn"`
+	Note     string    `json:"note"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.MemberID == "" {
+		return errors.New("memberId: cannot be blank")
+	}
+
+	if s.Title == "" {
+		return errors.New("title: cannot be blank")
+	}
+
+	// the isbn is optional, a member may not know it
+	if s.ISBN != "" {
+		isbn, err := book.ParseISBN(string(s.ISBN))
+		if err != nil {
+			return err
+		}
+		s.ISBN = isbn
+	}
+
+	return nil
+}
+
+type VoteRequest struct {
+	MemberID string `json:"memberId"`
+}
+
+func (s *VoteRequest) Bind(r *http.Request) error {
+	if s.MemberID == "" {
+		return errors.New("memberId: cannot be blank")
+	}
+
+	return nil
+}
+
+// StatusRequest moves the suggestion along the pipeline, Reason explains a rejection and
+// Book creates the book of an added suggestion, its blank name and isbn are taken from the suggestion
+type StatusRequest struct {
+	Status Status `json:"status"`
+	Reason string `json:"reason"`
+	Book   *Book  `json:"book,omitempty"`
+}
+
+// Book is the book.Request of an added suggestion, it is checked once the blanks are filled in
+// from the suggestion rather than on bind
+type Book book.Request
+
+func (s *StatusRequest) Bind(r *http.Request) error {
+	if !valid(s.Status) || s.Status == StatusUnderReview {
+		return errors.New("status: must be one of ordered, added, rejected")
+	}
+
+	if s.Status == StatusRejected && s.Reason == "" {
+		return errors.New("reason: cannot be blank for a rejected suggestion")
+	}
+
+	return nil
+}
+
+type Response struct {
+	ID        string    `json:"id" validate:"required"`
+	MemberID  string    `json:"memberId" validate:"required"`
+	Title     string    `json:"title" validate:"required"`
+	Author    string    `json:"author,omitempty"`
+	ISBN      book.ISBN `json:"isbn,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	Status    Status    `json:"status" validate:"required"`
+	Reason    string    `json:"reason,omitempty"`
+	BookID    string    `json:"bookId,omitempty"`
+	Votes     int       `json:"votes" validate:"required"`
+	CreatedAt time.Time `json:"createdAt" validate:"required" format:"date-time"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:        data.ID,
+		MemberID:  data.MemberID,
+		Title:     *data.Title,
+		Status:    *data.Status,
+		Votes:     len(data.Voters),
+		CreatedAt: data.CreatedAt,
+	}
+
+	if data.Author != nil {
+		res.Author = *data.Author
+	}
+
+	if data.ISBN != nil {
+		res.ISBN = *data.ISBN
+	}
+
+	if data.Note != nil {
+		res.Note = *data.Note
+	}
+
+	if data.Reason != nil {
+		res.Reason = *data.Reason
+	}
+
+	if data.BookID != nil {
+		res.BookID = *data.BookID
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
+
+// ParseStatus checks the status filter of a listing, blank lists every status
+func ParseStatus(status string) (Status, error) {
+	if status != "" && !valid(Status(status)) {
+		return "", errors.New("status: must be one of under_review, ordered, added, rejected")
+	}
+
+	return Status(status), nil
+}
+
+func valid(status Status) bool {
+	for _, object := range Statuses {
+		if object == status {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,67 @@
+package suggestion
+
+import (
+	"time"
+
+	"library-service/internal/domain/book"
+)
+
+type Status string
+
+const (
+	StatusUnderReview Status = "under_review"
+	StatusOrdered     Status = "ordered"
+	StatusAdded       Status = "added"
+	StatusRejected    Status = "rejected"
+)
+
+// Statuses lists the steps of the acquisition pipeline, a suggestion starts under review
+var Statuses = []Status{StatusUnderReview, StatusOrdered, StatusAdded, StatusRejected}
+
+// transitions are the statuses a suggestion may move to from each status,
+// added and rejected suggestions are closed
+var transitions = map[Status][]Status{
+	StatusUnderReview: {StatusOrdered, StatusAdded, StatusRejected},
+	StatusOrdered:     {StatusAdded, StatusRejected},
+}
+
+// Entity is a book a member asked the library to acquire, the other members vote for it
+// and BookID links the book once it is added to the catalog
+type Entity struct {
+	ID        string     `db:"id" bson:"_id"`
+	MemberID  string     `db:"member_id" bson:"member_id"`
+	Title     *string    `db:"title" bson:"title"`
+	Author    *string    `db:"author" bson:"author"`
+	ISBN      *book.ISBN `db:"isbn" bson:"isbn"`
+	Note      *string    `db:"note" bson:"note"`
+	Status    *Status    `db:"status" bson:"status"`
+	Reason    *string    `db:"reason" bson:"reason"`
+	BookID    *string    `db:"book_id" bson:"book_id"`
+	Voters    []string   `db:"voters" bson:"voters"`
+	CreatedAt time.Time  `db:"created_at" bson:"created_at"`
+}
+
+// Open tells whether the suggestion is still in the pipeline and can be voted for
+func (e Entity) Open() bool {
+	return e.Status != nil && len(transitions[*e.Status]) > 0
+}
+
+// CanMove tells whether the suggestion may move from its status to the given one
+func (e Entity) CanMove(status Status) bool {
+	if e.Status == nil {
+		return false
+	}
+
+	for _, next := range transitions[*e.Status] {
+		if next == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Audience is the member who made the suggestion and the members who voted for it
+func (e Entity) Audience() []string {
+	return append([]string{e.MemberID}, e.Voters...)
+}
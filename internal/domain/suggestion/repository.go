@@ -0,0 +1,18 @@
+package suggestion
+
+import "context"
+
+type Repository interface {
+	// List returns the suggestions in the status, or every suggestion for a blank status, the most voted first
+	List(ctx context.Context, status Status) (dest []Entity, err error)
+	Add(ctx context.Context, data Entity) (id string, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	Update(ctx context.Context, id string, data Entity) (err error)
+	// Vote adds the member to the voters of the suggestion, it returns ErrorVoted when the member already voted
+	Vote(ctx context.Context, id, memberID string) (err error)
+}
+
+// Notifier tells the members that the suggestion they made or voted for moved to its current status
+type Notifier interface {
+	Notify(ctx context.Context, members []string, data Entity) (err error)
+}
@@ -0,0 +1,50 @@
+package watch
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrorWatched = errors.New("memberId: already watches the book")
+	// ErrorAvailable is returned when a copy of the book is free already, there is nothing to wait for
+	ErrorAvailable = errors.New("book: has a copy available")
+)
+
+type Request struct {
+	MemberID string `json:"memberId"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.MemberID == "" {
+		return errors.New("memberId: cannot be blank")
+	}
+
+	return nil
+}
+
+type Response struct {
+	ID        string    `json:"id" validate:"required"`
+	BookID    string    `json:"bookId" validate:"required"`
+	MemberID  string    `json:"memberId" validate:"required"`
+	CreatedAt time.Time `json:"createdAt" validate:"required" format:"date-time"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:        data.ID,
+		BookID:    data.BookID,
+		MemberID:  data.MemberID,
+		CreatedAt: data.CreatedAt,
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
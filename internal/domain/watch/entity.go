@@ -0,0 +1,14 @@
+package watch
+
+import (
+	"time"
+)
+
+// Entity is a member who asked to be told once a copy of the book is free, without holding it.
+// The watch is dropped once the member was told.
+type Entity struct {
+	ID        string    `db:"id" bson:"_id"`
+	BookID    string    `db:"book_id" bson:"book_id"`
+	MemberID  string    `db:"member_id" bson:"member_id"`
+	CreatedAt time.Time `db:"created_at" bson:"created_at"`
+}
@@ -0,0 +1,20 @@
+package watch
+
+import (
+	"context"
+
+	"library-service/internal/domain/book"
+)
+
+type Repository interface {
+	// List returns the watches of the book, the oldest first
+	List(ctx context.Context, bookID string) (dest []Entity, err error)
+	// Add returns ErrorWatched when the member already watches the book
+	Add(ctx context.Context, data Entity) (id string, err error)
+	Delete(ctx context.Context, bookID, memberID string) (err error)
+}
+
+// Notifier tells the members watching the book that a copy of it is available
+type Notifier interface {
+	NotifyAvailable(ctx context.Context, members []string, data book.Entity) (err error)
+}
@@ -2,7 +2,6 @@ package handler
 
 import (
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/oauth"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 	"library-service/internal/service/payment"
@@ -11,9 +10,14 @@ import (
 	"library-service/internal/config"
 	"library-service/internal/handler/http"
 	"library-service/internal/service/auth"
+	"library-service/internal/service/export"
 	"library-service/internal/service/library"
+	"library-service/internal/service/status"
 	"library-service/internal/service/subscription"
+	"library-service/pkg/bulkhead"
 	"library-service/pkg/server/router"
+	"library-service/pkg/storage"
+	"library-service/pkg/worker"
 )
 
 type Dependencies struct {
@@ -22,6 +26,12 @@ type Dependencies struct {
 	PaymentService      *payment.Service
 	LibraryService      *library.Service
 	SubscriptionService *subscription.Service
+	ExportService       *export.Service
+	StatusService       *status.Service
+	Storage             storage.Storage
+	URLSigner           *storage.URLSigner
+	Bulkheads           *bulkhead.Group
+	Workers             *worker.Group
 }
 
 // Configuration is an alias for a function that will take in a pointer to a Handler and modify it
@@ -59,7 +69,26 @@ func WithHTTPHandler() Configuration {
 		// Create the http handler, if we needed parameters, such as connection strings they could be inputted here
 		h.HTTP = router.New()
 
-		h.HTTP.Use(middleware.Timeout(h.dependencies.Configs.APP.Timeout))
+		// Requests are shed by the priority of their route once the server is overloaded
+		shedder := router.NewShedder(h.dependencies.Configs.SHED.Limit, h.dependencies.Configs.SHED.Target)
+		if h.dependencies.Configs.SHED.Limit > 0 {
+			priorities := make(map[string]router.Priority, len(h.dependencies.Configs.SHED.Priorities))
+			for route, name := range h.dependencies.Configs.SHED.Priorities {
+				if priorities[route], err = router.ParsePriority(name); err != nil {
+					return
+				}
+			}
+			h.HTTP.Use(router.Shed(shedder, priorities, router.PriorityNormal, h.dependencies.Configs.SHED.RetryAfter))
+		}
+
+		// Bodies of the captured routes are logged at debug level
+		if len(h.dependencies.Configs.LOG.Capture) > 0 {
+			h.HTTP.Use(router.Capture(h.dependencies.Configs.LOG.Capture, h.dependencies.Configs.LOG.CaptureLimit, h.dependencies.Configs.LOG.Redact))
+		}
+
+		// Every request runs under the latency budget of its route
+		deadlineMetrics := router.NewDeadlineMetrics()
+		h.HTTP.Use(router.Budget(h.dependencies.Configs.APP.Timeout, h.dependencies.Configs.APP.Budgets, deadlineMetrics))
 
 		// Init swagger handler
 		docs.SwaggerInfo.BasePath = h.dependencies.Configs.APP.Path
@@ -69,23 +98,57 @@ func WithHTTPHandler() Configuration {
 		authHandler := oauth.NewBearerServer(
 			h.dependencies.Configs.TOKEN.Salt,
 			h.dependencies.Configs.TOKEN.Expires,
-			h.dependencies.AuthService, nil)
+			h.dependencies.AuthService, h.dependencies.AuthService.TokenFormatter())
 
 		h.HTTP.Post("/token", authHandler.UserCredentials)
 		h.HTTP.Post("/auth", authHandler.ClientCredentials)
 
+		sessionHandler := http.NewSessionHandler(h.dependencies.AuthService)
+
+		// Init file handler, its urls are signed instead of authorized with a token
+		fileHandler := http.NewFileHandler(h.dependencies.Storage, h.dependencies.URLSigner)
+		h.HTTP.Mount("/files", fileHandler.Routes())
+
+		// Init payment handler, the gateway calls back without a token, the callback only invalidates the cached status
+		paymentHandler := http.NewPaymentHandler(h.dependencies.PaymentService)
+		h.HTTP.Post("/callbacks/epay", paymentHandler.Callback)
+
+		// Init status handler, the status page is public
+		statusHandler := http.NewStatusHandler(h.dependencies.StatusService)
+		h.HTTP.Mount("/status", statusHandler.Routes())
+
+		// Init shared reading list handler, a shared list is read by anyone holding its link
+		shelfHandler := http.NewShelfHandler(h.dependencies.LibraryService)
+		h.HTTP.Mount("/lists", shelfHandler.Routes())
+
+		// Track clients still calling routes wrapped with router.Deprecated
+		deprecationUsage := router.NewDeprecationUsage(http.CredentialOf)
+
 		// Init service handlers
+		adminHandler := http.NewAdminHandler(h.dependencies.LibraryService, h.dependencies.StatusService, deprecationUsage, deadlineMetrics, shedder, h.dependencies.Bulkheads, h.dependencies.Workers)
 		authorHandler := http.NewAuthorHandler(h.dependencies.LibraryService)
+		exportHandler := http.NewExportHandler(h.dependencies.ExportService)
 		bookHandler := http.NewBookHandler(h.dependencies.LibraryService)
-		memberHandler := http.NewMemberHandler(h.dependencies.SubscriptionService)
+		copyHandler := http.NewCopyHandler(h.dependencies.LibraryService)
+		memberHandler := http.NewMemberHandler(h.dependencies.SubscriptionService, h.dependencies.LibraryService)
+		mobileHandler := http.NewMobileHandler(h.dependencies.LibraryService, h.dependencies.SubscriptionService)
+		seriesHandler := http.NewSeriesHandler(h.dependencies.LibraryService)
 
 		h.HTTP.Route("/", func(r chi.Router) {
 			// use the Bearer Authentication middleware
-			r.Use(oauth.Authorize(h.dependencies.Configs.TOKEN.Salt, nil))
+			r.Use(oauth.Authorize(h.dependencies.Configs.TOKEN.Salt, h.dependencies.AuthService.TokenFormatter()))
+
+			r.Post("/revoke", sessionHandler.Revoke)
 
+			r.Mount("/admin", adminHandler.Routes())
 			r.Mount("/authors", authorHandler.Routes())
 			r.Mount("/books", bookHandler.Routes())
+			r.Mount("/copies", copyHandler.Routes())
+			r.Mount("/exports", exportHandler.Routes())
 			r.Mount("/members", memberHandler.Routes())
+			r.Mount("/mobile/v1", mobileHandler.Routes())
+			r.Mount("/payments", paymentHandler.Routes())
+			r.Mount("/series", seriesHandler.Routes())
 		})
 
 		return
@@ -0,0 +1,183 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"library-service/internal/app"
+	"library-service/internal/config"
+	"library-service/internal/provider/webhook"
+	"library-service/pkg/log"
+)
+
+// notifySecret signs the bodies the webhook receives
+const notifySecret = "notify-secret"
+
+// client calls the handler the container wires for the http mode with the token of the test user,
+// over the memory store, with the national bank served from testdata/fixtures and a webhook that
+// keeps the notifications it receives
+type client struct {
+	server *httptest.Server
+	token  string
+
+	// check is called with every response when it is set, see TestContract
+	check func(t testing.TB, req *http.Request, res *http.Response, body []byte)
+
+	mu            sync.Mutex
+	notifications []notification
+}
+
+type notification struct {
+	signature string
+	body      []byte
+}
+
+func newClient(t *testing.T) *client {
+	t.Helper()
+
+	rates, err := os.ReadFile("testdata/fixtures/rates.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bank := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rss/get_rates.cfm" {
+			w.Header().Set("Content-Type", "text/xml")
+			w.Write(rates)
+		}
+	}))
+	t.Cleanup(bank.Close)
+
+	c := &client{}
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.notifications = append(c.notifications, notification{signature: r.Header.Get(webhook.SignatureHeader), body: body})
+	}))
+	t.Cleanup(receiver.Close)
+
+	t.Setenv("CONFIG_DIR", t.TempDir())
+	t.Setenv("CURRENCY_URL", bank.URL)
+	t.Setenv("NOTIFY_URL", receiver.URL)
+	t.Setenv("NOTIFY_SECRET", notifySecret)
+	t.Setenv("STORAGE_PATH", t.TempDir())
+
+	configs, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	container := app.NewContainer(configs, log.LoggerFromContext(context.Background()))
+	handlers, err := container.Handler()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the status page is filled by the checks the workers run, they are run once instead
+	statusService, err := container.StatusService()
+	if err != nil {
+		t.Fatal(err)
+	}
+	statusService.Check(context.Background(), time.Second)
+
+	c.server = httptest.NewServer(handlers.HTTP)
+	t.Cleanup(c.server.Close)
+	t.Cleanup(func() { container.Stop(context.Background()) })
+
+	form := url.Values{"grant_type": {"password"}, "username": {"user01"}, "password": {"12345"}}
+	res, err := http.PostForm(c.server.URL+"/token", form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&token); err != nil {
+		t.Fatal(err)
+	}
+	c.token = token.AccessToken
+
+	return c
+}
+
+// do sends the request with the json body, if any, and returns the response with its body read
+func (c *client) do(t testing.TB, method, path, body string) (*http.Response, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, c.server.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return c.send(t, req)
+}
+
+// send sends the request with the token of the test user and returns the response with its body read
+func (c *client) send(t testing.TB, req *http.Request) (*http.Response, []byte) {
+	t.Helper()
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.check != nil {
+		c.check(t, req, res, data)
+	}
+
+	return res, data
+}
+
+// create sends the request, which has to succeed, and returns the id of what it created
+func (c *client) create(t testing.TB, path, body string) string {
+	t.Helper()
+
+	res, data := c.do(t, http.MethodPost, path, body)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("POST %s: %s %s", path, res.Status, data)
+	}
+
+	var dest struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &dest); err != nil {
+		t.Fatalf("POST %s: %v %s", path, err, data)
+	}
+
+	return dest.Data.ID
+}
+
+// notified returns the notifications the webhook received since the last call
+func (c *client) notified() (dest []notification) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dest, c.notifications = c.notifications, nil
+
+	return
+}
@@ -0,0 +1,308 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/oauth"
+
+	"library-service/internal/domain/book"
+	"library-service/internal/domain/category"
+	"library-service/internal/domain/series"
+	"library-service/internal/service/library"
+	"library-service/internal/service/status"
+	"library-service/pkg/bulkhead"
+	"library-service/pkg/server/response"
+	"library-service/pkg/server/router"
+	"library-service/pkg/store"
+	"library-service/pkg/worker"
+)
+
+type AdminHandler struct {
+	libraryService   *library.Service
+	statusService    *status.Service
+	deprecationUsage *router.DeprecationUsage
+	deadlineMetrics  *router.DeadlineMetrics
+	shedder          *router.Shedder
+	bulkheads        *bulkhead.Group
+	workers          *worker.Group
+}
+
+func NewAdminHandler(l *library.Service, st *status.Service, u *router.DeprecationUsage, m *router.DeadlineMetrics, s *router.Shedder, b *bulkhead.Group, w *worker.Group) *AdminHandler {
+	return &AdminHandler{libraryService: l, statusService: st, deprecationUsage: u, deadlineMetrics: m, shedder: s, bulkheads: b, workers: w}
+}
+
+func (h *AdminHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/deprecations", h.listDeprecations)
+	r.Get("/deadlines", h.listDeadlines)
+	r.Get("/shedding", h.getShedding)
+	r.Get("/bulkheads", h.listBulkheads)
+	r.Get("/jobs", h.listJobs)
+
+	r.Route("/books", func(r chi.Router) {
+		r.Get("/", h.listBooks)
+		r.Get("/export", h.exportBooks)
+		r.Get("/duplicates", h.listDuplicateBooks)
+		r.Post("/{id}/restore", h.restoreBook)
+		r.Post("/{id}/merge", h.mergeBook)
+		r.Get("/{id}/history", h.listBookRevisions)
+		r.Post("/{id}/history/{revisionId}/rollback", h.rollbackBook)
+	})
+
+	r.Route("/categories", func(r chi.Router) {
+		r.Get("/", h.listCategories)
+		r.Post("/", h.addCategory)
+		r.Put("/{id}", h.updateCategory)
+		r.Delete("/{id}", h.deleteCategory)
+	})
+
+	r.Route("/incidents", func(r chi.Router) {
+		r.Get("/", h.listIncidents)
+		r.Post("/", h.addIncident)
+		r.Put("/{id}", h.updateIncident)
+		r.Delete("/{id}", h.deleteIncident)
+	})
+
+	r.Route("/reviews", func(r chi.Router) {
+		r.Get("/", h.listReviews)
+		r.Put("/{id}", h.moderateReview)
+	})
+
+	r.Route("/suggestions", func(r chi.Router) {
+		r.Get("/", h.listSuggestions)
+		r.Put("/{id}/status", h.moveSuggestion)
+	})
+
+	return r
+}
+
+// CredentialOf returns the oauth credential the request was authorized with
+func CredentialOf(r *http.Request) string {
+	credential, _ := r.Context().Value(oauth.CredentialContext).(string)
+	return credential
+}
+
+// @Summary	usage of deprecated routes per client
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Success	200	{array}	router.DeprecationCall
+// @Router		/admin/deprecations [get]
+func (h *AdminHandler) listDeprecations(w http.ResponseWriter, r *http.Request) {
+	response.OK(w, r, h.deprecationUsage.Report())
+}
+
+// @Summary	latency budget violations per route
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Success	200	{array}	router.DeadlineStat
+// @Router		/admin/deadlines [get]
+func (h *AdminHandler) listDeadlines(w http.ResponseWriter, r *http.Request) {
+	response.OK(w, r, h.deadlineMetrics.Report())
+}
+
+// @Summary	adaptive concurrency limit of the load shedding and the requests shed per priority
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Success	200	{object}	router.ShedStat
+// @Router		/admin/shedding [get]
+func (h *AdminHandler) getShedding(w http.ResponseWriter, r *http.Request) {
+	response.OK(w, r, h.shedder.Stat())
+}
+
+// @Summary	saturation of the bulkheads of the outbound dependencies
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Success	200	{array}	bulkhead.Stat
+// @Router		/admin/bulkheads [get]
+func (h *AdminHandler) listBulkheads(w http.ResponseWriter, r *http.Request) {
+	response.OK(w, r, h.bulkheads.Report())
+}
+
+// @Summary	state of the background workers, stuck ones took longer than expected and stalled ones stopped running
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Success	200	{array}	worker.Stat
+// @Router		/admin/jobs [get]
+func (h *AdminHandler) listJobs(w http.ResponseWriter, r *http.Request) {
+	response.OK(w, r, h.workers.Report())
+}
+
+// @Summary	list of books, the deleted ones too with include_deleted
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Param		include_deleted	query		bool	false	"list the deleted books too"
+// @Success	200				{array}		book.Response
+// @Failure	400				{object}	response.Object
+// @Failure	500				{object}	response.Object
+// @Router		/admin/books [get]
+func (h *AdminHandler) listBooks(w http.ResponseWriter, r *http.Request) {
+	includeDeleted := false
+	if value := r.URL.Query().Get("include_deleted"); value != "" {
+		var err error
+		if includeDeleted, err = strconv.ParseBool(value); err != nil {
+			response.BadRequest(w, r, errors.New("include_deleted: must be a boolean"), nil)
+			return
+		}
+	}
+
+	list := h.libraryService.ListBooks
+	if includeDeleted {
+		list = h.libraryService.ListBooksWithDeleted
+	}
+
+	res, err := list(r.Context())
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	stream the catalog as CSV or JSON Lines, the books are written as they are read in the order of their ids
+// @Tags		admin
+// @Produce	text/csv
+// @Produce	application/x-ndjson
+// @Param		format		query	string	false	"csv (default) or jsonl"
+// @Param		columns		query	string	false	"comma separated columns, all of them by default"
+// @Param		category	query	string	false	"category path, e.g. fiction/fantasy, subcategories included"
+// @Param		tags		query	string	false	"comma separated tags the books must all have"
+// @Param		series		query	string	false	"series id"
+// @Param		isbn		query	string	false	"ISBN-10 or ISBN-13 matching the book in either edition"
+// @Success	200
+// @Failure	400	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/admin/books/export [get]
+func (h *AdminHandler) exportBooks(w http.ResponseWriter, r *http.Request) {
+	filter, err := filterOf(r)
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	format, err := book.ParseExportFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	columns, err := book.ParseExportColumns(r.URL.Query().Get("columns"))
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	// the headers are only set once the filter is checked, an unknown category is still a bad request
+	started := false
+	start := func() {
+		if !started {
+			started = true
+			w.Header().Set("Content-Type", format.ContentType())
+			w.Header().Set("Content-Disposition", "attachment; filename=books."+string(format))
+		}
+	}
+
+	exporter := book.NewExporter(w, format, columns)
+	err = h.libraryService.EachBook(r.Context(), filter, func(data book.Response) error {
+		start()
+		return exporter.Write(data)
+	})
+	if err == nil {
+		start()
+		err = exporter.Flush()
+	}
+
+	if err != nil {
+		if started {
+			// the books already written cannot be taken back, aborting tells the client the export is incomplete
+			panic(http.ErrAbortHandler)
+		}
+
+		switch {
+		case errors.Is(err, category.ErrorUnknown), errors.Is(err, series.ErrorUnknown):
+			response.BadRequest(w, r, err, nil)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+	}
+}
+
+// @Summary	restore the deleted book
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Param		id	path	string	true	"path param"
+// @Success	200
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/admin/books/{id}/restore [post]
+func (h *AdminHandler) restoreBook(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if err := h.libraryService.RestoreBook(r.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
+
+// @Summary	history of changes of the book, the newest first
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{array}		book.RevisionResponse
+// @Failure	500	{object}	response.Object
+// @Router		/admin/books/{id}/history [get]
+func (h *AdminHandler) listBookRevisions(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.libraryService.ListBookRevisions(r.Context(), id)
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	undo the change of the revision, the book is put back in its state before it
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Param		id			path	string		true	"path param"
+// @Param		revisionId	path	string	true	"path param"
+// @Success	200
+// @Failure	400	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/admin/books/{id}/history/{revisionId}/rollback [post]
+func (h *AdminHandler) rollbackBook(w http.ResponseWriter, r *http.Request) {
+	id, revisionID := chi.URLParam(r, "id"), chi.URLParam(r, "revisionId")
+
+	if err := h.libraryService.RollbackBook(r.Context(), id, revisionID); err != nil {
+		switch {
+		case errors.Is(err, book.ErrorRollback):
+			response.BadRequest(w, r, err, nil)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"library-service/internal/domain/author"
 	"library-service/internal/service/library"
+	"library-service/pkg/bulkhead"
 	"library-service/pkg/server/response"
 	"library-service/pkg/store"
 )
@@ -31,6 +32,7 @@ func (h *AuthorHandler) Routes() chi.Router {
 		r.Get("/", h.get)
 		r.Put("/", h.update)
 		r.Delete("/", h.delete)
+		r.Post("/enrich", h.enrich)
 	})
 
 	return r
@@ -82,7 +84,7 @@ func (h *AuthorHandler) add(w http.ResponseWriter, r *http.Request) {
 // @Tags		authors
 // @Accept		json
 // @Produce	json
-// @Param		id	path		int	true	"path param"
+// @Param		id	path		string	true	"path param"
 // @Success	200	{object}	author.Response
 // @Failure	404	{object}	response.Object
 // @Failure	500	{object}	response.Object
@@ -108,7 +110,7 @@ func (h *AuthorHandler) get(w http.ResponseWriter, r *http.Request) {
 // @Tags		authors
 // @Accept		json
 // @Produce	json
-// @Param		id		path	int				true	"path param"
+// @Param		id		path	string				true	"path param"
 // @Param		request	body	author.Request	true	"body param"
 // @Success	200
 // @Failure	400	{object}	response.Object
@@ -139,7 +141,7 @@ func (h *AuthorHandler) update(w http.ResponseWriter, r *http.Request) {
 // @Tags		authors
 // @Accept		json
 // @Produce	json
-// @Param		id	path	int	true	"path param"
+// @Param		id	path	string	true	"path param"
 // @Success	200
 // @Failure	404	{object}	response.Object
 // @Failure	500	{object}	response.Object
@@ -157,3 +159,35 @@ func (h *AuthorHandler) delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// @Summary	fill in the identifiers, biography and photo of the author from wikidata, the fields edited by hand are kept
+// @Tags		authors
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	author.Response
+// @Failure	400	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Failure	503	{object}	response.Object
+// @Router		/authors/{id}/enrich [post]
+func (h *AuthorHandler) enrich(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.libraryService.EnrichAuthor(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, author.ErrorEnrichmentDisabled), errors.Is(err, author.ErrorNoIdentifier):
+			response.BadRequest(w, r, err, nil)
+		case errors.Is(err, author.ErrorNoProfile), errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		case errors.Is(err, bulkhead.ErrFull):
+			response.ServiceUnavailable(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
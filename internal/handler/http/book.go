@@ -3,12 +3,16 @@ package http
 import (
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 
 	"library-service/internal/domain/book"
+	"library-service/internal/domain/category"
+	"library-service/internal/domain/series"
 	"library-service/internal/service/library"
+	"library-service/pkg/bulkhead"
 	"library-service/pkg/server/response"
 	"library-service/pkg/store"
 )
@@ -26,12 +30,42 @@ func (h *BookHandler) Routes() chi.Router {
 
 	r.Get("/", h.list)
 	r.Post("/", h.add)
+	r.Get("/search", h.search)
+	r.Get("/new-arrivals", h.listNewArrivals)
+	r.Get("/trending", h.listTrending)
+
+	r.Route("/suggestions", func(r chi.Router) {
+		r.Get("/", h.listSuggestions)
+		r.Post("/", h.addSuggestion)
+		r.Get("/{id}", h.getSuggestion)
+		r.Post("/{id}/votes", h.voteSuggestion)
+	})
+	r.Post("/availability", h.listAvailability)
 
 	r.Route("/{id}", func(r chi.Router) {
 		r.Get("/", h.get)
 		r.Put("/", h.update)
+		r.Patch("/", h.patch)
 		r.Delete("/", h.delete)
 		r.Get("/authors", h.listAuthors)
+		r.Get("/availability", h.availability)
+		r.Post("/cover", h.uploadCover)
+		r.Post("/watch", h.watchBook)
+		r.Delete("/watch/{memberId}", h.unwatchBook)
+
+		r.Route("/reviews", func(r chi.Router) {
+			r.Get("/", h.listReviews)
+			r.Post("/", h.addReview)
+			r.Put("/{reviewId}", h.updateReview)
+		})
+
+		r.Route("/copies", func(r chi.Router) {
+			r.Get("/", h.listCopies)
+			r.Post("/", h.addCopy)
+			r.Get("/{copyId}", h.getCopy)
+			r.Put("/{copyId}", h.updateCopy)
+			r.Delete("/{copyId}", h.deleteCopy)
+		})
 	})
 
 	return r
@@ -41,11 +75,85 @@ func (h *BookHandler) Routes() chi.Router {
 // @Tags		books
 // @Accept		json
 // @Produce	json
-// @Success	200		{array}		book.Response
-// @Failure	500		{object}	response.Object
+// @Param		category	query		string	false	"category path, e.g. fiction/fantasy, subcategories included"
+// @Param		tags		query		string	false	"comma separated tags the books must all have"
+// @Param		series		query		string	false	"series id, its volumes are listed in reading order"
+// @Param		isbn		query		string	false	"ISBN-10 or ISBN-13, with or without hyphens, matching the book in either edition"
+// @Param		limit		query		int		false	"page size, 100 by default and 1000 at most"
+// @Param		offset		query		int		false	"number of books to skip"
+// @Success	200			{array}		book.Response
+// @Failure	400			{object}	response.Object
+// @Failure	500			{object}	response.Object
 // @Router		/books 	[get]
 func (h *BookHandler) list(w http.ResponseWriter, r *http.Request) {
-	res, err := h.libraryService.ListBooks(r.Context())
+	filter, err := filterOf(r)
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	limit, offset, err := parsePage(r, book.DefaultListLimit, book.MaxListLimit)
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	res, err := h.libraryService.FilterBooks(r.Context(), filter, limit, offset)
+	if err != nil {
+		switch {
+		case errors.Is(err, category.ErrorUnknown), errors.Is(err, series.ErrorUnknown):
+			response.BadRequest(w, r, err, nil)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// filterOf reads the category, tags, series and isbn query params of a list of books
+func filterOf(r *http.Request) (filter book.Filter, err error) {
+	filter = book.Filter{
+		Category: r.URL.Query().Get("category"),
+		Series:   r.URL.Query().Get("series"),
+	}
+	if tags := r.URL.Query().Get("tags"); tags != "" {
+		filter.Tags = strings.Split(tags, ",")
+	}
+
+	if isbn := r.URL.Query().Get("isbn"); isbn != "" {
+		filter.ISBN, err = book.ParseISBN(isbn)
+	}
+
+	return
+}
+
+// @Summary	search the books by name, genre and authors, the most relevant first
+// @Tags		books
+// @Accept		json
+// @Produce	json
+// @Param		q		query		string	true	"words matched as prefixes"
+// @Param		limit	query		int		false	"page size, 20 by default and 100 at most"
+// @Param		offset	query		int		false	"number of books to skip"
+// @Success	200		{array}		book.Response
+// @Failure	400		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/books/search [get]
+func (h *BookHandler) search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if len(book.SearchTerms(query)) == 0 {
+		response.BadRequest(w, r, errors.New("q: cannot be blank"), nil)
+		return
+	}
+
+	limit, offset, err := parsePage(r, book.DefaultSearchLimit, book.MaxSearchLimit)
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	res, err := h.libraryService.SearchBooks(r.Context(), query, limit, offset)
 	if err != nil {
 		response.InternalServerError(w, r, err)
 		return
@@ -54,7 +162,7 @@ func (h *BookHandler) list(w http.ResponseWriter, r *http.Request) {
 	response.OK(w, r, res)
 }
 
-// @Summary	add a new book to the repository
+// @Summary	add a new book to the repository, blank fields are filled in from the isbn when metadata lookup is enabled
 // @Tags		books
 // @Accept		json
 // @Produce	json
@@ -62,6 +170,7 @@ func (h *BookHandler) list(w http.ResponseWriter, r *http.Request) {
 // @Success	200		{object}	book.Response
 // @Failure	400		{object}	response.Object
 // @Failure	500		{object}	response.Object
+// @Failure	503		{object}	response.Object
 // @Router		/books [post]
 func (h *BookHandler) add(w http.ResponseWriter, r *http.Request) {
 	req := book.Request{}
@@ -72,7 +181,14 @@ func (h *BookHandler) add(w http.ResponseWriter, r *http.Request) {
 
 	res, err := h.libraryService.CreateBook(r.Context(), req)
 	if err != nil {
-		response.InternalServerError(w, r, err)
+		switch {
+		case errors.Is(err, bulkhead.ErrFull):
+			response.ServiceUnavailable(w, r, err)
+		case errors.Is(err, book.ErrorIncomplete), errors.Is(err, category.ErrorUnknown), errors.Is(err, series.ErrorUnknown), errors.Is(err, series.ErrorNoVolume), errors.Is(err, series.ErrorVolumeTaken):
+			response.BadRequest(w, r, err, req)
+		default:
+			response.InternalServerError(w, r, err)
+		}
 		return
 	}
 
@@ -83,7 +199,7 @@ func (h *BookHandler) add(w http.ResponseWriter, r *http.Request) {
 // @Tags		books
 // @Accept		json
 // @Produce	json
-// @Param		id	path		int	true	"path param"
+// @Param		id	path		string	true	"path param"
 // @Success	200	{object}	book.Response
 // @Failure	404	{object}	response.Object
 // @Failure	500	{object}	response.Object
@@ -109,7 +225,7 @@ func (h *BookHandler) get(w http.ResponseWriter, r *http.Request) {
 // @Tags		books
 // @Accept		json
 // @Produce	json
-// @Param		id		path	int				true	"path param"
+// @Param		id		path	string				true	"path param"
 // @Param		request	body	book.Request	true	"body param"
 // @Success	200
 // @Failure	400	{object}	response.Object
@@ -127,6 +243,41 @@ func (h *BookHandler) update(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.libraryService.UpdateBook(r.Context(), id, req); err != nil {
 		switch {
+		case errors.Is(err, book.ErrorIncomplete), errors.Is(err, category.ErrorUnknown), errors.Is(err, series.ErrorUnknown), errors.Is(err, series.ErrorNoVolume), errors.Is(err, series.ErrorVolumeTaken):
+			response.BadRequest(w, r, err, req)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
+
+// @Summary	partially update the book in the repository
+// @Tags		books
+// @Accept		json
+// @Produce	json
+// @Param		id		path	string					true	"path param"
+// @Param		request	body	book.PatchRequest	true	"body param"
+// @Success	200
+// @Failure	400	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/books/{id} [patch]
+func (h *BookHandler) patch(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := book.PatchRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	if err := h.libraryService.PatchBook(r.Context(), id, req); err != nil {
+		switch {
+		case errors.Is(err, category.ErrorUnknown), errors.Is(err, series.ErrorUnknown), errors.Is(err, series.ErrorNoVolume), errors.Is(err, series.ErrorVolumeTaken):
+			response.BadRequest(w, r, err, req)
 		case errors.Is(err, store.ErrorNotFound):
 			response.NotFound(w, r, err)
 		default:
@@ -136,11 +287,11 @@ func (h *BookHandler) update(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// @Summary	delete the book from the repository
+// @Summary	delete the book from the repository, admins can restore it
 // @Tags		books
 // @Accept		json
 // @Produce	json
-// @Param		id	path	int	true	"path param"
+// @Param		id	path	string	true	"path param"
 // @Success	200
 // @Failure	404	{object}	response.Object
 // @Failure	500	{object}	response.Object
@@ -163,7 +314,7 @@ func (h *BookHandler) delete(w http.ResponseWriter, r *http.Request) {
 // @Tags		books
 // @Accept		json
 // @Produce	json
-// @Param		id	path		int	true	"path param"
+// @Param		id	path		string	true	"path param"
 // @Success	200	{array}		author.Response
 // @Failure	404	{object}	response.Object
 // @Failure	500	{object}	response.Object
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"library-service/internal/domain/category"
+	"library-service/pkg/server/response"
+	"library-service/pkg/store"
+)
+
+// @Summary	list of the categories with their paths
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Success	200	{array}		category.Response
+// @Failure	500	{object}	response.Object
+// @Router		/admin/categories [get]
+func (h *AdminHandler) listCategories(w http.ResponseWriter, r *http.Request) {
+	res, err := h.libraryService.ListCategories(r.Context())
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	add a new category, under the parent if any
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Param		request	body		category.Request	true	"body param"
+// @Success	200		{object}	category.Response
+// @Failure	400		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/admin/categories [post]
+func (h *AdminHandler) addCategory(w http.ResponseWriter, r *http.Request) {
+	req := category.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.libraryService.CreateCategory(r.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, category.ErrorUnknown), errors.Is(err, category.ErrorExists):
+			response.BadRequest(w, r, err, req)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	rename the category or move it under another parent
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Param		id		path	string				true	"path param"
+// @Param		request	body	category.Request	true	"body param"
+// @Success	200
+// @Failure	400	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/admin/categories/{id} [put]
+func (h *AdminHandler) updateCategory(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := category.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	if err := h.libraryService.UpdateCategory(r.Context(), id, req); err != nil {
+		switch {
+		case errors.Is(err, category.ErrorUnknown), errors.Is(err, category.ErrorExists), errors.Is(err, category.ErrorCycle):
+			response.BadRequest(w, r, err, req)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
+
+// @Summary	delete the category, it must have no subcategories or books
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Param		id	path	string	true	"path param"
+// @Success	200
+// @Failure	400	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/admin/categories/{id} [delete]
+func (h *AdminHandler) deleteCategory(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if err := h.libraryService.DeleteCategory(r.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, category.ErrorInUse):
+			response.BadRequest(w, r, err, nil)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
@@ -0,0 +1,210 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/server/response"
+	"library-service/pkg/store"
+)
+
+// @Summary	list of the physical copies of the book
+// @Tags		books
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{array}		book.CopyResponse
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/books/{id}/copies [get]
+func (h *BookHandler) listCopies(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.libraryService.ListBookCopies(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	add a new physical copy of the book
+// @Tags		books
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string					true	"path param"
+// @Param		request	body		book.CopyRequest	true	"body param"
+// @Success	200		{object}	book.CopyResponse
+// @Failure	400		{object}	response.Object
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/books/{id}/copies [post]
+func (h *BookHandler) addCopy(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := book.CopyRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.libraryService.AddBookCopy(r.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	get the physical copy of the book
+// @Tags		books
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string		true	"path param"
+// @Param		copyId	path		string	true	"path param"
+// @Success	200		{object}	book.CopyResponse
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/books/{id}/copies/{copyId} [get]
+func (h *BookHandler) getCopy(w http.ResponseWriter, r *http.Request) {
+	id, copyID := chi.URLParam(r, "id"), chi.URLParam(r, "copyId")
+
+	res, err := h.libraryService.GetBookCopy(r.Context(), id, copyID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	update the physical copy of the book, e.g. its location or status
+// @Tags		books
+// @Accept		json
+// @Produce	json
+// @Param		id		path	string					true	"path param"
+// @Param		copyId	path	string				true	"path param"
+// @Param		request	body	book.CopyRequest	true	"body param"
+// @Success	200
+// @Failure	400	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/books/{id}/copies/{copyId} [put]
+func (h *BookHandler) updateCopy(w http.ResponseWriter, r *http.Request) {
+	id, copyID := chi.URLParam(r, "id"), chi.URLParam(r, "copyId")
+
+	req := book.CopyRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	if err := h.libraryService.UpdateBookCopy(r.Context(), id, copyID, req); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
+
+// @Summary	delete the physical copy of the book
+// @Tags		books
+// @Accept		json
+// @Produce	json
+// @Param		id		path	string		true	"path param"
+// @Param		copyId	path	string	true	"path param"
+// @Success	200
+// @Failure	400	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/books/{id}/copies/{copyId} [delete]
+func (h *BookHandler) deleteCopy(w http.ResponseWriter, r *http.Request) {
+	id, copyID := chi.URLParam(r, "id"), chi.URLParam(r, "copyId")
+
+	if err := h.libraryService.DeleteBookCopy(r.Context(), id, copyID); err != nil {
+		switch {
+		case errors.Is(err, book.ErrorCheckedOut):
+			response.BadRequest(w, r, err, nil)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
+
+// @Summary	count the copies of the book that are available
+// @Tags		books
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	book.Availability
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/books/{id}/availability [get]
+func (h *BookHandler) availability(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.libraryService.GetBookAvailability(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	count the available copies of many books in one request
+// @Tags		books
+// @Accept		json
+// @Produce	json
+// @Param		request	body		book.AvailabilityRequest	true	"up to 200 book ids"
+// @Success	200		{array}		book.Availability
+// @Failure	400		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/books/availability [post]
+func (h *BookHandler) listAvailability(w http.ResponseWriter, r *http.Request) {
+	req := book.AvailabilityRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.libraryService.ListBooksAvailability(r.Context(), req.IDs)
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"bufio"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/server/response"
+	"library-service/pkg/store"
+)
+
+// @Summary	upload the cover image of the book, it is resized to a thumbnail and a detail variant
+// @Tags		books
+// @Accept		multipart/form-data
+// @Produce	json
+// @Param		id		path		string		true	"path param"
+// @Param		file	formData	file	true	"jpeg or png image of at most 5MB and 6000x6000 pixels"
+// @Success	200		{object}	book.Response
+// @Failure	400		{object}	response.Object
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/books/{id}/cover [post]
+func (h *BookHandler) uploadCover(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	r.Body = http.MaxBytesReader(w, r.Body, book.MaxCoverSize+1<<20)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+	defer file.Close()
+
+	if header.Size > book.MaxCoverSize {
+		response.BadRequest(w, r, book.ErrorInvalidCover, nil)
+		return
+	}
+
+	src := bufio.NewReaderSize(file, 512)
+	head, _ := src.Peek(512)
+	if !contains(book.CoverTypes, http.DetectContentType(head)) {
+		response.BadRequest(w, r, book.ErrorInvalidCover, nil)
+		return
+	}
+
+	res, err := h.libraryService.UploadBookCover(r.Context(), id, src)
+	if err != nil {
+		switch {
+		case errors.Is(err, book.ErrorInvalidCover):
+			response.BadRequest(w, r, err, nil)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/server/response"
+	"library-service/pkg/store"
+)
+
+// @Summary	groups of books that are likely entered more than once, by isbn or by a similar title and a shared author
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Success	200	{array}		book.DuplicatesResponse
+// @Failure	500	{object}	response.Object
+// @Router		/admin/books/duplicates [get]
+func (h *AdminHandler) listDuplicateBooks(w http.ResponseWriter, r *http.Request) {
+	res, err := h.libraryService.FindDuplicateBooks(r.Context())
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	merge the duplicate into the book, its copies, reviews and loans move over and the duplicate is deleted
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Param		id		path	string					true	"path param"
+// @Param		request	body	book.MergeRequest	true	"body param"
+// @Success	200
+// @Failure	400	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/admin/books/{id}/merge [post]
+func (h *AdminHandler) mergeBook(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := book.MergeRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	if err := h.libraryService.MergeBooks(r.Context(), id, req.DuplicateID); err != nil {
+		switch {
+		case errors.Is(err, book.ErrorSelfMerge):
+			response.BadRequest(w, r, err, req)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"library-service/internal/domain/export"
+	exports "library-service/internal/service/export"
+	"library-service/pkg/server/response"
+	"library-service/pkg/store"
+)
+
+type ExportHandler struct {
+	exportService *exports.Service
+}
+
+func NewExportHandler(s *exports.Service) *ExportHandler {
+	return &ExportHandler{exportService: s}
+}
+
+func (h *ExportHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/", h.add)
+	r.Get("/{id}", h.get)
+
+	return r
+}
+
+// @Summary	start a new export job, its result is polled by id
+// @Tags		exports
+// @Accept		json
+// @Produce	json
+// @Param		request	body		export.Request	true	"body param"
+// @Success	200		{object}	export.Response
+// @Failure	400		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/exports [post]
+func (h *ExportHandler) add(w http.ResponseWriter, r *http.Request) {
+	req := export.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.exportService.CreateExport(r.Context(), req)
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	get the progress of the export job and the signed url once it is completed
+// @Tags		exports
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	export.Response
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/exports/{id} [get]
+func (h *ExportHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.exportService.GetExport(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
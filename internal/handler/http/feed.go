@@ -0,0 +1,77 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/server/response"
+)
+
+// feedMaxAge matches the interval the feeds are aggregated at by default, they don't change in between
+const feedMaxAge = "public, max-age=300"
+
+// @Summary	books added to the catalog most recently, the latest first
+// @Tags		books
+// @Accept		json
+// @Produce	json
+// @Param		limit	query		int		false	"page size, 20 by default and 100 at most"
+// @Param		offset	query		int		false	"number of books to skip"
+// @Success	200		{array}		book.Response
+// @Failure	400		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/books/new-arrivals [get]
+func (h *BookHandler) listNewArrivals(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := parsePage(r, book.DefaultFeedLimit, book.MaxFeedLimit)
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	res, err := h.libraryService.ListNewArrivals(r.Context(), limit, offset)
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+	w.Header().Set("Cache-Control", feedMaxAge)
+
+	response.OK(w, r, res)
+}
+
+// @Summary	books checked out the most over the window, the most checked out first
+// @Tags		books
+// @Accept		json
+// @Produce	json
+// @Param		window	query		string	false	"7d by default or 30d"
+// @Param		limit	query		int		false	"page size, 20 by default and 100 at most"
+// @Param		offset	query		int		false	"number of books to skip"
+// @Success	200		{array}		book.Response
+// @Failure	400		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/books/trending [get]
+func (h *BookHandler) listTrending(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := parsePage(r, book.DefaultFeedLimit, book.MaxFeedLimit)
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	window := r.URL.Query().Get("window")
+	if window == "" {
+		window = book.DefaultTrendingWindow
+	}
+
+	res, err := h.libraryService.ListTrendingBooks(r.Context(), window, limit, offset)
+	if err != nil {
+		switch {
+		case errors.Is(err, book.ErrorTrendingWindow):
+			response.BadRequest(w, r, err, nil)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+	w.Header().Set("Cache-Control", feedMaxAge)
+
+	response.OK(w, r, res)
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"library-service/pkg/server/response"
+	"library-service/pkg/storage"
+)
+
+// FileHandler serves stored files to anyone holding a signed url, it is mounted outside of the bearer authentication
+type FileHandler struct {
+	storage   storage.Storage
+	urlSigner *storage.URLSigner
+}
+
+func NewFileHandler(s storage.Storage, u *storage.URLSigner) *FileHandler {
+	return &FileHandler{storage: s, urlSigner: u}
+}
+
+func (h *FileHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/*", h.download)
+
+	return r
+}
+
+// @Summary	download the stored file with a signed url
+// @Tags		files
+// @Produce	jpeg
+// @Produce	text/csv
+// @Produce	octet-stream
+// @Param		key			path	string	true	"path param"
+// @Param		expires		query	string	true	"query param"
+// @Param		signature	query	string	true	"query param"
+// @Success	200
+// @Failure	403	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/files/{key} [get]
+func (h *FileHandler) download(w http.ResponseWriter, r *http.Request) {
+	key := chi.URLParam(r, "*")
+
+	if err := h.urlSigner.Verify(key, r.URL.Query()); err != nil {
+		response.Forbidden(w, r, err)
+		return
+	}
+
+	file, err := h.storage.Open(r.Context(), key)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrorNotFound), errors.Is(err, storage.ErrorInvalidKey):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+	defer file.Close()
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+path.Base(key)+"\"")
+
+	// a file is written once under its key, it is cached for as long as the link is valid
+	if expires, err := strconv.ParseInt(r.URL.Query().Get("expires"), 10, 64); err == nil {
+		if maxAge := expires - time.Now().Unix(); maxAge > 0 {
+			w.Header().Set("Cache-Control", "private, max-age="+strconv.FormatInt(maxAge, 10)+", immutable")
+		}
+	}
+
+	io.Copy(w, file)
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"library-service/internal/domain/health"
+	"library-service/pkg/server/response"
+	"library-service/pkg/store"
+)
+
+// @Summary	list of the incidents on the status page, the newest first
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Param		current	query		bool	false	"only the ones not resolved yet"
+// @Success	200		{array}		health.IncidentResponse
+// @Failure	500		{object}	response.Object
+// @Router		/admin/incidents [get]
+func (h *AdminHandler) listIncidents(w http.ResponseWriter, r *http.Request) {
+	current := r.URL.Query().Get("current") == "true"
+
+	res, err := h.statusService.ListIncidents(r.Context(), current)
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	report a new incident on the status page
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Param		request	body		health.IncidentRequest	true	"body param"
+// @Success	200		{object}	health.IncidentResponse
+// @Failure	400		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/admin/incidents [post]
+func (h *AdminHandler) addIncident(w http.ResponseWriter, r *http.Request) {
+	req := health.IncidentRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.statusService.AddIncident(r.Context(), req)
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	update the report of the incident, a resolved one cannot be reopened
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Param		id		path	string					true	"path param"
+// @Param		request	body	health.IncidentRequest	true	"body param"
+// @Success	200
+// @Failure	400	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/admin/incidents/{id} [put]
+func (h *AdminHandler) updateIncident(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := health.IncidentRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	if err := h.statusService.UpdateIncident(r.Context(), id, req); err != nil {
+		switch {
+		case errors.Is(err, health.ErrorResolved):
+			response.BadRequest(w, r, err, req)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
+
+// @Summary	delete the incident from the status page
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Param		id	path	string	true	"path param"
+// @Success	200
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/admin/incidents/{id} [delete]
+func (h *AdminHandler) deleteIncident(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if err := h.statusService.DeleteIncident(r.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
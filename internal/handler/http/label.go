@@ -0,0 +1,137 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"library-service/internal/domain/book"
+	"library-service/internal/service/library"
+	"library-service/pkg/server/response"
+	"library-service/pkg/store"
+)
+
+// CopyHandler serves the copies by their own id or barcode, the way the checkout desk knows them
+type CopyHandler struct {
+	libraryService *library.Service
+}
+
+func NewCopyHandler(s *library.Service) *CopyHandler {
+	return &CopyHandler{libraryService: s}
+}
+
+func (h *CopyHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/by-barcode/{code}", h.getByBarcode)
+	r.Post("/labels", h.labels)
+	r.Get("/{id}/label", h.label)
+
+	return r
+}
+
+// @Summary	get the copy the barcode is printed on, used by the checkout desk scanner
+// @Tags		copies
+// @Accept		json
+// @Produce	json
+// @Param		code	path		string	true	"path param"
+// @Success	200		{object}	book.CopyResponse
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/copies/by-barcode/{code} [get]
+func (h *CopyHandler) getByBarcode(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+
+	res, err := h.libraryService.GetCopyByBarcode(r.Context(), code)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	printable label of the copy with its barcode
+// @Tags		copies
+// @Produce	png
+// @Produce	application/pdf
+// @Param		id			path	string	true	"path param"
+// @Param		symbology	query	string	false	"code128 (default) or qr"
+// @Param		format		query	string	false	"png (default) or pdf, only the pdf has the book name"
+// @Success	200
+// @Failure	400	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/copies/{id}/label [get]
+func (h *CopyHandler) label(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	symbology, err := book.ParseSymbology(r.URL.Query().Get("symbology"))
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	format, err := book.ParseLabelFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	res, err := h.libraryService.RenderCopyLabel(r.Context(), id, symbology, format)
+	if err != nil {
+		switch {
+		case errors.Is(err, book.ErrorBarcode):
+			response.BadRequest(w, r, err, nil)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Write(res)
+}
+
+// @Summary	pdf of the labels of many copies, one label per page
+// @Tags		copies
+// @Accept		json
+// @Produce	application/pdf
+// @Param		request	body	book.LabelRequest	true	"up to 200 copy ids"
+// @Success	200
+// @Failure	400	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/copies/labels [post]
+func (h *CopyHandler) labels(w http.ResponseWriter, r *http.Request) {
+	req := book.LabelRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.libraryService.RenderCopyLabels(r.Context(), req.IDs, req.Symbology)
+	if err != nil {
+		switch {
+		case errors.Is(err, book.ErrorBarcode):
+			response.BadRequest(w, r, err, req)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", book.LabelPDF.ContentType())
+	w.Write(res)
+}
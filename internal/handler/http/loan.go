@@ -0,0 +1,164 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"library-service/internal/domain/loan"
+	"library-service/pkg/server/response"
+	"library-service/pkg/store"
+)
+
+// @Summary	loan history of the member, the latest first
+// @Tags		members
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string		true	"path param"
+// @Param		active	query		bool	false	"only the loans not returned yet"
+// @Success	200		{array}		loan.Response
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/members/{id}/loans [get]
+func (h *MemberHandler) listLoans(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	active := r.URL.Query().Get("active") == "true"
+
+	res, err := h.libraryService.ListLoans(r.Context(), id, active)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	check out an available copy to the member until the due date of their tier
+// @Tags		members
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string				true	"path param"
+// @Param		request	body		loan.Request	true	"body param"
+// @Success	200		{object}	loan.Response
+// @Failure	400		{object}	response.Object
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/members/{id}/loans [post]
+func (h *MemberHandler) checkoutBook(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := loan.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.libraryService.CheckoutBook(r.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, loan.ErrorUnavailable), errors.Is(err, loan.ErrorLimit):
+			response.BadRequest(w, r, err, req)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	loan of the member
+// @Tags		members
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string		true	"path param"
+// @Param		loanId	path		string	true	"path param"
+// @Success	200		{object}	loan.Response
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/members/{id}/loans/{loanId} [get]
+func (h *MemberHandler) getLoan(w http.ResponseWriter, r *http.Request) {
+	id, loanID := chi.URLParam(r, "id"), chi.URLParam(r, "loanId")
+
+	res, err := h.libraryService.GetLoan(r.Context(), id, loanID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	return the copy of the loan, it is available to check out again
+// @Tags		members
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string		true	"path param"
+// @Param		loanId	path		string	true	"path param"
+// @Success	200		{object}	loan.Response
+// @Failure	400		{object}	response.Object
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/members/{id}/loans/{loanId}/return [post]
+func (h *MemberHandler) returnLoan(w http.ResponseWriter, r *http.Request) {
+	id, loanID := chi.URLParam(r, "id"), chi.URLParam(r, "loanId")
+
+	res, err := h.libraryService.ReturnLoan(r.Context(), id, loanID)
+	if err != nil {
+		switch {
+		case errors.Is(err, loan.ErrorReturned):
+			response.BadRequest(w, r, err, nil)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	renew the loan for another period of the member's tier, up to the renewals the tier allows
+// @Tags		members
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string		true	"path param"
+// @Param		loanId	path		string	true	"path param"
+// @Success	200		{object}	loan.Response
+// @Failure	400		{object}	response.Object
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/members/{id}/loans/{loanId}/renew [post]
+func (h *MemberHandler) renewLoan(w http.ResponseWriter, r *http.Request) {
+	id, loanID := chi.URLParam(r, "id"), chi.URLParam(r, "loanId")
+
+	res, err := h.libraryService.RenewLoan(r.Context(), id, loanID)
+	if err != nil {
+		switch {
+		case errors.Is(err, loan.ErrorReturned), errors.Is(err, loan.ErrorOverdue), errors.Is(err, loan.ErrorRenewals):
+			response.BadRequest(w, r, err, nil)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/go-chi/render"
 
 	"library-service/internal/domain/member"
+	"library-service/internal/service/library"
 	"library-service/internal/service/subscription"
 	"library-service/pkg/server/response"
 	"library-service/pkg/store"
@@ -15,10 +16,11 @@ import (
 
 type MemberHandler struct {
 	subscriptionService *subscription.Service
+	libraryService      *library.Service
 }
 
-func NewMemberHandler(s *subscription.Service) *MemberHandler {
-	return &MemberHandler{subscriptionService: s}
+func NewMemberHandler(s *subscription.Service, l *library.Service) *MemberHandler {
+	return &MemberHandler{subscriptionService: s, libraryService: l}
 }
 
 func (h *MemberHandler) Routes() chi.Router {
@@ -30,8 +32,30 @@ func (h *MemberHandler) Routes() chi.Router {
 	r.Route("/{id}", func(r chi.Router) {
 		r.Get("/", h.get)
 		r.Put("/", h.update)
+		r.Patch("/", h.patch)
 		r.Delete("/", h.delete)
 		r.Get("/books", h.listBooks)
+
+		r.Route("/loans", func(r chi.Router) {
+			r.Get("/", h.listLoans)
+			r.Post("/", h.checkoutBook)
+			r.Get("/{loanId}", h.getLoan)
+			r.Post("/{loanId}/return", h.returnLoan)
+			r.Post("/{loanId}/renew", h.renewLoan)
+		})
+
+		r.Route("/lists", func(r chi.Router) {
+			r.Get("/", h.listShelves)
+			r.Post("/", h.addShelf)
+			r.Get("/{listId}", h.getShelf)
+			r.Put("/{listId}", h.renameShelf)
+			r.Delete("/{listId}", h.deleteShelf)
+			r.Post("/{listId}/books", h.addShelfBook)
+			r.Put("/{listId}/books", h.orderShelf)
+			r.Delete("/{listId}/books/{bookId}", h.removeShelfBook)
+			r.Post("/{listId}/share", h.shareShelf)
+			r.Delete("/{listId}/share", h.unshareShelf)
+		})
 	})
 
 	return r
@@ -83,7 +107,7 @@ func (h *MemberHandler) add(w http.ResponseWriter, r *http.Request) {
 // @Tags		members
 // @Accept		json
 // @Produce	json
-// @Param		id	path		int	true	"path param"
+// @Param		id	path		string	true	"path param"
 // @Success	200	{object}	member.Response
 // @Failure	404	{object}	response.Object
 // @Failure	500	{object}	response.Object
@@ -109,7 +133,7 @@ func (h *MemberHandler) get(w http.ResponseWriter, r *http.Request) {
 // @Tags		members
 // @Accept		json
 // @Produce	json
-// @Param		id		path	int				true	"path param"
+// @Param		id		path	string				true	"path param"
 // @Param		request	body	member.Request	true	"body param"
 // @Success	200
 // @Failure	400	{object}	response.Object
@@ -136,11 +160,42 @@ func (h *MemberHandler) update(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// @Summary	partially update the member in the repository
+// @Tags		members
+// @Accept		json
+// @Produce	json
+// @Param		id		path	string					true	"path param"
+// @Param		request	body	member.PatchRequest	true	"body param"
+// @Success	200
+// @Failure	400	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/members/{id} [patch]
+func (h *MemberHandler) patch(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := member.PatchRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	if err := h.subscriptionService.PatchMember(r.Context(), id, req); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
+
 // @Summary	delete the member from the repository
 // @Tags		members
 // @Accept		json
 // @Produce	json
-// @Param		id	path	int	true	"path param"
+// @Param		id	path	string	true	"path param"
 // @Success	200
 // @Failure	404	{object}	response.Object
 // @Failure	500	{object}	response.Object
@@ -163,7 +218,7 @@ func (h *MemberHandler) delete(w http.ResponseWriter, r *http.Request) {
 // @Tags		members
 // @Accept		json
 // @Produce	json
-// @Param		id	path		int	true	"path param"
+// @Param		id	path		string	true	"path param"
 // @Success	200	{array}		book.Response
 // @Failure	404	{object}	response.Object
 // @Failure	500	{object}	response.Object
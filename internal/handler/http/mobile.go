@@ -0,0 +1,164 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/patrickmn/go-cache"
+
+	"library-service/internal/service/library"
+	"library-service/internal/service/subscription"
+	"library-service/pkg/server/response"
+	"library-service/pkg/store"
+)
+
+const (
+	homeScreenKey = "home"
+	homeScreenTTL = time.Minute
+
+	checkoutScreenMaxAge = "private, max-age=30"
+)
+
+// MobileHandler is the backend-for-frontend of the mobile app, every endpoint
+// returns everything a single screen needs with the fields the app renders
+type MobileHandler struct {
+	libraryService      *library.Service
+	subscriptionService *subscription.Service
+
+	screens *cache.Cache
+}
+
+func NewMobileHandler(l *library.Service, s *subscription.Service) *MobileHandler {
+	return &MobileHandler{
+		libraryService:      l,
+		subscriptionService: s,
+		screens:             cache.New(homeScreenTTL, 2*homeScreenTTL),
+	}
+}
+
+func (h *MobileHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/home", h.home)
+	r.Get("/members/{id}/checkout", h.checkout)
+
+	return r
+}
+
+type MobileBook struct {
+	ID      string   `json:"id" validate:"required"`
+	Name    string   `json:"name" validate:"required"`
+	Genre   string   `json:"genre" validate:"required"`
+	Authors []string `json:"authors,omitempty"`
+}
+
+type MobileAuthor struct {
+	ID       string `json:"id" validate:"required"`
+	FullName string `json:"fullName" validate:"required"`
+}
+
+type HomeScreen struct {
+	Books   []MobileBook   `json:"books" validate:"required"`
+	Authors []MobileAuthor `json:"authors" validate:"required"`
+}
+
+type CheckoutScreen struct {
+	ID       string       `json:"id" validate:"required"`
+	FullName string       `json:"fullName" validate:"required"`
+	Books    []MobileBook `json:"books" validate:"required"`
+}
+
+// @Summary	home screen of the mobile app
+// @Tags		mobile
+// @Accept		json
+// @Produce	json
+// @Success	200	{object}	HomeScreen
+// @Failure	500	{object}	response.Object
+// @Router		/mobile/v1/home [get]
+func (h *MobileHandler) home(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=60")
+
+	if data, found := h.screens.Get(homeScreenKey); found {
+		response.OK(w, r, data)
+		return
+	}
+
+	books, err := h.libraryService.ListBooks(r.Context())
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	authors, err := h.libraryService.ListAuthors(r.Context())
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	res := HomeScreen{
+		Books:   make([]MobileBook, len(books)),
+		Authors: make([]MobileAuthor, len(authors)),
+	}
+
+	names := make(map[string]string, len(authors))
+	for i, data := range authors {
+		names[data.ID] = data.FullName
+		res.Authors[i] = MobileAuthor{ID: data.ID, FullName: data.FullName}
+	}
+
+	for i, data := range books {
+		res.Books[i] = MobileBook{ID: data.ID, Name: data.Name, Genre: data.Genre}
+		for _, id := range data.Authors {
+			if name, ok := names[id]; ok {
+				res.Books[i].Authors = append(res.Books[i].Authors, name)
+			}
+		}
+	}
+	h.screens.Set(homeScreenKey, res, cache.DefaultExpiration)
+
+	response.OK(w, r, res)
+}
+
+// @Summary	checkout screen of the mobile app with the books of the member
+// @Tags		mobile
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	CheckoutScreen
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/mobile/v1/members/{id}/checkout [get]
+func (h *MobileHandler) checkout(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	data, err := h.subscriptionService.GetMember(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	books, err := h.subscriptionService.ListMemberBooks(r.Context(), id)
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	res := CheckoutScreen{
+		ID:       data.ID,
+		FullName: data.FullName,
+		Books:    make([]MobileBook, len(books)),
+	}
+	for i, book := range books {
+		res.Books[i] = MobileBook{ID: book.ID, Name: book.Name, Genre: book.Genre}
+	}
+	w.Header().Set("Cache-Control", checkoutScreenMaxAge)
+
+	response.OK(w, r, res)
+}
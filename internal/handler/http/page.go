@@ -0,0 +1,31 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// parsePage reads the limit and offset query params, limit falls back to
+// the default when absent and is capped at max
+func parsePage(r *http.Request, defaultLimit, maxLimit int) (limit, offset int, err error) {
+	limit = defaultLimit
+
+	if value := r.URL.Query().Get("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 {
+			return 0, 0, errors.New("limit: must be a positive number")
+		}
+	}
+
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	if value := r.URL.Query().Get("offset"); value != "" {
+		if offset, err = strconv.Atoi(value); err != nil || offset < 0 {
+			return 0, 0, errors.New("offset: must be a non-negative number")
+		}
+	}
+
+	return
+}
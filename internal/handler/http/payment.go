@@ -0,0 +1,88 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"library-service/internal/provider/epay"
+	"library-service/internal/service/payment"
+	"library-service/pkg/bulkhead"
+	"library-service/pkg/server/response"
+)
+
+type PaymentHandler struct {
+	paymentService *payment.Service
+}
+
+func NewPaymentHandler(s *payment.Service) *PaymentHandler {
+	return &PaymentHandler{paymentService: s}
+}
+
+func (h *PaymentHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/{invoiceId}", h.status)
+
+	return r
+}
+
+// @Summary	check the status of the payment of the invoice at the gateway
+// @Tags		payments
+// @Accept		json
+// @Produce	json
+// @Param		invoiceId	path		string	true	"path param"
+// @Success	200			{object}	epay.StatusResponse
+// @Failure	400			{object}	response.Object
+// @Failure	500			{object}	response.Object
+// @Failure	503			{object}	response.Object
+// @Router		/payments/{invoiceId} [get]
+func (h *PaymentHandler) status(w http.ResponseWriter, r *http.Request) {
+	invoiceID := chi.URLParam(r, "invoiceId")
+
+	res, err := h.paymentService.GetPaymentStatus(r.Context(), invoiceID)
+	if err != nil {
+		switch {
+		case errors.Is(err, payment.ErrPaymentsDisabled):
+			response.BadRequest(w, r, err, nil)
+		case errors.Is(err, epay.ErrGatewayUnavailable), errors.Is(err, bulkhead.ErrFull):
+			response.ServiceUnavailable(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	receive the callback of the gateway about the payment of an invoice, it is the post link of the payments
+// @Tags		payments
+// @Accept		json
+// @Produce	json
+// @Param		request	body		epay.CallbackRequest	true	"body param"
+// @Success	200		{object}	response.Object
+// @Failure	400		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/callbacks/epay [post]
+func (h *PaymentHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	req := epay.CallbackRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	if err := h.paymentService.ReceivePaymentCallback(r.Context(), req); err != nil {
+		switch {
+		case errors.Is(err, payment.ErrPaymentsDisabled):
+			response.BadRequest(w, r, err, nil)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, nil)
+}
@@ -0,0 +1,173 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"library-service/internal/domain/review"
+	"library-service/pkg/server/response"
+	"library-service/pkg/store"
+)
+
+// @Summary	list of the reviews of the book, hidden reviews are left out
+// @Tags		books
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{array}		review.Response
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/books/{id}/reviews [get]
+func (h *BookHandler) listReviews(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.libraryService.ListBookReviews(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	rate and review the book, a member reviews a book once
+// @Tags		books
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string				true	"path param"
+// @Param		request	body		review.Request	true	"body param"
+// @Success	200		{object}	review.Response
+// @Failure	400		{object}	response.Object
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/books/{id}/reviews [post]
+func (h *BookHandler) addReview(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := review.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.libraryService.AddBookReview(r.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, review.ErrorExists):
+			response.BadRequest(w, r, err, req)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	edit the rating and text of the review
+// @Tags		books
+// @Accept		json
+// @Produce	json
+// @Param		id			path	string				true	"path param"
+// @Param		reviewId	path	string			true	"path param"
+// @Param		request		body	review.Request	true	"body param"
+// @Success	200
+// @Failure	400	{object}	response.Object
+// @Failure	403	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/books/{id}/reviews/{reviewId} [put]
+func (h *BookHandler) updateReview(w http.ResponseWriter, r *http.Request) {
+	id, reviewID := chi.URLParam(r, "id"), chi.URLParam(r, "reviewId")
+
+	req := review.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	if err := h.libraryService.UpdateBookReview(r.Context(), id, reviewID, req); err != nil {
+		switch {
+		case errors.Is(err, review.ErrorNotAuthor):
+			response.Forbidden(w, r, err)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
+
+// @Summary	list of the reviews in the moderation state, flagged by default
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Param		status	query		string	false	"visible, flagged or hidden"
+// @Success	200		{array}		review.Response
+// @Failure	400		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/admin/reviews [get]
+func (h *AdminHandler) listReviews(w http.ResponseWriter, r *http.Request) {
+	req := review.ModerationRequest{Status: review.StatusFlagged}
+	if status := r.URL.Query().Get("status"); status != "" {
+		req.Status = review.Status(status)
+	}
+
+	if err := req.Bind(r); err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	res, err := h.libraryService.ListReviewsByStatus(r.Context(), req.Status)
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	flag, hide or restore the review
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string						true	"path param"
+// @Param		request	body		review.ModerationRequest	true	"body param"
+// @Success	200		{object}	review.Response
+// @Failure	400		{object}	response.Object
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/admin/reviews/{id} [put]
+func (h *AdminHandler) moderateReview(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := review.ModerationRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.libraryService.ModerateReview(r.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
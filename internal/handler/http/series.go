@@ -0,0 +1,162 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"library-service/internal/domain/series"
+	"library-service/internal/service/library"
+	"library-service/pkg/server/response"
+	"library-service/pkg/store"
+)
+
+type SeriesHandler struct {
+	libraryService *library.Service
+}
+
+func NewSeriesHandler(s *library.Service) *SeriesHandler {
+	return &SeriesHandler{libraryService: s}
+}
+
+func (h *SeriesHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/", h.add)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Put("/", h.update)
+		r.Delete("/", h.delete)
+	})
+
+	return r
+}
+
+// @Summary	list of series with their volumes in reading order
+// @Tags		series
+// @Accept		json
+// @Produce	json
+// @Success	200			{array}		series.Response
+// @Failure	500			{object}	response.Object
+// @Router		/series 	[get]
+func (h *SeriesHandler) list(w http.ResponseWriter, r *http.Request) {
+	res, err := h.libraryService.ListSeries(r.Context())
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	add a new series, books join it with their seriesId and volume
+// @Tags		series
+// @Accept		json
+// @Produce	json
+// @Param		request	body		series.Request	true	"body param"
+// @Success	200		{object}	series.Response
+// @Failure	400		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/series [post]
+func (h *SeriesHandler) add(w http.ResponseWriter, r *http.Request) {
+	req := series.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.libraryService.CreateSeries(r.Context(), req)
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	get the series with its volumes in reading order
+// @Tags		series
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	series.Response
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/series/{id} [get]
+func (h *SeriesHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.libraryService.GetSeries(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	update the series
+// @Tags		series
+// @Accept		json
+// @Produce	json
+// @Param		id		path	string			true	"path param"
+// @Param		request	body	series.Request	true	"body param"
+// @Success	200
+// @Failure	400	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/series/{id} [put]
+func (h *SeriesHandler) update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := series.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	if err := h.libraryService.UpdateSeries(r.Context(), id, req); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
+
+// @Summary	delete the series, it must have no volumes
+// @Tags		series
+// @Accept		json
+// @Produce	json
+// @Param		id	path	string	true	"path param"
+// @Success	200
+// @Failure	400	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/series/{id} [delete]
+func (h *SeriesHandler) delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if err := h.libraryService.DeleteSeries(r.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, series.ErrorInUse):
+			response.BadRequest(w, r, err, nil)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
@@ -0,0 +1,46 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/oauth"
+
+	"library-service/internal/service/auth"
+	"library-service/pkg/server/response"
+)
+
+type SessionHandler struct {
+	authService *auth.Service
+}
+
+func NewSessionHandler(a *auth.Service) *SessionHandler {
+	return &SessionHandler{authService: a}
+}
+
+// @Summary	revoke the access token the request is authorized with and its refresh token
+// @Tags		auth
+// @Accept		json
+// @Produce	json
+// @Success	200	{object}	response.Object
+// @Failure	400	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/revoke [post]
+func (h *SessionHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	token, _ := r.Context().Value(oauth.AccessTokenContext).(string)
+
+	err := h.authService.RevokeToken(r.Context(), token)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrSessionsDisabled):
+			response.BadRequest(w, r, err, nil)
+		case errors.Is(err, auth.ErrSessionNotFound):
+			response.OK(w, r, nil)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, nil)
+}
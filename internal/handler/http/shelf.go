@@ -0,0 +1,363 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"library-service/internal/domain/shelf"
+	"library-service/internal/service/library"
+	"library-service/pkg/server/response"
+	"library-service/pkg/store"
+)
+
+// ShelfHandler serves the reading lists shared by their members, it is mounted outside of the bearer authentication
+type ShelfHandler struct {
+	libraryService *library.Service
+}
+
+func NewShelfHandler(l *library.Service) *ShelfHandler {
+	return &ShelfHandler{libraryService: l}
+}
+
+func (h *ShelfHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/{token}", h.get)
+
+	return r
+}
+
+// @Summary	read-only reading list shared by its member
+// @Tags		lists
+// @Accept		json
+// @Produce	json
+// @Param		token	path		string	true	"share token of the list"
+// @Success	200		{object}	shelf.Response
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/lists/{token} [get]
+func (h *ShelfHandler) get(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+
+	res, err := h.libraryService.GetSharedShelf(r.Context(), token)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	reading lists of the member
+// @Tags		members
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{array}		shelf.Response
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/members/{id}/lists [get]
+func (h *MemberHandler) listShelves(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.libraryService.ListShelves(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	add a named reading list, e.g. want to read, for the member
+// @Tags		members
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string				true	"path param"
+// @Param		request	body		shelf.Request	true	"body param"
+// @Success	200		{object}	shelf.Response
+// @Failure	400		{object}	response.Object
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/members/{id}/lists [post]
+func (h *MemberHandler) addShelf(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := shelf.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.libraryService.AddShelf(r.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, shelf.ErrorExists):
+			response.BadRequest(w, r, err, req)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	reading list of the member with its books in order
+// @Tags		members
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string		true	"path param"
+// @Param		listId	path		string	true	"path param"
+// @Success	200		{object}	shelf.Response
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/members/{id}/lists/{listId} [get]
+func (h *MemberHandler) getShelf(w http.ResponseWriter, r *http.Request) {
+	id, listID := chi.URLParam(r, "id"), chi.URLParam(r, "listId")
+
+	res, err := h.libraryService.GetShelf(r.Context(), id, listID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	rename the reading list of the member
+// @Tags		members
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string				true	"path param"
+// @Param		listId	path		string			true	"path param"
+// @Param		request	body		shelf.Request	true	"body param"
+// @Success	200		{object}	shelf.Response
+// @Failure	400		{object}	response.Object
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/members/{id}/lists/{listId} [put]
+func (h *MemberHandler) renameShelf(w http.ResponseWriter, r *http.Request) {
+	id, listID := chi.URLParam(r, "id"), chi.URLParam(r, "listId")
+
+	req := shelf.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.libraryService.RenameShelf(r.Context(), id, listID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, shelf.ErrorExists):
+			response.BadRequest(w, r, err, req)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	delete the reading list of the member
+// @Tags		members
+// @Accept		json
+// @Produce	json
+// @Param		id		path	string		true	"path param"
+// @Param		listId	path	string	true	"path param"
+// @Success	200
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/members/{id}/lists/{listId} [delete]
+func (h *MemberHandler) deleteShelf(w http.ResponseWriter, r *http.Request) {
+	id, listID := chi.URLParam(r, "id"), chi.URLParam(r, "listId")
+
+	if err := h.libraryService.DeleteShelf(r.Context(), id, listID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
+
+// @Summary	put the book at the end of the reading list
+// @Tags		members
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string					true	"path param"
+// @Param		listId	path		string				true	"path param"
+// @Param		request	body		shelf.BookRequest	true	"body param"
+// @Success	200		{object}	shelf.Response
+// @Failure	400		{object}	response.Object
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/members/{id}/lists/{listId}/books [post]
+func (h *MemberHandler) addShelfBook(w http.ResponseWriter, r *http.Request) {
+	id, listID := chi.URLParam(r, "id"), chi.URLParam(r, "listId")
+
+	req := shelf.BookRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.libraryService.AddShelfBook(r.Context(), id, listID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, shelf.ErrorListed):
+			response.BadRequest(w, r, err, req)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	put the books of the reading list in a new order, the request has every book of the list once
+// @Tags		members
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string					true	"path param"
+// @Param		listId	path		string				true	"path param"
+// @Param		request	body		shelf.OrderRequest	true	"body param"
+// @Success	200		{object}	shelf.Response
+// @Failure	400		{object}	response.Object
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/members/{id}/lists/{listId}/books [put]
+func (h *MemberHandler) orderShelf(w http.ResponseWriter, r *http.Request) {
+	id, listID := chi.URLParam(r, "id"), chi.URLParam(r, "listId")
+
+	req := shelf.OrderRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.libraryService.OrderShelf(r.Context(), id, listID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, shelf.ErrorOrder):
+			response.BadRequest(w, r, err, req)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	take the book off the reading list
+// @Tags		members
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string		true	"path param"
+// @Param		listId	path		string	true	"path param"
+// @Param		bookId	path		string	true	"path param"
+// @Success	200		{object}	shelf.Response
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/members/{id}/lists/{listId}/books/{bookId} [delete]
+func (h *MemberHandler) removeShelfBook(w http.ResponseWriter, r *http.Request) {
+	id, listID, bookID := chi.URLParam(r, "id"), chi.URLParam(r, "listId"), chi.URLParam(r, "bookId")
+
+	res, err := h.libraryService.RemoveShelfBook(r.Context(), id, listID, bookID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	share the reading list with a public read-only link at /lists/{shareToken}
+// @Tags		members
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string		true	"path param"
+// @Param		listId	path		string	true	"path param"
+// @Success	200		{object}	shelf.Response
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/members/{id}/lists/{listId}/share [post]
+func (h *MemberHandler) shareShelf(w http.ResponseWriter, r *http.Request) {
+	id, listID := chi.URLParam(r, "id"), chi.URLParam(r, "listId")
+
+	res, err := h.libraryService.ShareShelf(r.Context(), id, listID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	take the public link of the reading list down
+// @Tags		members
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string		true	"path param"
+// @Param		listId	path		string	true	"path param"
+// @Success	200		{object}	shelf.Response
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/members/{id}/lists/{listId}/share [delete]
+func (h *MemberHandler) unshareShelf(w http.ResponseWriter, r *http.Request) {
+	id, listID := chi.URLParam(r, "id"), chi.URLParam(r, "listId")
+
+	res, err := h.libraryService.UnshareShelf(r.Context(), id, listID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
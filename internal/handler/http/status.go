@@ -0,0 +1,66 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/patrickmn/go-cache"
+
+	"library-service/internal/service/status"
+	"library-service/pkg/server/response"
+)
+
+const (
+	statusPageKey = "status"
+	statusPageTTL = 30 * time.Second
+
+	statusPageMaxAge = "public, max-age=30"
+)
+
+// StatusHandler serves the data of the public status page, it is read without a token
+type StatusHandler struct {
+	statusService *status.Service
+
+	pages *cache.Cache
+}
+
+func NewStatusHandler(s *status.Service) *StatusHandler {
+	return &StatusHandler{
+		statusService: s,
+		pages:         cache.New(statusPageTTL, 2*statusPageTTL),
+	}
+}
+
+func (h *StatusHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.get)
+
+	return r
+}
+
+// @Summary	health of the components, their uptime over 30 days and the current incidents
+// @Tags		status
+// @Accept		json
+// @Produce	json
+// @Success	200	{object}	health.Page
+// @Failure	500	{object}	response.Object
+// @Router		/status [get]
+func (h *StatusHandler) get(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", statusPageMaxAge)
+
+	if data, found := h.pages.Get(statusPageKey); found {
+		response.OK(w, r, data)
+		return
+	}
+
+	res, err := h.statusService.GetPage(r.Context())
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+	h.pages.Set(statusPageKey, res, cache.DefaultExpiration)
+
+	response.OK(w, r, res)
+}
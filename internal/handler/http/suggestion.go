@@ -0,0 +1,205 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"library-service/internal/domain/book"
+	"library-service/internal/domain/category"
+	"library-service/internal/domain/series"
+	"library-service/internal/domain/suggestion"
+	"library-service/pkg/bulkhead"
+	"library-service/pkg/server/response"
+	"library-service/pkg/store"
+)
+
+// @Summary	list of the books the members suggested to acquire, the most voted first
+// @Tags		books
+// @Accept		json
+// @Produce	json
+// @Param		status	query		string	false	"under_review, ordered, added or rejected, every status by default"
+// @Success	200		{array}		suggestion.Response
+// @Failure	400		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/books/suggestions [get]
+func (h *BookHandler) listSuggestions(w http.ResponseWriter, r *http.Request) {
+	status, err := suggestion.ParseStatus(r.URL.Query().Get("status"))
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	res, err := h.libraryService.ListSuggestions(r.Context(), status)
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	suggest a book the library doesn't own to acquire
+// @Tags		books
+// @Accept		json
+// @Produce	json
+// @Param		request	body		suggestion.Request	true	"body param"
+// @Success	200		{object}	suggestion.Response
+// @Failure	400		{object}	response.Object
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/books/suggestions [post]
+func (h *BookHandler) addSuggestion(w http.ResponseWriter, r *http.Request) {
+	req := suggestion.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.libraryService.AddSuggestion(r.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, suggestion.ErrorOwned), errors.Is(err, suggestion.ErrorExists):
+			response.BadRequest(w, r, err, req)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	get the suggestion from the repository
+// @Tags		books
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	suggestion.Response
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/books/suggestions/{id} [get]
+func (h *BookHandler) getSuggestion(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.libraryService.GetSuggestion(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	vote for the suggestion of another member, once per member
+// @Tags		books
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string					true	"path param"
+// @Param		request	body		suggestion.VoteRequest	true	"body param"
+// @Success	200		{object}	suggestion.Response
+// @Failure	400		{object}	response.Object
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/books/suggestions/{id}/votes [post]
+func (h *BookHandler) voteSuggestion(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := suggestion.VoteRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.libraryService.VoteSuggestion(r.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, suggestion.ErrorVoted), errors.Is(err, suggestion.ErrorSelfVote), errors.Is(err, suggestion.ErrorClosed):
+			response.BadRequest(w, r, err, req)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	list of the suggestions in the acquisition pipeline, under review by default
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Param		status	query		string	false	"under_review, ordered, added or rejected"
+// @Success	200		{array}		suggestion.Response
+// @Failure	400		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/admin/suggestions [get]
+func (h *AdminHandler) listSuggestions(w http.ResponseWriter, r *http.Request) {
+	status := suggestion.StatusUnderReview
+	if value := r.URL.Query().Get("status"); value != "" {
+		parsed, err := suggestion.ParseStatus(value)
+		if err != nil {
+			response.BadRequest(w, r, err, nil)
+			return
+		}
+		status = parsed
+	}
+
+	res, err := h.libraryService.ListSuggestions(r.Context(), status)
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	move the suggestion to ordered, added or rejected and notify its voters, an added suggestion gets its book created
+// @Tags		admin
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string						true	"path param"
+// @Param		request	body		suggestion.StatusRequest	true	"body param"
+// @Success	200		{object}	suggestion.Response
+// @Failure	400		{object}	response.Object
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Failure	503		{object}	response.Object
+// @Router		/admin/suggestions/{id}/status [put]
+func (h *AdminHandler) moveSuggestion(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := suggestion.StatusRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.libraryService.MoveSuggestion(r.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, bulkhead.ErrFull):
+			response.ServiceUnavailable(w, r, err)
+		case errors.Is(err, suggestion.ErrorTransition), errors.Is(err, suggestion.ErrorBook), errors.Is(err, book.ErrorIncomplete), errors.Is(err, category.ErrorUnknown), errors.Is(err, series.ErrorUnknown), errors.Is(err, series.ErrorNoVolume), errors.Is(err, series.ErrorVolumeTaken):
+			response.BadRequest(w, r, err, req)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
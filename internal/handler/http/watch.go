@@ -0,0 +1,73 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"library-service/internal/domain/watch"
+	"library-service/pkg/server/response"
+	"library-service/pkg/store"
+)
+
+// @Summary	tell the member once a copy of the book is free, without joining a hold queue
+// @Tags		books
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string				true	"path param"
+// @Param		request	body		watch.Request	true	"body param"
+// @Success	200		{object}	watch.Response
+// @Failure	400		{object}	response.Object
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/books/{id}/watch [post]
+func (h *BookHandler) watchBook(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := watch.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.libraryService.WatchBook(r.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, watch.ErrorWatched), errors.Is(err, watch.ErrorAvailable):
+			response.BadRequest(w, r, err, req)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	stop watching the book
+// @Tags		books
+// @Accept		json
+// @Produce	json
+// @Param		id			path	string		true	"path param"
+// @Param		memberId	path	string	true	"path param"
+// @Success	200
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/books/{id}/watch/{memberId} [delete]
+func (h *BookHandler) unwatchBook(w http.ResponseWriter, r *http.Request) {
+	id, memberID := chi.URLParam(r, "id"), chi.URLParam(r, "memberId")
+
+	if err := h.libraryService.UnwatchBook(r.Context(), id, memberID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
@@ -0,0 +1,172 @@
+package handler_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"library-service/pkg/golden"
+)
+
+// catalog holds the ids of what seed added
+type catalog struct {
+	author, series, book, copy, spare, member, reader, loan, list, review, suggestion string
+}
+
+// seed adds a book of a series by an author with two copies lent to two members, the first of them
+// keeps the book in a reading list, reviewed it, watches it and suggested another book
+func seed(t *testing.T, c *client) (ids catalog) {
+	t.Helper()
+
+	ids.author = c.create(t, "/authors", `{"fullName": "Mukhtar Auezov", "pseudonym": "Auezov", "specialty": "writer"}`)
+	ids.series = c.create(t, "/series", `{"name": "Abai Zholy", "description": "The novel-epic in four volumes"}`)
+	ids.book = c.create(t, "/books", `{"name": "Abai", "genre": "novel", "isbn": "978-0-14-044793-4", "year": 1942,
+		"description": "The first volume of the novel-epic", "authors": ["`+ids.author+`"]}`)
+	c.do(t, http.MethodPatch, "/books/"+ids.book, `{"seriesId": "`+ids.series+`", "volume": 1}`)
+
+	ids.copy = c.create(t, "/books/"+ids.book+"/copies", `{"barcode": "LIB-000001", "condition": "good", "location": "A-1"}`)
+	ids.spare = c.create(t, "/books/"+ids.book+"/copies", `{"barcode": "LIB-000002", "condition": "fair", "location": "A-2"}`)
+
+	ids.member = c.create(t, "/members", `{"fullName": "Zhanat Rakhmet", "tier": "premium"}`)
+	ids.loan = c.create(t, "/members/"+ids.member+"/loans", `{"copyId": "`+ids.copy+`"}`)
+	ids.reader = c.create(t, "/members", `{"fullName": "Aigerim Sadykova"}`)
+	c.create(t, "/members/"+ids.reader+"/loans", `{"copyId": "`+ids.spare+`"}`)
+
+	ids.list = c.create(t, "/members/"+ids.member+"/lists", `{"name": "Want to read"}`)
+	c.do(t, http.MethodPost, "/members/"+ids.member+"/lists/"+ids.list+"/books", `{"bookId": "`+ids.book+`"}`)
+
+	ids.review = c.create(t, "/books/"+ids.book+"/reviews", `{"memberId": "`+ids.member+`", "rating": 5, "text": "A classic"}`)
+	c.create(t, "/books/"+ids.book+"/watch", `{"memberId": "`+ids.member+`"}`)
+	ids.suggestion = c.create(t, "/books/suggestions", `{"memberId": "`+ids.member+`", "title": "Kokserek",
+		"author": "Mukhtar Auezov", "isbn": "978-0-306-40615-7", "note": "The short stories"}`)
+
+	return
+}
+
+// TestResponseSnapshots keeps the wire format of the responses in testdata/snapshots,
+// run the tests with -update after an intended change and review the diff of the files
+func TestResponseSnapshots(t *testing.T) {
+	c := newClient(t)
+	ids := seed(t, c)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"book.json", http.MethodGet, "/books/" + ids.book, "", http.StatusOK},
+		{"books.json", http.MethodGet, "/books", "", http.StatusOK},
+		{"search.json", http.MethodGet, "/books/search?q=abai", "", http.StatusOK},
+		{"book_authors.json", http.MethodGet, "/books/" + ids.book + "/authors", "", http.StatusOK},
+		{"availability.json", http.MethodGet, "/books/" + ids.book + "/availability", "", http.StatusOK},
+		{"copies.json", http.MethodGet, "/books/" + ids.book + "/copies", "", http.StatusOK},
+		{"reviews.json", http.MethodGet, "/books/" + ids.book + "/reviews", "", http.StatusOK},
+		{"suggestion.json", http.MethodGet, "/books/suggestions/" + ids.suggestion, "", http.StatusOK},
+		{"author.json", http.MethodGet, "/authors/" + ids.author, "", http.StatusOK},
+		{"series.json", http.MethodGet, "/series/" + ids.series, "", http.StatusOK},
+		{"member.json", http.MethodGet, "/members/" + ids.member, "", http.StatusOK},
+		{"loan.json", http.MethodGet, "/members/" + ids.member + "/loans/" + ids.loan, "", http.StatusOK},
+		{"list.json", http.MethodGet, "/members/" + ids.member + "/lists/" + ids.list, "", http.StatusOK},
+		{"home.json", http.MethodGet, "/mobile/v1/home", "", http.StatusOK},
+		{"checkout.json", http.MethodGet, "/mobile/v1/members/" + ids.member + "/checkout", "", http.StatusOK},
+		{"status.json", http.MethodGet, "/status", "", http.StatusOK},
+		{"bad_request.json", http.MethodPost, "/books", `{"name": "Abai"}`, http.StatusBadRequest},
+		{"not_found.json", http.MethodGet, "/books/00000000-0000-0000-0000-000000000000", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, data := c.do(t, tt.method, tt.path, tt.body)
+			if res.StatusCode != tt.status {
+				t.Fatalf("%s %s: got %s, want %d\n%s", tt.method, tt.path, res.Status, tt.status, data)
+			}
+
+			golden.JSON(t, "snapshots/"+tt.name, data)
+		})
+	}
+}
+
+// textObjects matches the text of the pdf writer, which draws every line as BT ... ET on a line of its own
+var textObjects = regexp.MustCompile(`(?m)^BT .* ET$`)
+
+// textLayer returns the text objects of the pdf with their font size and position, the barcodes are images and left out
+func textLayer(doc []byte) []byte {
+	return append(bytes.Join(textObjects.FindAll(doc, -1), []byte("\n")), '\n')
+}
+
+// TestLabelSnapshots keeps the text layer of the printed labels in testdata/snapshots
+func TestLabelSnapshots(t *testing.T) {
+	c := newClient(t)
+	ids := seed(t, c)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"label_code128.txt", http.MethodGet, "/copies/" + ids.copy + "/label?format=pdf", ""},
+		{"label_qr.txt", http.MethodGet, "/copies/" + ids.copy + "/label?format=pdf&symbology=qr", ""},
+		{"labels.txt", http.MethodPost, "/copies/labels", `{"ids": ["` + ids.copy + `", "` + ids.spare + `"]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, data := c.do(t, tt.method, tt.path, tt.body)
+			if res.StatusCode != http.StatusOK {
+				t.Fatalf("%s %s: got %s\n%s", tt.method, tt.path, res.Status, data)
+			}
+			if got := res.Header.Get("Content-Type"); got != "application/pdf" {
+				t.Fatalf("%s %s: got %s, want application/pdf", tt.method, tt.path, got)
+			}
+
+			golden.Assert(t, "snapshots/"+tt.name, textLayer(data))
+		})
+	}
+}
+
+// TestNotificationSnapshots keeps the bodies the webhook receives in testdata/snapshots
+// and checks that every body is signed with the secret
+func TestNotificationSnapshots(t *testing.T) {
+	c := newClient(t)
+	ids := seed(t, c)
+	c.notified()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"notification_suggestion.json", http.MethodPut, "/admin/suggestions/" + ids.suggestion + "/status", `{"status": "ordered"}`},
+		{"notification_available.json", http.MethodPost, "/members/" + ids.member + "/loans/" + ids.loan + "/return", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, data := c.do(t, tt.method, tt.path, tt.body)
+			if res.StatusCode != http.StatusOK {
+				t.Fatalf("%s %s: got %s\n%s", tt.method, tt.path, res.Status, data)
+			}
+
+			notifications := c.notified()
+			if len(notifications) != 1 {
+				t.Fatalf("%s %s: got %d notifications, want 1", tt.method, tt.path, len(notifications))
+			}
+
+			mac := hmac.New(sha256.New, []byte(notifySecret))
+			mac.Write(notifications[0].body)
+			if want := hex.EncodeToString(mac.Sum(nil)); notifications[0].signature != want {
+				t.Errorf("got signature %q, want %q", notifications[0].signature, want)
+			}
+
+			golden.JSON(t, "snapshots/"+tt.name, notifications[0].body)
+		})
+	}
+}
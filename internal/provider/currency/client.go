@@ -13,6 +13,9 @@ import (
 
 type Credentials struct {
 	URL string
+
+	// Transport overrides the http transport, e.g. to put the calls behind a bulkhead
+	Transport http.RoundTripper
 }
 
 type Client struct {
@@ -28,6 +31,13 @@ func New(credentials Credentials) *Client {
 	httpClient := http.DefaultClient
 	httpClient.Timeout = 30 * time.Second
 
+	if credentials.Transport != nil {
+		httpClient = &http.Client{
+			Transport: credentials.Transport,
+			Timeout:   30 * time.Second,
+		}
+	}
+
 	client := &Client{
 		caches:     caches,
 		httpClient: httpClient,
@@ -76,3 +86,23 @@ func (c *Client) request(ctx context.Context, method, url string, out interface{
 
 	return
 }
+
+// Ping checks that the national bank answers, any response short of a server error will do
+func (c *Client) Ping(ctx context.Context) (err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.Credentials.URL, nil)
+	if err != nil {
+		return
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return
+	}
+	res.Body.Close()
+
+	if res.StatusCode >= http.StatusInternalServerError {
+		return errors.New("unhealthy response " + res.Status)
+	}
+
+	return
+}
@@ -70,9 +70,10 @@ func (c *Client) GetRatesByDate(ctx context.Context, datetime time.Time) (dest [
 		return dest, errors.New("datetime: cannot be blank")
 	}
 
+	// retry until the rates are fetched or the context is done, which bounds the fetch of the first rate at startup
 	for {
 		dest, err = c.getRatesByDate(ctx, datetime)
-		if err == nil {
+		if err == nil || ctx.Err() != nil {
 			break
 		}
 	}
@@ -0,0 +1,107 @@
+package epay
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultRecoveryTimeout  = 30 * time.Second
+)
+
+// ErrGatewayUnavailable is returned while the circuit breaker is open.
+var ErrGatewayUnavailable = errors.New("epay: gateway unavailable")
+
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+type breaker struct {
+	sync.Mutex
+
+	threshold int
+	timeout   time.Duration
+
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newBreaker(threshold int, timeout time.Duration) *breaker {
+	if threshold <= 0 {
+		threshold = defaultFailureThreshold
+	}
+
+	if timeout <= 0 {
+		timeout = defaultRecoveryTimeout
+	}
+
+	return &breaker{
+		threshold: threshold,
+		timeout:   timeout,
+		state:     BreakerClosed,
+	}
+}
+
+// allow reports whether a request may be sent. Once the recovery timeout has
+// passed an open breaker lets a single probe through in the half-open state.
+func (b *breaker) allow() error {
+	b.Lock()
+	defer b.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.timeout {
+			return ErrGatewayUnavailable
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+	case BreakerHalfOpen:
+		if b.probing {
+			return ErrGatewayUnavailable
+		}
+		b.probing = true
+	}
+
+	return nil
+}
+
+func (b *breaker) success() {
+	b.Lock()
+	defer b.Unlock()
+
+	b.state = BreakerClosed
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *breaker) failure() {
+	b.Lock()
+	defer b.Unlock()
+
+	b.failures++
+	b.probing = false
+
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+func (b *breaker) current() BreakerState {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.timeout {
+		return BreakerHalfOpen
+	}
+
+	return b.state
+}
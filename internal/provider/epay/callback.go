@@ -1,6 +1,8 @@
 package epay
 
 import (
+	"errors"
+	"net/http"
 	"time"
 )
 
@@ -37,3 +39,11 @@ type CallbackRequest struct {
 	IPLatitude     float64   `json:"ipLatitude"`
 	CardID         string    `json:"cardId"`
 }
+
+func (s *CallbackRequest) Bind(r *http.Request) error {
+	if s.InvoiceID == "" {
+		return errors.New("invoiceId: cannot be blank")
+	}
+
+	return nil
+}
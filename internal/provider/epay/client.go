@@ -1,6 +1,7 @@
 package epay
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -16,21 +17,52 @@ type Credentials struct {
 
 	OAuthURL       string
 	PaymentPageURL string
-	GlobalToken    TokenResponse
+
+	// FailureThreshold is the number of consecutive failures after which
+	// the circuit breaker opens, RecoveryTimeout is how long it stays open
+	// before a probe request is let through.
+	FailureThreshold int
+	RecoveryTimeout  time.Duration
+
+	// ClockSkew is the tolerated difference between our clock and the provider's,
+	// the global token is refreshed that much earlier
+	ClockSkew time.Duration
+
+	// TokenStore shares the global token between replicas, by default every client fetches its own
+	TokenStore TokenStore
+
+	// StatusTTL is how long a payment status check is answered from the cache, 5 seconds by default
+	StatusTTL time.Duration
+
+	// Transport overrides the http transport, e.g. to inject faults outside prod
+	Transport http.RoundTripper
 }
 
 type Client struct {
 	httpClient  *http.Client
 	credentials Credentials
+	breaker     *breaker
+	tokens      *tokenState
+	statuses    *statusState
 }
 
 func New(credentials Credentials) (client Client, err error) {
 	httpClient := http.DefaultClient
 	httpClient.Timeout = 30 * time.Second
 
+	if credentials.Transport != nil {
+		httpClient = &http.Client{
+			Transport: credentials.Transport,
+			Timeout:   30 * time.Second,
+		}
+	}
+
 	client = Client{
 		httpClient:  httpClient,
 		credentials: credentials,
+		breaker:     newBreaker(credentials.FailureThreshold, credentials.RecoveryTimeout),
+		tokens:      &tokenState{},
+		statuses:    newStatusState(credentials.StatusTTL),
 	}
 	err = client.initGlobalTokenRefresher()
 
@@ -38,8 +70,16 @@ func New(credentials Credentials) (client Client, err error) {
 }
 
 func (c *Client) request(ctx context.Context, repeat bool, method, url string, body io.Reader, headers map[string]string, out interface{}) (err error) {
+	// keep the body, so that it is sent again when the request is repeated
+	var payload []byte
+	if body != nil {
+		if payload, err = io.ReadAll(body); err != nil {
+			return
+		}
+	}
+
 	// setup http request
-	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(payload))
 	if err != nil {
 		return
 	}
@@ -49,19 +89,38 @@ func (c *Client) request(ctx context.Context, repeat bool, method, url string, b
 		req.Header.Add(key, value)
 	}
 
+	// check circuit breaker
+	if err = c.breaker.allow(); err != nil {
+		return
+	}
+
 	// send http request
 	res, err := c.httpClient.Do(req)
 	if err != nil {
+		c.breaker.failure()
 		return
 	}
 	defer res.Body.Close()
 
+	// only transport errors and server errors count against the gateway
+	if res.StatusCode >= http.StatusInternalServerError {
+		c.breaker.failure()
+	} else {
+		c.breaker.success()
+	}
+
 	// check unauthorized status
 	if res.StatusCode == http.StatusUnauthorized && repeat {
-		if err = c.initGlobalTokenRefresher(); err != nil {
+		stale := c.GlobalToken().AccessToken
+		if _, err = c.refreshGlobalToken(stale); err != nil {
 			return
 		}
-		return c.request(ctx, false, method, url, body, headers, out)
+
+		// a request sent with the stale global token is repeated with the new one
+		if headers["Authorization"] == "Bearer "+stale {
+			headers["Authorization"] = "Bearer " + c.GlobalToken().AccessToken
+		}
+		return c.request(ctx, false, method, url, bytes.NewReader(payload), headers, out)
 	}
 
 	// read response body
@@ -74,7 +133,17 @@ func (c *Client) request(ctx context.Context, repeat bool, method, url string, b
 	if res.StatusCode != http.StatusOK {
 		return errors.New(string(data))
 	}
+
+	// the charge and the cancel are answered with an empty body
+	if out == nil {
+		return
+	}
 	err = json.Unmarshal(data, &out)
 
 	return
 }
+
+// State returns the current state of the gateway circuit breaker
+func (c *Client) State() BreakerState {
+	return c.breaker.current()
+}
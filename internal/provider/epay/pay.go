@@ -3,16 +3,22 @@ package epay
 import (
 	"bytes"
 	"context"
+	"embed"
 	"encoding/json"
 	"fmt"
 	"html/template"
 	"net/http"
 	"net/url"
-	"os"
-	"path/filepath"
 	"time"
 )
 
+// the pages are compiled in, so that they are found whatever the working directory
+//
+//go:embed template/*.html
+var pages embed.FS
+
+var templates = template.Must(template.ParseFS(pages, "template/*.html"))
+
 type PaymentCardID struct {
 	ID string `json:"id"`
 }
@@ -38,6 +44,7 @@ type PaymentRequest struct {
 
 	HomebankToken  string `json:"-"`
 	PaymentPageURL string `json:"-"`
+	CardSave       bool   `json:"-"`
 
 	Token  TokenResponse  `json:"-"`
 	Status StatusResponse `json:"-"`
@@ -62,11 +69,6 @@ type PaymentResponse struct {
 }
 
 func (c *Client) PayByPaymentPage(ctx context.Context, w http.ResponseWriter, src PaymentRequest, dueDate time.Time) (err error) {
-	rootDir, err := os.Getwd()
-	if err != nil {
-		return
-	}
-
 	if !dueDate.IsZero() && (time.Now().Unix() > dueDate.Add(-1500*time.Second).Unix()) {
 		src.Status.Transaction.StatusName = "EXPIRED"
 		src.Status.Transaction.StatusDescription = "Истек срок оплаты"
@@ -75,15 +77,15 @@ func (c *Client) PayByPaymentPage(ctx context.Context, w http.ResponseWriter, sr
 	templateName := ""
 	switch src.Status.Transaction.StatusName {
 	case "NEW", "AUTH", "EXPIRED":
-		templateName = filepath.Join(rootDir, "pkg", "provider", "epay", "template", "pending.html")
+		templateName = "pending.html"
 	case "CHARGE":
-		templateName = filepath.Join(rootDir, "pkg", "provider", "epay", "template", "success.html")
+		templateName = "success.html"
 	case "CANCEL", "REFUND":
-		templateName = filepath.Join(rootDir, "pkg", "provider", "epay", "template", "cancelled.html")
+		templateName = "cancelled.html"
 	case "REJECT", "FAILED", "3D", "CANCEL_OLD":
-		templateName = filepath.Join(rootDir, "pkg", "provider", "epay", "template", "failed.html")
+		templateName = "failed.html"
 	default:
-		templateName = filepath.Join(rootDir, "pkg", "provider", "epay", "template", "payment.html")
+		templateName = "payment.html"
 
 		src.Token, err = c.GetPaymentToken(ctx, &src)
 		if err != nil {
@@ -92,12 +94,7 @@ func (c *Client) PayByPaymentPage(ctx context.Context, w http.ResponseWriter, sr
 		src.PaymentPageURL = c.credentials.PaymentPageURL
 	}
 
-	tmpl, err := template.ParseFiles(templateName)
-	if err != nil {
-		return
-	}
-
-	return tmpl.Execute(w, src)
+	return templates.ExecuteTemplate(w, templateName, src)
 }
 
 func (c *Client) PayBySavedCard(ctx context.Context, src PaymentRequest) (dst PaymentResponse, err error) {
@@ -2,9 +2,16 @@ package epay
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/url"
+	"strconv"
 	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"library-service/pkg/lru"
 )
 
 type TransactionResponse struct {
@@ -53,7 +60,95 @@ type StatusResponse struct {
 	Transaction   TransactionResponse `json:"transaction"`
 }
 
+const (
+	defaultStatusTTL = 5 * time.Second
+	statusTimeout    = 10 * time.Second
+	statusCapacity   = 1024
+)
+
+// statusState caches the status checks by invoice and token, it is shared by the copies of a Client
+type statusState struct {
+	group singleflight.Group
+	cache *lru.Cache
+	ttl   time.Duration
+}
+
+// cachedStatus is a status with the time its check was started, so that a check started before
+// the invoice was invalidated is not answered from the cache
+type cachedStatus struct {
+	res     StatusResponse
+	started time.Time
+}
+
+func newStatusState(ttl time.Duration) *statusState {
+	if ttl <= 0 {
+		ttl = defaultStatusTTL
+	}
+
+	return &statusState{
+		cache: lru.New(statusCapacity, ttl),
+		ttl:   ttl,
+	}
+}
+
+// GetStatus answers from the cache for the StatusTTL after the invoice was last checked with the token,
+// concurrent checks of the same invoice with the same token share a single request to the gateway.
+// A caller with another token never gets the status checked with someone else's.
 func (c *Client) GetStatus(ctx context.Context, token string, invoiceID string) (dst StatusResponse, err error) {
+	var invalidated time.Time
+	if at, ok := c.statuses.cache.Get(invalidatedKey(invoiceID)); ok {
+		invalidated = at.(time.Time)
+	}
+
+	key := statusKey(invoiceID, token)
+	if value, ok := c.statuses.cache.Get(key); ok && value.(cachedStatus).started.After(invalidated) {
+		return value.(cachedStatus).res, nil
+	}
+
+	// the checks started before and after an invalidation are not shared
+	ch := c.statuses.group.DoChan(key+":"+strconv.FormatInt(invalidated.UnixNano(), 10), func() (interface{}, error) {
+		// the check is shared, so it doesn't end with the context of the first caller
+		ctx, cancel := context.WithTimeout(context.Background(), statusTimeout)
+		defer cancel()
+
+		started := time.Now()
+		res, err := c.fetchStatus(ctx, token, invoiceID)
+		if err != nil {
+			return res, err
+		}
+		c.statuses.cache.Set(key, cachedStatus{res: res, started: started})
+
+		return res, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		err = ctx.Err()
+	case res := <-ch:
+		dst, err = res.Val.(StatusResponse), res.Err
+	}
+
+	return
+}
+
+// InvalidateStatus drops the cached statuses of the invoice for every token, the payment service calls it
+// once the callback of the invoice is received. A check that was in flight by then is not answered from the cache.
+func (c *Client) InvalidateStatus(invoiceID string) {
+	// the mark outlives the checks in flight and the statuses they cache
+	c.statuses.cache.SetWithTTL(invalidatedKey(invoiceID), time.Now(), statusTimeout+c.statuses.ttl)
+}
+
+// statusKey keys the status by the invoice and a hash of the token, so that the token isn't kept in the cache
+func statusKey(invoiceID, token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return "status:" + invoiceID + ":" + hex.EncodeToString(hash[:])
+}
+
+func invalidatedKey(invoiceID string) string {
+	return "invalidated:" + invoiceID
+}
+
+func (c *Client) fetchStatus(ctx context.Context, token string, invoiceID string) (dst StatusResponse, err error) {
 	path, err := url.Parse(c.credentials.URL)
 	if err != nil {
 		return
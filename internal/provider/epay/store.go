@@ -0,0 +1,91 @@
+package epay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const defaultTokenKey = "epay:token"
+
+// ErrTokenNotFound is returned by a TokenStore that holds no token.
+var ErrTokenNotFound = errors.New("epay: token not found")
+
+// TokenStore shares the global token between replicas, so only the replica
+// holding the lock fetches a new one from the OAuth endpoint.
+type TokenStore interface {
+	// Get returns the token with the time left until it has to be refreshed
+	Get(ctx context.Context) (dest TokenResponse, ttl time.Duration, err error)
+	Set(ctx context.Context, token TokenResponse, ttl time.Duration) (err error)
+
+	// Lock returns false if another replica is already refreshing the token
+	Lock(ctx context.Context, ttl time.Duration) (unlock func(), ok bool, err error)
+}
+
+// RedisTokenStore is a TokenStore backed by Redis
+type RedisTokenStore struct {
+	client *redis.Client
+	key    string
+}
+
+func NewRedisTokenStore(client *redis.Client) *RedisTokenStore {
+	return &RedisTokenStore{
+		client: client,
+		key:    defaultTokenKey,
+	}
+}
+
+func (s *RedisTokenStore) Get(ctx context.Context) (dest TokenResponse, ttl time.Duration, err error) {
+	pipe := s.client.Pipeline()
+	get := pipe.Get(ctx, s.key)
+	pttl := pipe.PTTL(ctx, s.key)
+
+	if _, err = pipe.Exec(ctx); err != nil {
+		if errors.Is(err, redis.Nil) {
+			err = ErrTokenNotFound
+		}
+		return
+	}
+
+	if err = json.Unmarshal([]byte(get.Val()), &dest); err != nil {
+		return
+	}
+	ttl = pttl.Val()
+
+	return
+}
+
+func (s *RedisTokenStore) Set(ctx context.Context, token TokenResponse, ttl time.Duration) (err error) {
+	payload, err := json.Marshal(token)
+	if err != nil {
+		return
+	}
+
+	return s.client.Set(ctx, s.key, payload, ttl).Err()
+}
+
+// unlockScript deletes the lock only if it is still held by the caller
+var unlockScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0`)
+
+func (s *RedisTokenStore) Lock(ctx context.Context, ttl time.Duration) (unlock func(), ok bool, err error) {
+	key, value := s.key+":lock", uuid.New().String()
+
+	ok, err = s.client.SetNX(ctx, key, value, ttl).Result()
+	if err != nil || !ok {
+		return
+	}
+
+	unlock = func() {
+		unlockScript.Run(context.Background(), s.client, []string{key}, value)
+	}
+
+	return
+}
@@ -3,11 +3,15 @@ package epay
 import (
 	"bytes"
 	"context"
+	"errors"
+	"math/rand"
 	"mime/multipart"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/shopspring/decimal"
+	"golang.org/x/sync/singleflight"
 )
 
 type TokenResponse struct {
@@ -18,26 +22,173 @@ type TokenResponse struct {
 	RefreshToken string          `json:"refresh_token"`
 }
 
-func (c *Client) initGlobalTokenRefresher() (err error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+const (
+	tokenRefreshBuffer = 60 * time.Second
+	tokenRefreshJitter = 0.1
+	tokenLockTTL       = 10 * time.Second
+	tokenPollInterval  = 200 * time.Millisecond
+	tokenRetryInterval = 5 * time.Second
+	tokenTimeout       = 10 * time.Second
+)
+
+// TokenStats reports the age of the global token and how its refreshes went
+type TokenStats struct {
+	Age         time.Duration
+	Refreshes   int
+	Failures    int
+	LastRefresh time.Time
+	LastError   string
+}
+
+// tokenState holds the global token, it is shared by the copies of a Client
+type tokenState struct {
+	sync.RWMutex
+
+	group    singleflight.Group
+	token    TokenResponse
+	loadedAt time.Time
+	stats    TokenStats
+}
+
+// GlobalToken returns the token the refresher keeps up to date
+func (c *Client) GlobalToken() TokenResponse {
+	c.tokens.RLock()
+	defer c.tokens.RUnlock()
 
-	c.credentials.GlobalToken, err = c.GetPaymentToken(ctx, nil)
+	return c.tokens.token
+}
+
+// TokenStats returns the metrics of the global token refresher
+func (c *Client) TokenStats() (dst TokenStats) {
+	c.tokens.RLock()
+	defer c.tokens.RUnlock()
+
+	dst = c.tokens.stats
+	if !c.tokens.loadedAt.IsZero() {
+		dst.Age = time.Since(c.tokens.loadedAt)
+	}
+
+	return
+}
+
+func (c *Client) initGlobalTokenRefresher() (err error) {
+	// pre-warm the token, with a shared store it is usually fetched by another replica already
+	ttl, err := c.refreshGlobalToken("")
 	if err != nil {
 		return
 	}
-	ticker := time.NewTicker(time.Duration(c.credentials.GlobalToken.ExpiresIn.IntPart()-60) * time.Second)
 
 	go func() {
+		timer := time.NewTimer(jitter(ttl))
 		for {
-			<-ticker.C
-			c.credentials.GlobalToken, err = c.GetPaymentToken(ctx, nil)
+			<-timer.C
+
+			ttl, err := c.refreshGlobalToken("")
+			if err != nil {
+				ttl = tokenRetryInterval
+			}
+			timer.Reset(jitter(ttl))
 		}
 	}()
 
 	return
 }
 
+// refreshGlobalToken loads a new global token, concurrent callers share a single refresh.
+// A stale token rejected by the gateway is never taken from the store again.
+func (c *Client) refreshGlobalToken(stale string) (ttl time.Duration, err error) {
+	res, err, _ := c.tokens.group.Do("global", func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), tokenTimeout)
+		defer cancel()
+
+		token, ttl, err := c.loadGlobalToken(ctx, stale)
+
+		c.tokens.Lock()
+		defer c.tokens.Unlock()
+
+		if err != nil {
+			c.tokens.stats.Failures++
+			c.tokens.stats.LastError = err.Error()
+			return ttl, err
+		}
+		c.tokens.token = token
+		c.tokens.loadedAt = time.Now()
+		c.tokens.stats.Refreshes++
+		c.tokens.stats.LastRefresh = c.tokens.loadedAt
+
+		return ttl, nil
+	})
+	if err != nil {
+		return
+	}
+	ttl = res.(time.Duration)
+
+	return
+}
+
+// loadGlobalToken returns the global token and the time until it has to be refreshed.
+// With a TokenStore the token is shared: a single replica takes the lock and fetches it
+// from the OAuth endpoint while the others wait for it to appear in the store.
+func (c *Client) loadGlobalToken(ctx context.Context, stale string) (dst TokenResponse, ttl time.Duration, err error) {
+	store := c.credentials.TokenStore
+	if store == nil {
+		if dst, err = c.GetPaymentToken(ctx, nil); err != nil {
+			return
+		}
+		ttl = c.tokenTTL(dst)
+		return
+	}
+
+	for {
+		dst, ttl, err = store.Get(ctx)
+		if err == nil && (stale == "" || dst.AccessToken != stale) {
+			return
+		}
+
+		if err != nil && !errors.Is(err, ErrTokenNotFound) {
+			return
+		}
+
+		unlock, ok, err := store.Lock(ctx, tokenLockTTL)
+		if err != nil {
+			return dst, ttl, err
+		}
+
+		if ok {
+			defer unlock()
+
+			if dst, err = c.GetPaymentToken(ctx, nil); err != nil {
+				return dst, ttl, err
+			}
+			ttl = c.tokenTTL(dst)
+
+			return dst, ttl, store.Set(ctx, dst, ttl)
+		}
+
+		select {
+		case <-ctx.Done():
+			return dst, ttl, ctx.Err()
+		case <-time.After(tokenPollInterval):
+		}
+	}
+}
+
+// tokenTTL is how long the token is used before it is refreshed, leaving room
+// for the clock skew between us and the provider
+func (c *Client) tokenTTL(token TokenResponse) time.Duration {
+	ttl := time.Duration(token.ExpiresIn.IntPart())*time.Second - tokenRefreshBuffer - c.credentials.ClockSkew
+	if ttl <= 0 {
+		ttl = tokenPollInterval
+	}
+
+	return ttl
+}
+
+// jitter brings the refresh forward by up to a tenth of the ttl, so replicas don't refresh at once
+func jitter(ttl time.Duration) time.Duration {
+	return ttl - time.Duration(rand.Float64()*tokenRefreshJitter*float64(ttl))
+}
+
 func (c *Client) GetPaymentToken(ctx context.Context, src *PaymentRequest) (dst TokenResponse, err error) {
 	path, err := url.Parse(c.credentials.OAuthURL)
 	if err != nil {
@@ -47,7 +198,6 @@ func (c *Client) GetPaymentToken(ctx context.Context, src *PaymentRequest) (dst
 
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
-	defer writer.Close()
 
 	_ = writer.WriteField("client_id", c.credentials.Login)
 	_ = writer.WriteField("client_secret", c.credentials.Password)
@@ -61,10 +211,15 @@ func (c *Client) GetPaymentToken(ctx context.Context, src *PaymentRequest) (dst
 		_ = writer.WriteField("terminal", src.TerminalID)
 	}
 
+	// the closing boundary has to be written before the body is sent
+	if err = writer.Close(); err != nil {
+		return
+	}
+
 	headers := map[string]string{
 		"Content-Type": writer.FormDataContentType(),
 	}
-	err = c.request(ctx, true, "POST", path.String(), body, headers, &dst)
+	err = c.request(ctx, false, "POST", path.String(), body, headers, &dst)
 
 	return
 }
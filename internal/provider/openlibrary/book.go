@@ -0,0 +1,75 @@
+package openlibrary
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/store"
+)
+
+type name struct {
+	Name string `json:"name"`
+}
+
+type Book struct {
+	Title       string `json:"title"`
+	PublishDate string `json:"publish_date"`
+	Authors     []name `json:"authors"`
+	Subjects    []name `json:"subjects"`
+	Cover       struct {
+		Small  string `json:"small"`
+		Medium string `json:"medium"`
+		Large  string `json:"large"`
+	} `json:"cover"`
+}
+
+var yearPattern = regexp.MustCompile(`\d{4}`)
+
+// Lookup implements book.MetadataProvider with the books api, see https://openlibrary.org/dev/docs/api/books
+func (c *Client) Lookup(ctx context.Context, isbn book.ISBN) (dest book.Metadata, err error) {
+	path, err := url.Parse(c.credentials.URL)
+	if err != nil {
+		return
+	}
+	path = path.JoinPath("/api/books")
+
+	key := "ISBN:" + isbn.ISBN13().String()
+	values := url.Values{}
+	values.Set("bibkeys", key)
+	values.Set("format", "json")
+	values.Set("jscmd", "data")
+	path.RawQuery = values.Encode()
+
+	res := map[string]Book{}
+	if err = c.request(ctx, "GET", path.String(), &res); err != nil {
+		return
+	}
+
+	data, ok := res[key]
+	if !ok {
+		err = store.ErrorNotFound
+		return
+	}
+
+	dest = book.Metadata{
+		Title:    data.Title,
+		CoverURL: data.Cover.Large,
+	}
+
+	for _, author := range data.Authors {
+		dest.Authors = append(dest.Authors, author.Name)
+	}
+
+	if len(data.Subjects) > 0 {
+		dest.Genre = data.Subjects[0].Name
+	}
+
+	if year := yearPattern.FindString(data.PublishDate); year != "" {
+		dest.Year, _ = strconv.Atoi(year)
+	}
+
+	return
+}
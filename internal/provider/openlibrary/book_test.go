@@ -0,0 +1,74 @@
+package openlibrary
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/fixture"
+	"library-service/pkg/store"
+)
+
+// the books api is asked by the isbn-13 whatever the isbn was written as
+var request = fixture.Exchange{
+	Method: http.MethodGet,
+	Path:   "/api/books",
+	Query:  url.Values{"bibkeys": {"ISBN:9780140449136"}, "format": {"json"}, "jscmd": {"data"}},
+	Header: http.Header{"Accept": {"application/json"}},
+}
+
+func TestLookup(t *testing.T) {
+	found, none, failed := request, request, request
+	found.File = "books.json"
+	none.File = "none.json"
+	failed.Status, failed.Body = http.StatusServiceUnavailable, "Service Unavailable"
+
+	tests := []struct {
+		name     string
+		isbn     string
+		exchange fixture.Exchange
+		want     book.Metadata
+		err      error
+	}{
+		{"isbn-10", "0-14-044913-2", found, book.Metadata{
+			Title:    "Crime and Punishment",
+			Genre:    "Psychological fiction",
+			Authors:  []string{"Fyodor Dostoyevsky"},
+			Year:     2003,
+			CoverURL: "https://covers.openlibrary.org/b/id/8479246-L.jpg",
+		}, nil},
+		{"unknown", "978-0-14-044913-6", none, book.Metadata{}, store.ErrorNotFound},
+		{"unavailable", "9780140449136", failed, book.Metadata{}, errors.New("Service Unavailable")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := fixture.Server(t, tt.exchange)
+			client := New(Credentials{URL: server.URL})
+
+			isbn, err := book.ParseISBN(tt.isbn)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := client.Lookup(context.Background(), isbn)
+			if tt.err != nil {
+				if err == nil || err.Error() != tt.err.Error() {
+					t.Fatalf("got error %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package openlibrary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+type Credentials struct {
+	URL string
+
+	// Transport overrides the http transport, e.g. to put the calls behind a bulkhead
+	Transport http.RoundTripper
+}
+
+type Client struct {
+	httpClient  *http.Client
+	credentials Credentials
+}
+
+func New(credentials Credentials) *Client {
+	return &Client{
+		httpClient: &http.Client{
+			Transport: credentials.Transport,
+			Timeout:   10 * time.Second,
+		},
+		credentials: credentials,
+	}
+}
+
+func (c *Client) request(ctx context.Context, method, url string, out interface{}) (err error) {
+	// create new request
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Add("Accept", "application/json")
+
+	// send request
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+
+	// read response body
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+
+	// check response status
+	if res.StatusCode != http.StatusOK {
+		return errors.New(string(data))
+	}
+	err = json.Unmarshal(data, &out)
+
+	return
+}
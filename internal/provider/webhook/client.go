@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of the body keyed with the secret, so that the
+// receiver can tell the events come from the library
+const SignatureHeader = "X-Library-Signature"
+
+type Credentials struct {
+	URL    string
+	Secret string
+
+	// Transport overrides the http transport, e.g. to put the calls behind a bulkhead
+	Transport http.RoundTripper
+}
+
+// Client posts the events to a webhook, the receiver delivers them to the members
+type Client struct {
+	httpClient  *http.Client
+	credentials Credentials
+
+	mu       sync.Mutex
+	delivery error
+}
+
+func New(credentials Credentials) *Client {
+	return &Client{
+		httpClient: &http.Client{
+			Transport: credentials.Transport,
+			Timeout:   10 * time.Second,
+		},
+		credentials: credentials,
+	}
+}
+
+// Event is the body of every call, Data depends on the Type
+type Event struct {
+	Type    string    `json:"type"`
+	Members []string  `json:"members"`
+	Data    any       `json:"data"`
+	SentAt  time.Time `json:"sentAt"`
+}
+
+// Ping reports the failure of the last delivery, the webhook isn't called only to check it
+func (c *Client) Ping(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.delivery
+}
+
+func (c *Client) send(ctx context.Context, event Event) (err error) {
+	defer func() {
+		c.mu.Lock()
+		c.delivery = err
+		c.mu.Unlock()
+	}()
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	// create new request
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.credentials.URL, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	if c.credentials.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(c.credentials.Secret))
+		mac.Write(body)
+		req.Header.Add(SignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	// send request
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+
+	// check response status
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		data, _ := io.ReadAll(io.LimitReader(res.Body, 1<<10))
+		return fmt.Errorf("webhook: %s: %s", res.Status, data)
+	}
+
+	return
+}
@@ -0,0 +1,18 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"library-service/internal/domain/suggestion"
+)
+
+// Notify sends a suggestion.<status> event with the suggestion to the members
+func (c *Client) Notify(ctx context.Context, members []string, data suggestion.Entity) (err error) {
+	return c.send(ctx, Event{
+		Type:    "suggestion." + string(*data.Status),
+		Members: members,
+		Data:    suggestion.ParseFromEntity(data),
+		SentAt:  time.Now(),
+	})
+}
@@ -0,0 +1,18 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"library-service/internal/domain/book"
+)
+
+// NotifyAvailable sends a book.available event with the book to the members watching it
+func (c *Client) NotifyAvailable(ctx context.Context, members []string, data book.Entity) (err error) {
+	return c.send(ctx, Event{
+		Type:    "book.available",
+		Members: members,
+		Data:    book.ParseFromEntity(data),
+		SentAt:  time.Now(),
+	})
+}
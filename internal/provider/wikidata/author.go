@@ -0,0 +1,141 @@
+package wikidata
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"library-service/internal/domain/author"
+	"library-service/pkg/store"
+)
+
+const (
+	propertyORCID = "P496"
+	propertyVIAF  = "P214"
+	propertyImage = "P18"
+
+	commonsFilePath = "https://commons.wikimedia.org/wiki/Special:FilePath/"
+)
+
+type Entity struct {
+	ID           string  `json:"id"`
+	Missing      *string `json:"missing"`
+	Descriptions map[string]struct {
+		Value string `json:"value"`
+	} `json:"descriptions"`
+	Claims map[string][]struct {
+		Mainsnak struct {
+			Datavalue struct {
+				Value json.RawMessage `json:"value"`
+			} `json:"datavalue"`
+		} `json:"mainsnak"`
+	} `json:"claims"`
+	Sitelinks map[string]struct {
+		Title string `json:"title"`
+	} `json:"sitelinks"`
+}
+
+// Lookup implements author.ProfileProvider with the wikibase api, see https://www.wikidata.org/w/api.php.
+// The item is found by the title of its english wikipedia article first, then by its ORCID or VIAF.
+func (c *Client) Lookup(ctx context.Context, ids author.Identifiers) (dest author.Profile, err error) {
+	values := url.Values{}
+	switch {
+	case ids.Wikipedia != "":
+		values.Set("sites", "enwiki")
+		values.Set("titles", ids.Wikipedia)
+	case ids.ORCID != "":
+		err = c.search(ctx, propertyORCID, ids.ORCID, values)
+	default:
+		err = c.search(ctx, propertyVIAF, ids.VIAF, values)
+	}
+	if err != nil {
+		return
+	}
+
+	values.Set("action", "wbgetentities")
+	values.Set("props", "claims|descriptions|sitelinks")
+	values.Set("languages", "en")
+	values.Set("sitefilter", "enwiki")
+
+	res := struct {
+		Entities map[string]Entity `json:"entities"`
+	}{}
+	if err = c.api(ctx, values, &res); err != nil {
+		return
+	}
+
+	for _, data := range res.Entities {
+		if data.Missing != nil {
+			continue
+		}
+
+		dest = author.Profile{
+			Identifiers: author.Identifiers{
+				ORCID:     data.claim(propertyORCID),
+				VIAF:      data.claim(propertyVIAF),
+				Wikipedia: data.Sitelinks["enwiki"].Title,
+			},
+			Biography: data.Descriptions["en"].Value,
+		}
+
+		if image := data.claim(propertyImage); image != "" {
+			dest.Photo = commonsFilePath + url.PathEscape(strings.ReplaceAll(image, " ", "_"))
+		}
+		return
+	}
+	err = store.ErrorNotFound
+
+	return
+}
+
+// search sets the id of the item with the value of the property, e.g. haswbstatement:P496=0000-0002-1825-0097
+func (c *Client) search(ctx context.Context, property, value string, values url.Values) (err error) {
+	params := url.Values{}
+	params.Set("action", "query")
+	params.Set("list", "search")
+	params.Set("srsearch", "haswbstatement:"+property+"="+value)
+	params.Set("srlimit", "1")
+
+	res := struct {
+		Query struct {
+			Search []struct {
+				Title string `json:"title"`
+			} `json:"search"`
+		} `json:"query"`
+	}{}
+	if err = c.api(ctx, params, &res); err != nil {
+		return
+	}
+
+	if len(res.Query.Search) == 0 {
+		return store.ErrorNotFound
+	}
+	values.Set("ids", res.Query.Search[0].Title)
+
+	return
+}
+
+func (c *Client) api(ctx context.Context, values url.Values, out interface{}) (err error) {
+	path, err := url.Parse(c.credentials.URL)
+	if err != nil {
+		return
+	}
+	path = path.JoinPath("/w/api.php")
+
+	values.Set("format", "json")
+	path.RawQuery = values.Encode()
+
+	return c.request(ctx, "GET", path.String(), out)
+}
+
+// claim returns the first value of a string property of the item
+func (e Entity) claim(property string) (value string) {
+	for _, claim := range e.Claims[property] {
+		if json.Unmarshal(claim.Mainsnak.Datavalue.Value, &value) == nil && value != "" {
+			return
+		}
+	}
+
+	return ""
+}
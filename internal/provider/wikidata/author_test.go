@@ -0,0 +1,92 @@
+package wikidata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"library-service/internal/domain/author"
+	"library-service/pkg/fixture"
+	"library-service/pkg/store"
+)
+
+// wikimedia refuses the clients that don't identify themselves
+var headers = http.Header{"Accept": {"application/json"}, "User-Agent": {"library-service"}}
+
+// entities asks for the item by the values, either the title of the article or the id the search found
+func entities(values url.Values, file string) fixture.Exchange {
+	query := url.Values{
+		"action":     {"wbgetentities"},
+		"props":      {"claims|descriptions|sitelinks"},
+		"languages":  {"en"},
+		"sitefilter": {"enwiki"},
+		"format":     {"json"},
+	}
+	for key, value := range values {
+		query[key] = value
+	}
+
+	return fixture.Exchange{Method: http.MethodGet, Path: "/w/api.php", Query: query, Header: headers, File: file}
+}
+
+func search(statement, file string) fixture.Exchange {
+	query := url.Values{
+		"action":   {"query"},
+		"list":     {"search"},
+		"srsearch": {"haswbstatement:" + statement},
+		"srlimit":  {"1"},
+		"format":   {"json"},
+	}
+
+	return fixture.Exchange{Method: http.MethodGet, Path: "/w/api.php", Query: query, Header: headers, File: file}
+}
+
+func TestLookup(t *testing.T) {
+	dostoevsky := author.Profile{
+		Identifiers: author.Identifiers{VIAF: "104728228", Wikipedia: "Fyodor Dostoevsky"},
+		Biography:   "Russian novelist (1821–1881)",
+		Photo: "https://commons.wikimedia.org/wiki/Special:FilePath/" +
+			"Vasily_Perov_-_%D0%9F%D0%BE%D1%80%D1%82%D1%80%D0%B5%D1%82_%D0%A4.%D0%9C.%D0%94%D0%BE%D1%81%D1%82%D0%BE%D0%B5%D0%B2%D1%81%D0%BA%D0%BE%D0%B3%D0%BE_-_Google_Art_Project.jpg",
+	}
+
+	tests := []struct {
+		name      string
+		ids       author.Identifiers
+		exchanges []fixture.Exchange
+		want      author.Profile
+		err       error
+	}{
+		{"wikipedia", author.Identifiers{Wikipedia: "Fyodor Dostoevsky", ORCID: "0000-0002-1825-0097"}, []fixture.Exchange{
+			entities(url.Values{"sites": {"enwiki"}, "titles": {"Fyodor Dostoevsky"}}, "entities.json"),
+		}, dostoevsky, nil},
+		{"viaf", author.Identifiers{VIAF: "104728228"}, []fixture.Exchange{
+			search("P214=104728228", "search.json"),
+			entities(url.Values{"ids": {"Q991"}}, "entities.json"),
+		}, dostoevsky, nil},
+		{"orcid unknown", author.Identifiers{ORCID: "0000-0002-1825-0097", VIAF: "104728228"}, []fixture.Exchange{
+			search("P496=0000-0002-1825-0097", "search_none.json"),
+		}, author.Profile{}, store.ErrorNotFound},
+		{"article missing", author.Identifiers{Wikipedia: "Nobody In Particular"}, []fixture.Exchange{
+			entities(url.Values{"sites": {"enwiki"}, "titles": {"Nobody In Particular"}}, "missing.json"),
+		}, author.Profile{}, store.ErrorNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := fixture.Server(t, tt.exchanges...)
+			client := New(Credentials{URL: server.URL})
+
+			got, err := client.Lookup(context.Background(), tt.ids)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got error %v, want %v", err, tt.err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
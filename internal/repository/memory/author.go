@@ -2,12 +2,12 @@ package memory
 
 import (
 	"context"
-	"database/sql"
 	"sync"
 
 	"github.com/google/uuid"
 
 	"library-service/internal/domain/author"
+	"library-service/pkg/store"
 )
 
 type AuthorRepository struct {
@@ -50,7 +50,7 @@ func (r *AuthorRepository) Get(ctx context.Context, id string) (dest author.Enti
 
 	dest, ok := r.db[id]
 	if !ok {
-		err = sql.ErrNoRows
+		err = store.ErrorNotFound
 		return
 	}
 
@@ -61,10 +61,47 @@ func (r *AuthorRepository) Update(ctx context.Context, id string, data author.En
 	r.Lock()
 	defer r.Unlock()
 
-	if _, ok := r.db[id]; !ok {
-		return sql.ErrNoRows
+	dest, ok := r.db[id]
+	if !ok {
+		return store.ErrorNotFound
 	}
-	r.db[id] = data
+
+	if data.FullName != nil {
+		dest.FullName = data.FullName
+	}
+
+	if data.Pseudonym != nil {
+		dest.Pseudonym = data.Pseudonym
+	}
+
+	if data.Specialty != nil {
+		dest.Specialty = data.Specialty
+	}
+
+	if data.ORCID != nil {
+		dest.ORCID = data.ORCID
+	}
+
+	if data.VIAF != nil {
+		dest.VIAF = data.VIAF
+	}
+
+	if data.Wikipedia != nil {
+		dest.Wikipedia = data.Wikipedia
+	}
+
+	if data.Biography != nil {
+		dest.Biography = data.Biography
+	}
+
+	if data.Photo != nil {
+		dest.Photo = data.Photo
+	}
+
+	if data.Edited != nil {
+		dest.Edited = data.Edited
+	}
+	r.db[id] = dest
 
 	return
 }
@@ -74,7 +111,7 @@ func (r *AuthorRepository) Delete(ctx context.Context, id string) (err error) {
 	defer r.Unlock()
 
 	if _, ok := r.db[id]; !ok {
-		return sql.ErrNoRows
+		return store.ErrorNotFound
 	}
 	delete(r.db, id)
 
@@ -2,22 +2,29 @@ package memory
 
 import (
 	"context"
-	"database/sql"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 
 	"library-service/internal/domain/book"
+	"library-service/pkg/store"
 )
 
 type BookRepository struct {
 	db map[string]book.Entity
 	sync.RWMutex
+
+	// authors are searched by their names along with the books
+	authors *AuthorRepository
 }
 
-func NewBookRepository() *BookRepository {
+func NewBookRepository(authors *AuthorRepository) *BookRepository {
 	return &BookRepository{
-		db: make(map[string]book.Entity),
+		db:      make(map[string]book.Entity),
+		authors: authors,
 	}
 }
 
@@ -25,6 +32,34 @@ func (r *BookRepository) List(ctx context.Context) (dest []book.Entity, err erro
 	r.RLock()
 	defer r.RUnlock()
 
+	dest = make([]book.Entity, 0, len(r.db))
+	for _, data := range r.db {
+		if data.DeletedAt == nil {
+			dest = append(dest, data)
+		}
+	}
+
+	return
+}
+
+func (r *BookRepository) ListByIDs(ctx context.Context, ids []string) (dest []book.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]book.Entity, 0, len(ids))
+	for _, id := range ids {
+		if data, ok := r.db[id]; ok && data.DeletedAt == nil {
+			dest = append(dest, data)
+		}
+	}
+
+	return
+}
+
+func (r *BookRepository) ListWithDeleted(ctx context.Context) (dest []book.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
 	dest = make([]book.Entity, 0, len(r.db))
 	for _, data := range r.db {
 		dest = append(dest, data)
@@ -33,12 +68,68 @@ func (r *BookRepository) List(ctx context.Context) (dest []book.Entity, err erro
 	return
 }
 
+func (r *BookRepository) Each(ctx context.Context, fn func(data book.Entity) error) (err error) {
+	// fn runs outside of the lock, it may be as slow as the client the books are written to
+	data, err := r.List(ctx)
+	if err != nil {
+		return
+	}
+
+	sort.Slice(data, func(i, j int) bool {
+		return data[i].ID < data[j].ID
+	})
+
+	for _, object := range data {
+		if err = fn(object); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (r *BookRepository) Filter(ctx context.Context, filter book.Filter, limit, offset int) (dest []book.Entity, err error) {
+	data, err := r.List(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, object := range data {
+		if filter.Match(object) {
+			dest = append(dest, object)
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].ID < dest[j].ID
+	})
+
+	if filter.Series != "" {
+		dest = book.ReadingOrder(dest)
+	}
+
+	if offset >= len(dest) {
+		return nil, nil
+	}
+	dest = dest[offset:]
+
+	if limit < len(dest) {
+		dest = dest[:limit]
+	}
+
+	return
+}
+
 func (r *BookRepository) Add(ctx context.Context, data book.Entity) (dest string, err error) {
 	r.Lock()
 	defer r.Unlock()
 
-	id := r.generateID()
-	data.ID = id
+	if data.SeriesID != nil && *data.SeriesID == "" {
+		data.SeriesID, data.Volume = nil, nil
+	}
+
+	id, now := r.generateID(), time.Now()
+	data.ID, data.CreatedAt = id, &now
 	r.db[id] = data
 
 	return id, nil
@@ -49,8 +140,8 @@ func (r *BookRepository) Get(ctx context.Context, id string) (dest book.Entity,
 	defer r.RUnlock()
 
 	dest, ok := r.db[id]
-	if !ok {
-		err = sql.ErrNoRows
+	if !ok || dest.DeletedAt != nil {
+		err = store.ErrorNotFound
 		return
 	}
 
@@ -61,10 +152,66 @@ func (r *BookRepository) Update(ctx context.Context, id string, data book.Entity
 	r.Lock()
 	defer r.Unlock()
 
-	if _, ok := r.db[id]; !ok {
-		return sql.ErrNoRows
+	dest, ok := r.db[id]
+	if !ok || dest.DeletedAt != nil {
+		return store.ErrorNotFound
+	}
+
+	if data.Name != nil {
+		dest.Name = data.Name
+	}
+
+	if data.Genre != nil {
+		dest.Genre = data.Genre
+	}
+
+	if data.ISBN != nil {
+		dest.ISBN = data.ISBN
+	}
+
+	if data.Authors != nil {
+		dest.Authors = data.Authors
 	}
-	r.db[id] = data
+
+	if data.Categories != nil {
+		dest.Categories = data.Categories
+	}
+
+	if data.Tags != nil {
+		dest.Tags = data.Tags
+	}
+
+	if data.SeriesID != nil {
+		dest.SeriesID, dest.Volume = data.SeriesID, data.Volume
+		if *data.SeriesID == "" {
+			dest.SeriesID, dest.Volume = nil, nil
+		}
+	}
+
+	if data.Year != nil {
+		dest.Year = data.Year
+	}
+
+	if data.Cover != nil {
+		dest.Cover = data.Cover
+	}
+
+	if data.Description != nil {
+		dest.Description = data.Description
+	}
+
+	if data.CoverKey != nil {
+		dest.CoverKey = data.CoverKey
+	}
+
+	if data.Rating != nil {
+		dest.Rating = data.Rating
+	}
+
+	if data.ReviewCount != nil {
+		dest.ReviewCount = data.ReviewCount
+	}
+	r.db[id] = dest
 
 	return
 }
@@ -73,10 +220,108 @@ func (r *BookRepository) Delete(ctx context.Context, id string) (err error) {
 	r.Lock()
 	defer r.Unlock()
 
-	if _, ok := r.db[id]; !ok {
-		return sql.ErrNoRows
+	dest, ok := r.db[id]
+	if !ok || dest.DeletedAt != nil {
+		return store.ErrorNotFound
+	}
+	now := time.Now()
+	dest.DeletedAt = &now
+	r.db[id] = dest
+
+	return
+}
+
+// searchText joins the searched fields of the book, an author that is not found is left out
+func (r *BookRepository) searchText(data book.Entity) string {
+	text := []string{*data.Name}
+
+	if r.authors != nil {
+		r.authors.RLock()
+		for _, id := range data.Authors {
+			if author, ok := r.authors.db[id]; ok {
+				text = append(text, *author.FullName, *author.Pseudonym)
+			}
+		}
+		r.authors.RUnlock()
+	}
+
+	text = append(text, *data.Genre)
+	if data.Description != nil {
+		text = append(text, *data.Description)
+	}
+
+	return strings.Join(text, " ")
+}
+
+func (r *BookRepository) Restore(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	dest, ok := r.db[id]
+	if !ok || dest.DeletedAt == nil {
+		return store.ErrorNotFound
+	}
+	dest.DeletedAt = nil
+	r.db[id] = dest
+
+	return
+}
+
+// Search ranks the books by the number of words that the terms are a prefix of, the words are those of
+// the search_vector of postgres: the name, the names of the authors, the genre and the description
+func (r *BookRepository) Search(ctx context.Context, query string, limit, offset int) (dest []book.Entity, err error) {
+	terms := book.SearchTerms(query)
+	if len(terms) == 0 {
+		return
+	}
+
+	r.RLock()
+	defer r.RUnlock()
+
+	ranks := make(map[string]int)
+	for id, data := range r.db {
+		if data.DeletedAt != nil {
+			continue
+		}
+		words := book.SearchTerms(r.searchText(data))
+
+		rank := 0
+		for _, term := range terms {
+			matches := 0
+			for _, word := range words {
+				if strings.HasPrefix(word, term) {
+					matches++
+				}
+			}
+
+			if matches == 0 {
+				rank = 0
+				break
+			}
+			rank += matches
+		}
+
+		if rank > 0 {
+			ranks[id] = rank
+			dest = append(dest, data)
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		if ranks[dest[i].ID] != ranks[dest[j].ID] {
+			return ranks[dest[i].ID] > ranks[dest[j].ID]
+		}
+		return dest[i].ID < dest[j].ID
+	})
+
+	if offset >= len(dest) {
+		return nil, nil
+	}
+	dest = dest[offset:]
+
+	if limit < len(dest) {
+		dest = dest[:limit]
 	}
-	delete(r.db, id)
 
 	return
 }
@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"library-service/internal/domain/category"
+	"library-service/pkg/store"
+)
+
+type CategoryRepository struct {
+	db map[string]category.Entity
+	sync.RWMutex
+}
+
+func NewCategoryRepository() *CategoryRepository {
+	return &CategoryRepository{
+		db: make(map[string]category.Entity),
+	}
+}
+
+func (r *CategoryRepository) List(ctx context.Context) (dest []category.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]category.Entity, 0, len(r.db))
+	for _, data := range r.db {
+		dest = append(dest, data)
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return *dest[i].Name < *dest[j].Name
+	})
+
+	return
+}
+
+func (r *CategoryRepository) Add(ctx context.Context, data category.Entity) (dest string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if data.ParentID != nil && *data.ParentID == "" {
+		data.ParentID = nil
+	}
+
+	id := r.generateID()
+	data.ID = id
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *CategoryRepository) Get(ctx context.Context, id string) (dest category.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *CategoryRepository) Update(ctx context.Context, id string, data category.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		return store.ErrorNotFound
+	}
+
+	if data.Name != nil {
+		dest.Name = data.Name
+	}
+
+	if data.Slug != nil {
+		dest.Slug = data.Slug
+	}
+
+	if data.ParentID != nil {
+		dest.ParentID = data.ParentID
+		if *data.ParentID == "" {
+			dest.ParentID = nil
+		}
+	}
+	r.db[id] = dest
+
+	return
+}
+
+func (r *CategoryRepository) Delete(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.db[id]; !ok {
+		return store.ErrorNotFound
+	}
+	delete(r.db, id)
+
+	return
+}
+
+func (r *CategoryRepository) generateID() string {
+	return uuid.New().String()
+}
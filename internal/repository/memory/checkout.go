@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"library-service/internal/domain/book"
+)
+
+type CheckoutRepository struct {
+	db map[string]book.Checkout
+	sync.RWMutex
+}
+
+func NewCheckoutRepository() *CheckoutRepository {
+	return &CheckoutRepository{
+		db: make(map[string]book.Checkout),
+	}
+}
+
+func (r *CheckoutRepository) Add(ctx context.Context, data book.Checkout) (dest string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	id := r.generateID()
+	data.ID = id
+	data.CreatedAt = time.Now()
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *CheckoutRepository) Count(ctx context.Context, since time.Time) (dest map[string]int, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make(map[string]int)
+	for _, data := range r.db {
+		if !data.CreatedAt.Before(since) {
+			dest[data.BookID]++
+		}
+	}
+
+	return
+}
+
+func (r *CheckoutRepository) generateID() string {
+	return uuid.New().String()
+}
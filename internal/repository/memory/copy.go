@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/store"
+)
+
+type CopyRepository struct {
+	db map[string]book.Copy
+	sync.RWMutex
+}
+
+func NewCopyRepository() *CopyRepository {
+	return &CopyRepository{
+		db: make(map[string]book.Copy),
+	}
+}
+
+func (r *CopyRepository) List(ctx context.Context, bookID string) (dest []book.Copy, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]book.Copy, 0)
+	for _, data := range r.db {
+		if data.BookID == bookID {
+			dest = append(dest, data)
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return *dest[i].Barcode < *dest[j].Barcode
+	})
+
+	return
+}
+
+func (r *CopyRepository) ListByBooks(ctx context.Context, bookIDs []string) (dest []book.Copy, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	books := make(map[string]bool, len(bookIDs))
+	for _, id := range bookIDs {
+		books[id] = true
+	}
+
+	dest = make([]book.Copy, 0)
+	for _, data := range r.db {
+		if books[data.BookID] {
+			dest = append(dest, data)
+		}
+	}
+
+	return
+}
+
+func (r *CopyRepository) Add(ctx context.Context, data book.Copy) (dest string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	id := r.generateID()
+	data.ID = id
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *CopyRepository) Get(ctx context.Context, id string) (dest book.Copy, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *CopyRepository) GetByBarcode(ctx context.Context, barcode string) (dest book.Copy, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	for _, data := range r.db {
+		if data.Barcode != nil && *data.Barcode == barcode {
+			return data, nil
+		}
+	}
+	err = store.ErrorNotFound
+
+	return
+}
+
+func (r *CopyRepository) Update(ctx context.Context, id string, data book.Copy) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		return store.ErrorNotFound
+	}
+
+	if data.Barcode != nil {
+		dest.Barcode = data.Barcode
+	}
+
+	if data.Condition != nil {
+		dest.Condition = data.Condition
+	}
+
+	if data.Location != nil {
+		dest.Location = data.Location
+	}
+
+	if data.Status != nil {
+		dest.Status = data.Status
+	}
+	r.db[id] = dest
+
+	return
+}
+
+func (r *CopyRepository) Delete(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.db[id]; !ok {
+		return store.ErrorNotFound
+	}
+	delete(r.db, id)
+
+	return
+}
+
+func (r *CopyRepository) generateID() string {
+	return uuid.New().String()
+}
@@ -0,0 +1,171 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"library-service/internal/domain/health"
+	"library-service/pkg/store"
+)
+
+type IncidentRepository struct {
+	db map[string]health.Incident
+	sync.RWMutex
+}
+
+func NewIncidentRepository() *IncidentRepository {
+	return &IncidentRepository{
+		db: make(map[string]health.Incident),
+	}
+}
+
+func (r *IncidentRepository) List(ctx context.Context, current bool) (dest []health.Incident, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]health.Incident, 0, len(r.db))
+	for _, data := range r.db {
+		if !current || *data.State != health.StateResolved {
+			dest = append(dest, data)
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.After(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *IncidentRepository) Add(ctx context.Context, data health.Incident) (dest string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	id := r.generateID()
+	data.ID = id
+	data.CreatedAt = time.Now()
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *IncidentRepository) Get(ctx context.Context, id string) (dest health.Incident, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *IncidentRepository) Update(ctx context.Context, id string, data health.Incident) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		return store.ErrorNotFound
+	}
+
+	if data.Title != nil {
+		dest.Title = data.Title
+	}
+
+	if data.Description != nil {
+		dest.Description = data.Description
+	}
+
+	if data.Impact != nil {
+		dest.Impact = data.Impact
+	}
+
+	if data.State != nil {
+		dest.State = data.State
+	}
+
+	if len(data.Components) > 0 {
+		dest.Components = data.Components
+	}
+
+	if data.ResolvedAt != nil {
+		dest.ResolvedAt = data.ResolvedAt
+	}
+	r.db[id] = dest
+
+	return
+}
+
+func (r *IncidentRepository) Delete(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.db[id]; !ok {
+		return store.ErrorNotFound
+	}
+	delete(r.db, id)
+
+	return
+}
+
+func (r *IncidentRepository) generateID() string {
+	return uuid.New().String()
+}
+
+// CheckRepository only keeps the days of the uptime window
+type CheckRepository struct {
+	db map[string]health.Day
+	sync.RWMutex
+}
+
+func NewCheckRepository() *CheckRepository {
+	return &CheckRepository{
+		db: make(map[string]health.Day),
+	}
+}
+
+func (r *CheckRepository) Record(ctx context.Context, component string, day time.Time, passed bool) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	key := component + "/" + day.Format("2006-01-02")
+	data, ok := r.db[key]
+	if !ok {
+		data = health.Day{Component: component, Day: day}
+	}
+
+	data.Total++
+	if passed {
+		data.Passed++
+	}
+	r.db[key] = data
+
+	for key, data := range r.db {
+		if day.Sub(data.Day) > health.UptimeWindow {
+			delete(r.db, key)
+		}
+	}
+
+	return
+}
+
+func (r *CheckRepository) List(ctx context.Context, since time.Time) (dest []health.Day, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]health.Day, 0, len(r.db))
+	for _, data := range r.db {
+		if !data.Day.Before(since) {
+			dest = append(dest, data)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"library-service/internal/domain/loan"
+	"library-service/pkg/store"
+)
+
+type LoanRepository struct {
+	db map[string]loan.Entity
+	sync.RWMutex
+}
+
+func NewLoanRepository() *LoanRepository {
+	return &LoanRepository{
+		db: make(map[string]loan.Entity),
+	}
+}
+
+func (r *LoanRepository) List(ctx context.Context, memberID string) (dest []loan.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]loan.Entity, 0)
+	for _, data := range r.db {
+		if data.MemberID == memberID {
+			dest = append(dest, data)
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.After(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *LoanRepository) Add(ctx context.Context, data loan.Entity, limit int) (dest string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	active := 0
+	for _, object := range r.db {
+		if !object.Active() {
+			continue
+		}
+
+		if object.CopyID == data.CopyID {
+			return "", loan.ErrorUnavailable
+		}
+
+		if object.MemberID == data.MemberID {
+			active++
+		}
+	}
+
+	if active >= limit {
+		return "", loan.ErrorLimit
+	}
+
+	id := r.generateID()
+	data.ID = id
+	data.CreatedAt = time.Now()
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *LoanRepository) Get(ctx context.Context, id string) (dest loan.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *LoanRepository) Update(ctx context.Context, id string, data loan.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		return store.ErrorNotFound
+	}
+	dest.DueAt, dest.Renewals, dest.ReturnedAt = data.DueAt, data.Renewals, data.ReturnedAt
+	r.db[id] = dest
+
+	return
+}
+
+func (r *LoanRepository) Delete(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.db[id]; !ok {
+		return store.ErrorNotFound
+	}
+	delete(r.db, id)
+
+	return
+}
+
+func (r *LoanRepository) generateID() string {
+	return uuid.New().String()
+}
@@ -2,12 +2,12 @@ package memory
 
 import (
 	"context"
-	"database/sql"
 	"sync"
 
 	"github.com/google/uuid"
 
 	"library-service/internal/domain/member"
+	"library-service/pkg/store"
 )
 
 type MemberRepository struct {
@@ -50,7 +50,7 @@ func (r *MemberRepository) Get(ctx context.Context, id string) (dest member.Enti
 
 	dest, ok := r.db[id]
 	if !ok {
-		err = sql.ErrNoRows
+		err = store.ErrorNotFound
 		return
 	}
 
@@ -61,10 +61,23 @@ func (r *MemberRepository) Update(ctx context.Context, id string, data member.En
 	r.Lock()
 	defer r.Unlock()
 
-	if _, ok := r.db[id]; !ok {
-		return sql.ErrNoRows
+	dest, ok := r.db[id]
+	if !ok {
+		return store.ErrorNotFound
 	}
-	r.db[id] = data
+
+	if data.FullName != nil {
+		dest.FullName = data.FullName
+	}
+
+	if data.Books != nil {
+		dest.Books = data.Books
+	}
+
+	if data.Tier != nil {
+		dest.Tier = data.Tier
+	}
+	r.db[id] = dest
 
 	return
 }
@@ -74,7 +87,7 @@ func (r *MemberRepository) Delete(ctx context.Context, id string) (err error) {
 	defer r.Unlock()
 
 	if _, ok := r.db[id]; !ok {
-		return sql.ErrNoRows
+		return store.ErrorNotFound
 	}
 	delete(r.db, id)
 
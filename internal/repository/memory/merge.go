@@ -0,0 +1,145 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"library-service/pkg/store"
+)
+
+// MergeRepository holds the locks of every repository it touches, so a merge is seen whole or not at all
+type MergeRepository struct {
+	books     *BookRepository
+	copies    *CopyRepository
+	checkouts *CheckoutRepository
+	loans     *LoanRepository
+	reviews   *ReviewRepository
+	watches   *WatchRepository
+	members   *MemberRepository
+}
+
+func NewMergeRepository(books *BookRepository, copies *CopyRepository, checkouts *CheckoutRepository, loans *LoanRepository, reviews *ReviewRepository, watches *WatchRepository, members *MemberRepository) *MergeRepository {
+	return &MergeRepository{
+		books:     books,
+		copies:    copies,
+		checkouts: checkouts,
+		loans:     loans,
+		reviews:   reviews,
+		watches:   watches,
+		members:   members,
+	}
+}
+
+func (r *MergeRepository) Merge(ctx context.Context, id, duplicateID string) (err error) {
+	r.books.Lock()
+	defer r.books.Unlock()
+	r.copies.Lock()
+	defer r.copies.Unlock()
+	r.checkouts.Lock()
+	defer r.checkouts.Unlock()
+	r.loans.Lock()
+	defer r.loans.Unlock()
+	r.reviews.Lock()
+	defer r.reviews.Unlock()
+	r.watches.Lock()
+	defer r.watches.Unlock()
+	r.members.Lock()
+	defer r.members.Unlock()
+
+	canonical, ok := r.books.db[id]
+	if !ok || canonical.DeletedAt != nil {
+		return store.ErrorNotFound
+	}
+
+	duplicate, ok := r.books.db[duplicateID]
+	if !ok || duplicate.DeletedAt != nil {
+		return store.ErrorNotFound
+	}
+
+	for key, data := range r.copies.db {
+		if data.BookID == duplicateID {
+			data.BookID = id
+			r.copies.db[key] = data
+		}
+	}
+
+	for key, data := range r.checkouts.db {
+		if data.BookID == duplicateID {
+			data.BookID = id
+			r.checkouts.db[key] = data
+		}
+	}
+
+	for key, data := range r.loans.db {
+		if data.BookID == duplicateID {
+			data.BookID = id
+			r.loans.db[key] = data
+		}
+	}
+
+	reviewed := make(map[string]bool)
+	for _, data := range r.reviews.db {
+		if data.BookID == id {
+			reviewed[data.MemberID] = true
+		}
+	}
+
+	for key, data := range r.reviews.db {
+		if data.BookID != duplicateID {
+			continue
+		}
+
+		if reviewed[data.MemberID] {
+			delete(r.reviews.db, key)
+			continue
+		}
+		data.BookID = id
+		r.reviews.db[key] = data
+	}
+
+	watched := make(map[string]bool)
+	for _, data := range r.watches.db {
+		if data.BookID == id {
+			watched[data.MemberID] = true
+		}
+	}
+
+	for key, data := range r.watches.db {
+		if data.BookID != duplicateID {
+			continue
+		}
+
+		if watched[data.MemberID] {
+			delete(r.watches.db, key)
+			continue
+		}
+		data.BookID = id
+		r.watches.db[key] = data
+	}
+
+	for key, data := range r.members.db {
+		books, changed := make([]string, 0, len(data.Books)), false
+		seen := make(map[string]bool, len(data.Books))
+		for _, bookID := range data.Books {
+			if bookID == duplicateID {
+				bookID, changed = id, true
+			}
+
+			if !seen[bookID] {
+				seen[bookID] = true
+				books = append(books, bookID)
+			}
+		}
+
+		if changed {
+			data.Books = books
+			r.members.db[key] = data
+		}
+	}
+
+	now := time.Now()
+	duplicate.DeletedAt = &now
+	r.books.db[duplicateID] = duplicate
+
+	return
+}
@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"library-service/internal/domain/book"
+)
+
+type RelationRepository struct {
+	db map[string][]book.Relation
+	sync.RWMutex
+}
+
+func NewRelationRepository() *RelationRepository {
+	return &RelationRepository{
+		db: make(map[string][]book.Relation),
+	}
+}
+
+func (r *RelationRepository) List(ctx context.Context, bookID string) (dest []book.Relation, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = append([]book.Relation{}, r.db[bookID]...)
+
+	return
+}
+
+// Replace keeps the relations of each book in the order of the data
+func (r *RelationRepository) Replace(ctx context.Context, data []book.Relation) (err error) {
+	db := make(map[string][]book.Relation)
+	for _, object := range data {
+		db[object.BookID] = append(db[object.BookID], object)
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	r.db = db
+
+	return
+}
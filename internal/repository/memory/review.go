@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"library-service/internal/domain/review"
+	"library-service/pkg/store"
+)
+
+type ReviewRepository struct {
+	db map[string]review.Entity
+	sync.RWMutex
+}
+
+func NewReviewRepository() *ReviewRepository {
+	return &ReviewRepository{
+		db: make(map[string]review.Entity),
+	}
+}
+
+func (r *ReviewRepository) List(ctx context.Context, bookID string) (dest []review.Entity, err error) {
+	return r.filter(func(data review.Entity) bool {
+		return data.BookID == bookID
+	}), nil
+}
+
+func (r *ReviewRepository) ListByStatus(ctx context.Context, status review.Status) (dest []review.Entity, err error) {
+	return r.filter(func(data review.Entity) bool {
+		return *data.Status == status
+	}), nil
+}
+
+func (r *ReviewRepository) filter(match func(data review.Entity) bool) (dest []review.Entity) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]review.Entity, 0)
+	for _, data := range r.db {
+		if match(data) {
+			dest = append(dest, data)
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.After(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *ReviewRepository) Add(ctx context.Context, data review.Entity) (dest string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, object := range r.db {
+		if object.BookID == data.BookID && object.MemberID == data.MemberID {
+			return "", review.ErrorExists
+		}
+	}
+
+	id := r.generateID()
+	data.ID = id
+	data.CreatedAt = time.Now()
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *ReviewRepository) Get(ctx context.Context, id string) (dest review.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *ReviewRepository) Update(ctx context.Context, id string, data review.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		return store.ErrorNotFound
+	}
+
+	if data.Rating != nil {
+		dest.Rating = data.Rating
+	}
+
+	if data.Text != nil {
+		dest.Text = data.Text
+	}
+
+	if data.Status != nil {
+		dest.Status = data.Status
+	}
+	r.db[id] = dest
+
+	return
+}
+
+func (r *ReviewRepository) generateID() string {
+	return uuid.New().String()
+}
@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/store"
+)
+
+type RevisionRepository struct {
+	db map[string]book.Revision
+	sync.RWMutex
+}
+
+func NewRevisionRepository() *RevisionRepository {
+	return &RevisionRepository{
+		db: make(map[string]book.Revision),
+	}
+}
+
+func (r *RevisionRepository) List(ctx context.Context, bookID string) (dest []book.Revision, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]book.Revision, 0)
+	for _, data := range r.db {
+		if data.BookID == bookID {
+			dest = append(dest, data)
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.After(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *RevisionRepository) Add(ctx context.Context, data book.Revision) (dest string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	id := r.generateID()
+	data.ID = id
+	data.CreatedAt = time.Now()
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *RevisionRepository) Get(ctx context.Context, id string) (dest book.Revision, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *RevisionRepository) generateID() string {
+	return uuid.New().String()
+}
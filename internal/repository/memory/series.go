@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"library-service/internal/domain/series"
+	"library-service/pkg/store"
+)
+
+type SeriesRepository struct {
+	db map[string]series.Entity
+	sync.RWMutex
+}
+
+func NewSeriesRepository() *SeriesRepository {
+	return &SeriesRepository{
+		db: make(map[string]series.Entity),
+	}
+}
+
+func (r *SeriesRepository) List(ctx context.Context) (dest []series.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]series.Entity, 0, len(r.db))
+	for _, data := range r.db {
+		dest = append(dest, data)
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return *dest[i].Name < *dest[j].Name
+	})
+
+	return
+}
+
+func (r *SeriesRepository) Add(ctx context.Context, data series.Entity) (dest string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	id := r.generateID()
+	data.ID = id
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *SeriesRepository) Get(ctx context.Context, id string) (dest series.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *SeriesRepository) Update(ctx context.Context, id string, data series.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		return store.ErrorNotFound
+	}
+
+	if data.Name != nil {
+		dest.Name = data.Name
+	}
+
+	if data.Description != nil {
+		dest.Description = data.Description
+	}
+	r.db[id] = dest
+
+	return
+}
+
+func (r *SeriesRepository) Delete(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.db[id]; !ok {
+		return store.ErrorNotFound
+	}
+	delete(r.db, id)
+
+	return
+}
+
+func (r *SeriesRepository) generateID() string {
+	return uuid.New().String()
+}
@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"library-service/internal/domain/shelf"
+	"library-service/pkg/store"
+)
+
+type ShelfRepository struct {
+	db map[string]shelf.Entity
+	sync.RWMutex
+}
+
+func NewShelfRepository() *ShelfRepository {
+	return &ShelfRepository{
+		db: make(map[string]shelf.Entity),
+	}
+}
+
+func (r *ShelfRepository) List(ctx context.Context, memberID string) (dest []shelf.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]shelf.Entity, 0)
+	for _, data := range r.db {
+		if data.MemberID == memberID {
+			dest = append(dest, data)
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.Before(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *ShelfRepository) Add(ctx context.Context, data shelf.Entity) (dest string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.named(data.MemberID, *data.Name, "") {
+		return "", shelf.ErrorExists
+	}
+
+	id := r.generateID()
+	data.ID = id
+	data.Books = append([]string{}, data.Books...)
+	data.CreatedAt = time.Now()
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *ShelfRepository) Get(ctx context.Context, id string) (dest shelf.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *ShelfRepository) GetByToken(ctx context.Context, token string) (dest shelf.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	for _, data := range r.db {
+		if data.ShareToken != nil && *data.ShareToken == token {
+			return data, nil
+		}
+	}
+
+	return dest, store.ErrorNotFound
+}
+
+func (r *ShelfRepository) Update(ctx context.Context, id string, data shelf.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		return store.ErrorNotFound
+	}
+
+	if data.Name != nil {
+		if r.named(dest.MemberID, *data.Name, id) {
+			return shelf.ErrorExists
+		}
+		dest.Name = data.Name
+	}
+
+	if data.Books != nil {
+		dest.Books = append([]string{}, data.Books...)
+	}
+
+	if data.ShareToken != nil {
+		dest.ShareToken = data.ShareToken
+		if *data.ShareToken == "" {
+			dest.ShareToken = nil
+		}
+	}
+	r.db[id] = dest
+
+	return
+}
+
+func (r *ShelfRepository) Delete(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.db[id]; !ok {
+		return store.ErrorNotFound
+	}
+	delete(r.db, id)
+
+	return
+}
+
+// named tells whether another shelf of the member than the one with the id has the name
+func (r *ShelfRepository) named(memberID, name, id string) bool {
+	for _, data := range r.db {
+		if data.ID != id && data.MemberID == memberID && *data.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *ShelfRepository) generateID() string {
+	return uuid.New().String()
+}
@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"library-service/internal/domain/suggestion"
+	"library-service/pkg/store"
+)
+
+type SuggestionRepository struct {
+	db map[string]suggestion.Entity
+	sync.RWMutex
+}
+
+func NewSuggestionRepository() *SuggestionRepository {
+	return &SuggestionRepository{
+		db: make(map[string]suggestion.Entity),
+	}
+}
+
+func (r *SuggestionRepository) List(ctx context.Context, status suggestion.Status) (dest []suggestion.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]suggestion.Entity, 0, len(r.db))
+	for _, data := range r.db {
+		if status == "" || *data.Status == status {
+			dest = append(dest, data)
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		if len(dest[i].Voters) != len(dest[j].Voters) {
+			return len(dest[i].Voters) > len(dest[j].Voters)
+		}
+		return dest[i].CreatedAt.Before(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *SuggestionRepository) Add(ctx context.Context, data suggestion.Entity) (dest string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	id := r.generateID()
+	data.ID = id
+	data.CreatedAt = time.Now()
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *SuggestionRepository) Get(ctx context.Context, id string) (dest suggestion.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *SuggestionRepository) Update(ctx context.Context, id string, data suggestion.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		return store.ErrorNotFound
+	}
+
+	if data.Status != nil {
+		dest.Status = data.Status
+	}
+
+	if data.Reason != nil {
+		dest.Reason = data.Reason
+	}
+
+	if data.BookID != nil {
+		dest.BookID = data.BookID
+	}
+	r.db[id] = dest
+
+	return
+}
+
+func (r *SuggestionRepository) Vote(ctx context.Context, id, memberID string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		return store.ErrorNotFound
+	}
+
+	for _, voter := range dest.Voters {
+		if voter == memberID {
+			return suggestion.ErrorVoted
+		}
+	}
+	dest.Voters = append(append([]string{}, dest.Voters...), memberID)
+	r.db[id] = dest
+
+	return
+}
+
+func (r *SuggestionRepository) generateID() string {
+	return uuid.New().String()
+}
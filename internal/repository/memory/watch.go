@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"library-service/internal/domain/watch"
+	"library-service/pkg/store"
+)
+
+type WatchRepository struct {
+	db map[string]watch.Entity
+	sync.RWMutex
+}
+
+func NewWatchRepository() *WatchRepository {
+	return &WatchRepository{
+		db: make(map[string]watch.Entity),
+	}
+}
+
+func (r *WatchRepository) List(ctx context.Context, bookID string) (dest []watch.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]watch.Entity, 0)
+	for _, data := range r.db {
+		if data.BookID == bookID {
+			dest = append(dest, data)
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.Before(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *WatchRepository) Add(ctx context.Context, data watch.Entity) (dest string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, object := range r.db {
+		if object.BookID == data.BookID && object.MemberID == data.MemberID {
+			return "", watch.ErrorWatched
+		}
+	}
+
+	id := r.generateID()
+	data.ID = id
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *WatchRepository) Delete(ctx context.Context, bookID, memberID string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for id, data := range r.db {
+		if data.BookID == bookID && data.MemberID == memberID {
+			delete(r.db, id)
+			return
+		}
+	}
+
+	return store.ErrorNotFound
+}
+
+func (r *WatchRepository) generateID() string {
+	return uuid.New().String()
+}
@@ -72,6 +72,8 @@ func (r *AuthorRepository) Update(ctx context.Context, id string, data author.En
 }
 
 func (r *AuthorRepository) prepareArgs(data author.Entity) (args bson.M) {
+	args = bson.M{}
+
 	if data.FullName != nil {
 		args["full_name"] = data.FullName
 	}
@@ -84,6 +86,30 @@ func (r *AuthorRepository) prepareArgs(data author.Entity) (args bson.M) {
 		args["specialty"] = data.Specialty
 	}
 
+	if data.ORCID != nil {
+		args["orcid"] = data.ORCID
+	}
+
+	if data.VIAF != nil {
+		args["viaf"] = data.VIAF
+	}
+
+	if data.Wikipedia != nil {
+		args["wikipedia"] = data.Wikipedia
+	}
+
+	if data.Biography != nil {
+		args["biography"] = data.Biography
+	}
+
+	if data.Photo != nil {
+		args["photo_url"] = data.Photo
+	}
+
+	if data.Edited != nil {
+		args["edited"] = data.Edited
+	}
+
 	return
 }
 
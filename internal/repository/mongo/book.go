@@ -3,10 +3,13 @@ package mongo
 import (
 	"context"
 	"errors"
+	"regexp"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"library-service/internal/domain/book"
 	"library-service/pkg/store"
@@ -14,16 +17,70 @@ import (
 
 type BookRepository struct {
 	db *mongo.Collection
+
+	// authors are searched by their names along with the books
+	authors *mongo.Collection
 }
 
 func NewBookRepository(db *mongo.Database) *BookRepository {
 	return &BookRepository{
-		db: db.Collection("books"),
+		db:      db.Collection("books"),
+		authors: db.Collection("authors"),
 	}
 }
 
 func (r *BookRepository) List(ctx context.Context) (dest []book.Entity, err error) {
-	cur, err := r.db.Find(ctx, bson.M{})
+	return r.find(ctx, bson.M{"deleted_at": nil})
+}
+
+func (r *BookRepository) ListByIDs(ctx context.Context, ids []string) (dest []book.Entity, err error) {
+	return r.find(ctx, bson.M{"_id": bson.M{"$in": ids}, "deleted_at": nil})
+}
+
+func (r *BookRepository) ListWithDeleted(ctx context.Context) (dest []book.Entity, err error) {
+	return r.find(ctx, bson.M{})
+}
+
+func (r *BookRepository) find(ctx context.Context, filter bson.M) (dest []book.Entity, err error) {
+	cur, err := r.db.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &dest); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (r *BookRepository) Filter(ctx context.Context, filter book.Filter, limit, offset int) (dest []book.Entity, err error) {
+	args := bson.M{"deleted_at": nil}
+	sort := bson.D{{Key: "_id", Value: 1}}
+
+	if len(filter.Categories) > 0 {
+		args["categories"] = bson.M{"$in": filter.Categories}
+	}
+
+	if len(filter.Tags) > 0 {
+		args["tags"] = bson.M{"$all": filter.Tags}
+	}
+
+	if filter.Series != "" {
+		args["series_id"] = filter.Series
+		sort = bson.D{{Key: "volume", Value: 1}, {Key: "_id", Value: 1}}
+	}
+
+	if isbns := filter.ISBNs(); isbns != nil {
+		args["isbn"] = bson.M{"$in": isbns}
+	}
+
+	opts := options.Find().
+		SetSort(sort).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cur, err := r.db.Find(ctx, args, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +92,36 @@ func (r *BookRepository) List(ctx context.Context) (dest []book.Entity, err erro
 	return
 }
 
+func (r *BookRepository) Each(ctx context.Context, fn func(data book.Entity) error) (err error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+
+	cur, err := r.db.Find(ctx, bson.M{"deleted_at": nil}, opts)
+	if err != nil {
+		return
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var data book.Entity
+		if err = cur.Decode(&data); err != nil {
+			return
+		}
+
+		if err = fn(data); err != nil {
+			return
+		}
+	}
+
+	return cur.Err()
+}
+
 func (r *BookRepository) Add(ctx context.Context, data book.Entity) (id string, err error) {
+	if data.SeriesID != nil && *data.SeriesID == "" {
+		data.SeriesID, data.Volume = nil, nil
+	}
+	now := time.Now()
+	data.CreatedAt = &now
+
 	res, err := r.db.InsertOne(ctx, data)
 	if err != nil {
 		return "", err
@@ -45,7 +131,7 @@ func (r *BookRepository) Add(ctx context.Context, data book.Entity) (id string,
 }
 
 func (r *BookRepository) Get(ctx context.Context, id string) (dest book.Entity, err error) {
-	if err = r.db.FindOne(ctx, bson.M{"_id": id}).Decode(&dest); err != nil {
+	if err = r.db.FindOne(ctx, bson.M{"_id": id, "deleted_at": nil}).Decode(&dest); err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			err = store.ErrorNotFound
 		}
@@ -58,7 +144,7 @@ func (r *BookRepository) Update(ctx context.Context, id string, data book.Entity
 	args := r.prepareArgs(data)
 	if len(args) > 0 {
 
-		out, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": args})
+		out, err := r.db.UpdateOne(ctx, bson.M{"_id": id, "deleted_at": nil}, bson.M{"$set": args})
 		if err != nil {
 			return err
 		}
@@ -72,6 +158,8 @@ func (r *BookRepository) Update(ctx context.Context, id string, data book.Entity
 }
 
 func (r *BookRepository) prepareArgs(data book.Entity) (args bson.M) {
+	args = bson.M{}
+
 	if data.Name != nil {
 		args["name"] = data.Name
 	}
@@ -84,20 +172,122 @@ func (r *BookRepository) prepareArgs(data book.Entity) (args bson.M) {
 		args["isbn"] = data.ISBN
 	}
 
-	if len(data.Authors) > 0 {
+	if data.Authors != nil {
 		args["authors"] = data.Authors
 	}
 
+	if data.Categories != nil {
+		args["categories"] = data.Categories
+	}
+
+	if data.Tags != nil {
+		args["tags"] = data.Tags
+	}
+
+	if data.SeriesID != nil {
+		args["series_id"], args["volume"] = data.SeriesID, data.Volume
+		if *data.SeriesID == "" {
+			args["series_id"], args["volume"] = nil, nil
+		}
+	}
+
+	if data.Year != nil {
+		args["year"] = data.Year
+	}
+
+	if data.Cover != nil {
+		args["cover_url"] = data.Cover
+	}
+
+	if data.Description != nil {
+		args["description"] = data.Description
+	}
+
+	if data.CoverKey != nil {
+		args["cover_key"] = data.CoverKey
+	}
+
+	if data.Rating != nil {
+		args["rating"] = data.Rating
+	}
+
+	if data.ReviewCount != nil {
+		args["review_count"] = data.ReviewCount
+	}
+
+	return
+}
+
+// Search matches every term as a word prefix of the fields of the search_vector of postgres: the name,
+// the names of the authors, the genre and the description. Mongo text indexes don't support prefixes,
+// so the books are ordered by name instead of relevance.
+func (r *BookRepository) Search(ctx context.Context, query string, limit, offset int) (dest []book.Entity, err error) {
+	terms := book.SearchTerms(query)
+	if len(terms) == 0 {
+		return
+	}
+
+	filter := bson.A{}
+	for _, term := range terms {
+		pattern := searchPattern(term)
+
+		authors, err := r.authors.Distinct(ctx, "_id", bson.M{"$or": bson.A{
+			bson.M{"full_name": pattern},
+			bson.M{"pseudonym": pattern},
+		}})
+		if err != nil {
+			return nil, err
+		}
+
+		filter = append(filter, bson.M{"$or": bson.A{
+			bson.M{"name": pattern},
+			bson.M{"authors": bson.M{"$in": authors}},
+			bson.M{"genre": pattern},
+			bson.M{"description": pattern},
+		}})
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "name", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	filter = append(filter, bson.M{"deleted_at": nil})
+
+	cur, err := r.db.Find(ctx, bson.M{"$and": filter}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &dest); err != nil {
+		return nil, err
+	}
+
 	return
 }
 
+// searchPattern matches the term at the start of a word, the words are split on what is neither a letter
+// nor a digit like book.SearchTerms does, \W alone would split the words of non-latin titles
+func searchPattern(term string) primitive.Regex {
+	return primitive.Regex{Pattern: `(^|[^\p{L}\p{N}])` + regexp.QuoteMeta(term), Options: "i"}
+}
+
+// Delete only marks the book as deleted, its copies and reviews are kept for Restore
 func (r *BookRepository) Delete(ctx context.Context, id string) (err error) {
-	out, err := r.db.DeleteOne(ctx, bson.M{"_id": id})
+	return r.setDeletedAt(ctx, bson.M{"_id": id, "deleted_at": nil}, time.Now())
+}
+
+func (r *BookRepository) Restore(ctx context.Context, id string) (err error) {
+	return r.setDeletedAt(ctx, bson.M{"_id": id, "deleted_at": bson.M{"$ne": nil}}, nil)
+}
+
+func (r *BookRepository) setDeletedAt(ctx context.Context, filter bson.M, value any) (err error) {
+	out, err := r.db.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"deleted_at": value}})
 	if err != nil {
 		return err
 	}
 
-	if out.DeletedCount == 0 {
+	if out.MatchedCount == 0 {
 		return store.ErrorNotFound
 	}
 
@@ -0,0 +1,113 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"library-service/internal/domain/category"
+	"library-service/pkg/store"
+)
+
+type CategoryRepository struct {
+	db *mongo.Collection
+}
+
+func NewCategoryRepository(db *mongo.Database) *CategoryRepository {
+	return &CategoryRepository{
+		db: db.Collection("categories"),
+	}
+}
+
+func (r *CategoryRepository) List(ctx context.Context) (dest []category.Entity, err error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cur, err := r.db.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &dest); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (r *CategoryRepository) Add(ctx context.Context, data category.Entity) (id string, err error) {
+	if data.ParentID != nil && *data.ParentID == "" {
+		data.ParentID = nil
+	}
+
+	res, err := r.db.InsertOne(ctx, data)
+	if err != nil {
+		return "", err
+	}
+
+	return res.InsertedID.(primitive.ObjectID).String(), nil
+}
+
+func (r *CategoryRepository) Get(ctx context.Context, id string) (dest category.Entity, err error) {
+	if err = r.db.FindOne(ctx, bson.M{"_id": id}).Decode(&dest); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *CategoryRepository) Update(ctx context.Context, id string, data category.Entity) (err error) {
+	args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		out, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": args})
+		if err != nil {
+			return err
+		}
+
+		if out.MatchedCount == 0 {
+			return store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *CategoryRepository) prepareArgs(data category.Entity) (args bson.M) {
+	args = bson.M{}
+
+	if data.Name != nil {
+		args["name"] = data.Name
+	}
+
+	if data.Slug != nil {
+		args["slug"] = data.Slug
+	}
+
+	if data.ParentID != nil {
+		args["parent_id"] = data.ParentID
+		if *data.ParentID == "" {
+			args["parent_id"] = nil
+		}
+	}
+
+	return
+}
+
+func (r *CategoryRepository) Delete(ctx context.Context, id string) (err error) {
+	out, err := r.db.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if out.DeletedCount == 0 {
+		return store.ErrorNotFound
+	}
+
+	return
+}
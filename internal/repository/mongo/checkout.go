@@ -0,0 +1,60 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"library-service/internal/domain/book"
+)
+
+type CheckoutRepository struct {
+	db *mongo.Collection
+}
+
+func NewCheckoutRepository(db *mongo.Database) *CheckoutRepository {
+	return &CheckoutRepository{
+		db: db.Collection("book_checkouts"),
+	}
+}
+
+func (r *CheckoutRepository) Add(ctx context.Context, data book.Checkout) (id string, err error) {
+	data.CreatedAt = time.Now()
+
+	res, err := r.db.InsertOne(ctx, data)
+	if err != nil {
+		return "", err
+	}
+
+	return res.InsertedID.(primitive.ObjectID).String(), nil
+}
+
+func (r *CheckoutRepository) Count(ctx context.Context, since time.Time) (dest map[string]int, err error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"created_at": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$book_id", "checkouts": bson.M{"$sum": 1}}}},
+	}
+
+	cur, err := r.db.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		BookID    string `bson:"_id"`
+		Checkouts int    `bson:"checkouts"`
+	}
+	if err = cur.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	dest = make(map[string]int, len(rows))
+	for _, row := range rows {
+		dest[row.BookID] = row.Checkouts
+	}
+
+	return
+}
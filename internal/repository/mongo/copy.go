@@ -0,0 +1,133 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/store"
+)
+
+type CopyRepository struct {
+	db *mongo.Collection
+}
+
+func NewCopyRepository(db *mongo.Database) *CopyRepository {
+	return &CopyRepository{
+		db: db.Collection("book_copies"),
+	}
+}
+
+func (r *CopyRepository) List(ctx context.Context, bookID string) (dest []book.Copy, err error) {
+	opts := options.Find().SetSort(bson.D{{Key: "barcode", Value: 1}})
+
+	cur, err := r.db.Find(ctx, bson.M{"book_id": bookID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &dest); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (r *CopyRepository) ListByBooks(ctx context.Context, bookIDs []string) (dest []book.Copy, err error) {
+	cur, err := r.db.Find(ctx, bson.M{"book_id": bson.M{"$in": bookIDs}})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &dest); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (r *CopyRepository) Add(ctx context.Context, data book.Copy) (id string, err error) {
+	res, err := r.db.InsertOne(ctx, data)
+	if err != nil {
+		return "", err
+	}
+
+	return res.InsertedID.(primitive.ObjectID).String(), nil
+}
+
+func (r *CopyRepository) Get(ctx context.Context, id string) (dest book.Copy, err error) {
+	if err = r.db.FindOne(ctx, bson.M{"_id": id}).Decode(&dest); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *CopyRepository) GetByBarcode(ctx context.Context, barcode string) (dest book.Copy, err error) {
+	if err = r.db.FindOne(ctx, bson.M{"barcode": barcode}).Decode(&dest); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *CopyRepository) Update(ctx context.Context, id string, data book.Copy) (err error) {
+	args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		out, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": args})
+		if err != nil {
+			return err
+		}
+
+		if out.MatchedCount == 0 {
+			return store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *CopyRepository) prepareArgs(data book.Copy) (args bson.M) {
+	args = bson.M{}
+
+	if data.Barcode != nil {
+		args["barcode"] = data.Barcode
+	}
+
+	if data.Condition != nil {
+		args["condition"] = data.Condition
+	}
+
+	if data.Location != nil {
+		args["location"] = data.Location
+	}
+
+	if data.Status != nil {
+		args["status"] = data.Status
+	}
+
+	return
+}
+
+func (r *CopyRepository) Delete(ctx context.Context, id string) (err error) {
+	out, err := r.db.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if out.DeletedCount == 0 {
+		return store.ErrorNotFound
+	}
+
+	return
+}
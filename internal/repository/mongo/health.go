@@ -0,0 +1,160 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"library-service/internal/domain/health"
+	"library-service/pkg/store"
+)
+
+type IncidentRepository struct {
+	db *mongo.Collection
+}
+
+func NewIncidentRepository(db *mongo.Database) *IncidentRepository {
+	return &IncidentRepository{
+		db: db.Collection("incidents"),
+	}
+}
+
+func (r *IncidentRepository) List(ctx context.Context, current bool) (dest []health.Incident, err error) {
+	filter := bson.M{}
+	if current {
+		filter["state"] = bson.M{"$ne": health.StateResolved}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cur, err := r.db.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &dest); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (r *IncidentRepository) Add(ctx context.Context, data health.Incident) (id string, err error) {
+	data.CreatedAt = time.Now()
+
+	res, err := r.db.InsertOne(ctx, data)
+	if err != nil {
+		return "", err
+	}
+
+	return res.InsertedID.(primitive.ObjectID).String(), nil
+}
+
+func (r *IncidentRepository) Get(ctx context.Context, id string) (dest health.Incident, err error) {
+	if err = r.db.FindOne(ctx, bson.M{"_id": id}).Decode(&dest); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *IncidentRepository) Update(ctx context.Context, id string, data health.Incident) (err error) {
+	args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		out, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": args})
+		if err != nil {
+			return err
+		}
+
+		if out.MatchedCount == 0 {
+			return store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *IncidentRepository) Delete(ctx context.Context, id string) (err error) {
+	out, err := r.db.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if out.DeletedCount == 0 {
+		return store.ErrorNotFound
+	}
+
+	return
+}
+
+func (r *IncidentRepository) prepareArgs(data health.Incident) (args bson.M) {
+	args = bson.M{}
+
+	if data.Title != nil {
+		args["title"] = data.Title
+	}
+
+	if data.Description != nil {
+		args["description"] = data.Description
+	}
+
+	if data.Impact != nil {
+		args["impact"] = data.Impact
+	}
+
+	if data.State != nil {
+		args["state"] = data.State
+	}
+
+	if len(data.Components) > 0 {
+		args["components"] = data.Components
+	}
+
+	if data.ResolvedAt != nil {
+		args["resolved_at"] = data.ResolvedAt
+	}
+
+	return
+}
+
+type CheckRepository struct {
+	db *mongo.Collection
+}
+
+func NewCheckRepository(db *mongo.Database) *CheckRepository {
+	return &CheckRepository{
+		db: db.Collection("health_checks"),
+	}
+}
+
+func (r *CheckRepository) Record(ctx context.Context, component string, day time.Time, passed bool) (err error) {
+	inc := bson.M{"total": 1, "passed": 0}
+	if passed {
+		inc["passed"] = 1
+	}
+
+	opts := options.Update().SetUpsert(true)
+	_, err = r.db.UpdateOne(ctx, bson.M{"component": component, "day": day}, bson.M{"$inc": inc}, opts)
+
+	return
+}
+
+func (r *CheckRepository) List(ctx context.Context, since time.Time) (dest []health.Day, err error) {
+	cur, err := r.db.Find(ctx, bson.M{"day": bson.M{"$gte": since}})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &dest); err != nil {
+		return nil, err
+	}
+
+	return
+}
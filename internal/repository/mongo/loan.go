@@ -0,0 +1,135 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"library-service/internal/domain/loan"
+	"library-service/pkg/store"
+)
+
+type LoanRepository struct {
+	client *mongo.Client
+
+	db      *mongo.Collection
+	members *mongo.Collection
+}
+
+func NewLoanRepository(db *mongo.Database) *LoanRepository {
+	return &LoanRepository{
+		client:  db.Client(),
+		db:      db.Collection("member_loans"),
+		members: db.Collection("members"),
+	}
+}
+
+func (r *LoanRepository) List(ctx context.Context, memberID string) (dest []loan.Entity, err error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cur, err := r.db.Find(ctx, bson.M{"member_id": memberID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &dest); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// Add runs in a transaction, that takes a replica set or a sharded cluster. The member is written first so that
+// their parallel checkouts conflict and are retried one after the other, the loan is inserted only while the
+// member has fewer than limit books on loan and loan.ErrorLimit is returned otherwise. A violated unique index
+// over the copy_id of the loans without a returned_at is reported as loan.ErrorUnavailable.
+func (r *LoanRepository) Add(ctx context.Context, data loan.Entity, limit int) (id string, err error) {
+	session, err := r.client.StartSession()
+	if err != nil {
+		return
+	}
+	defer session.EndSession(ctx)
+
+	res, err := session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return r.add(sc, data, limit)
+	})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			err = loan.ErrorUnavailable
+		}
+		return "", err
+	}
+
+	return res.(string), nil
+}
+
+func (r *LoanRepository) add(ctx context.Context, data loan.Entity, limit int) (id string, err error) {
+	if _, err = r.members.UpdateOne(ctx, bson.M{"_id": data.MemberID}, bson.M{"$currentDate": bson.M{"loaned_at": true}}); err != nil {
+		return
+	}
+
+	count, err := r.db.CountDocuments(ctx, bson.M{"member_id": data.MemberID, "returned_at": nil})
+	if err != nil {
+		return
+	}
+
+	if count >= int64(limit) {
+		return "", loan.ErrorLimit
+	}
+
+	data.CreatedAt = time.Now()
+
+	res, err := r.db.InsertOne(ctx, data)
+	if err != nil {
+		return
+	}
+
+	return res.InsertedID.(primitive.ObjectID).String(), nil
+}
+
+func (r *LoanRepository) Get(ctx context.Context, id string) (dest loan.Entity, err error) {
+	if err = r.db.FindOne(ctx, bson.M{"_id": id}).Decode(&dest); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *LoanRepository) Update(ctx context.Context, id string, data loan.Entity) (err error) {
+	sets := bson.M{
+		"due_at":      data.DueAt,
+		"renewals":    data.Renewals,
+		"returned_at": data.ReturnedAt,
+	}
+
+	out, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": sets})
+	if err != nil {
+		return err
+	}
+
+	if out.MatchedCount == 0 {
+		return store.ErrorNotFound
+	}
+
+	return
+}
+
+func (r *LoanRepository) Delete(ctx context.Context, id string) (err error) {
+	out, err := r.db.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if out.DeletedCount == 0 {
+		return store.ErrorNotFound
+	}
+
+	return
+}
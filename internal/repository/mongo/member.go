@@ -72,14 +72,20 @@ func (r *MemberRepository) Update(ctx context.Context, id string, data member.En
 }
 
 func (r *MemberRepository) prepareArgs(data member.Entity) (args bson.M) {
+	args = bson.M{}
+
 	if data.FullName != nil {
 		args["full_name"] = data.FullName
 	}
 
-	if len(data.Books) > 0 {
+	if data.Books != nil {
 		args["books"] = data.Books
 	}
 
+	if data.Tier != nil {
+		args["tier"] = data.Tier
+	}
+
 	return
 }
 
@@ -0,0 +1,113 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"library-service/pkg/store"
+)
+
+type MergeRepository struct {
+	client *mongo.Client
+
+	books     *mongo.Collection
+	copies    *mongo.Collection
+	checkouts *mongo.Collection
+	loans     *mongo.Collection
+	reviews   *mongo.Collection
+	watches   *mongo.Collection
+	members   *mongo.Collection
+}
+
+func NewMergeRepository(db *mongo.Database) *MergeRepository {
+	return &MergeRepository{
+		client:    db.Client(),
+		books:     db.Collection("books"),
+		copies:    db.Collection("book_copies"),
+		checkouts: db.Collection("book_checkouts"),
+		loans:     db.Collection("member_loans"),
+		reviews:   db.Collection("book_reviews"),
+		watches:   db.Collection("book_watches"),
+		members:   db.Collection("members"),
+	}
+}
+
+// Merge runs in a transaction, that takes a replica set or a sharded cluster
+func (r *MergeRepository) Merge(ctx context.Context, id, duplicateID string) (err error) {
+	session, err := r.client.StartSession()
+	if err != nil {
+		return
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, r.merge(sc, id, duplicateID)
+	})
+
+	return
+}
+
+func (r *MergeRepository) merge(ctx context.Context, id, duplicateID string) (err error) {
+	count, err := r.books.CountDocuments(ctx, bson.M{"_id": bson.M{"$in": []string{id, duplicateID}}, "deleted_at": nil})
+	if err != nil {
+		return
+	}
+
+	if count < 2 {
+		return store.ErrorNotFound
+	}
+
+	if _, err = r.copies.UpdateMany(ctx, bson.M{"book_id": duplicateID}, bson.M{"$set": bson.M{"book_id": id}}); err != nil {
+		return
+	}
+
+	if _, err = r.checkouts.UpdateMany(ctx, bson.M{"book_id": duplicateID}, bson.M{"$set": bson.M{"book_id": id}}); err != nil {
+		return
+	}
+
+	if _, err = r.loans.UpdateMany(ctx, bson.M{"book_id": duplicateID}, bson.M{"$set": bson.M{"book_id": id}}); err != nil {
+		return
+	}
+
+	members, err := r.reviews.Distinct(ctx, "member_id", bson.M{"book_id": id})
+	if err != nil {
+		return
+	}
+
+	if _, err = r.reviews.DeleteMany(ctx, bson.M{"book_id": duplicateID, "member_id": bson.M{"$in": members}}); err != nil {
+		return
+	}
+
+	if _, err = r.reviews.UpdateMany(ctx, bson.M{"book_id": duplicateID}, bson.M{"$set": bson.M{"book_id": id}}); err != nil {
+		return
+	}
+
+	watchers, err := r.watches.Distinct(ctx, "member_id", bson.M{"book_id": id})
+	if err != nil {
+		return
+	}
+
+	if _, err = r.watches.DeleteMany(ctx, bson.M{"book_id": duplicateID, "member_id": bson.M{"$in": watchers}}); err != nil {
+		return
+	}
+
+	if _, err = r.watches.UpdateMany(ctx, bson.M{"book_id": duplicateID}, bson.M{"$set": bson.M{"book_id": id}}); err != nil {
+		return
+	}
+
+	// a field can't be added to and pulled from in the same update
+	if _, err = r.members.UpdateMany(ctx, bson.M{"books": duplicateID}, bson.M{"$addToSet": bson.M{"books": id}}); err != nil {
+		return
+	}
+
+	if _, err = r.members.UpdateMany(ctx, bson.M{"books": duplicateID}, bson.M{"$pull": bson.M{"books": duplicateID}}); err != nil {
+		return
+	}
+
+	_, err = r.books.UpdateOne(ctx, bson.M{"_id": duplicateID}, bson.M{"$set": bson.M{"deleted_at": time.Now()}})
+
+	return
+}
@@ -0,0 +1,67 @@
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"library-service/internal/domain/book"
+)
+
+type RelationRepository struct {
+	client *mongo.Client
+	db     *mongo.Collection
+}
+
+func NewRelationRepository(db *mongo.Database) *RelationRepository {
+	return &RelationRepository{
+		client: db.Client(),
+		db:     db.Collection("book_relations"),
+	}
+}
+
+func (r *RelationRepository) List(ctx context.Context, bookID string) (dest []book.Relation, err error) {
+	opts := options.Find().SetSort(bson.D{{Key: "borrowers", Value: -1}, {Key: "related_id", Value: 1}})
+
+	cur, err := r.db.Find(ctx, bson.M{"book_id": bookID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &dest); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// Replace runs in a transaction, that takes a replica set or a sharded cluster
+func (r *RelationRepository) Replace(ctx context.Context, data []book.Relation) (err error) {
+	session, err := r.client.StartSession()
+	if err != nil {
+		return
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		if _, err := r.db.DeleteMany(sc, bson.M{}); err != nil {
+			return nil, err
+		}
+
+		if len(data) == 0 {
+			return nil, nil
+		}
+
+		documents := make([]interface{}, len(data))
+		for i, object := range data {
+			documents[i] = object
+		}
+
+		_, err := r.db.InsertMany(sc, documents)
+		return nil, err
+	})
+
+	return
+}
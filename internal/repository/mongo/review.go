@@ -0,0 +1,108 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"library-service/internal/domain/review"
+	"library-service/pkg/store"
+)
+
+type ReviewRepository struct {
+	db *mongo.Collection
+}
+
+func NewReviewRepository(db *mongo.Database) *ReviewRepository {
+	return &ReviewRepository{
+		db: db.Collection("book_reviews"),
+	}
+}
+
+func (r *ReviewRepository) List(ctx context.Context, bookID string) (dest []review.Entity, err error) {
+	return r.find(ctx, bson.M{"book_id": bookID})
+}
+
+func (r *ReviewRepository) ListByStatus(ctx context.Context, status review.Status) (dest []review.Entity, err error) {
+	return r.find(ctx, bson.M{"status": status})
+}
+
+func (r *ReviewRepository) find(ctx context.Context, filter bson.M) (dest []review.Entity, err error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cur, err := r.db.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &dest); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// Add reports a violated unique index over book_id and member_id as review.ErrorExists
+func (r *ReviewRepository) Add(ctx context.Context, data review.Entity) (id string, err error) {
+	data.CreatedAt = time.Now()
+
+	res, err := r.db.InsertOne(ctx, data)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			err = review.ErrorExists
+		}
+		return "", err
+	}
+
+	return res.InsertedID.(primitive.ObjectID).String(), nil
+}
+
+func (r *ReviewRepository) Get(ctx context.Context, id string) (dest review.Entity, err error) {
+	if err = r.db.FindOne(ctx, bson.M{"_id": id}).Decode(&dest); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *ReviewRepository) Update(ctx context.Context, id string, data review.Entity) (err error) {
+	args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		out, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": args})
+		if err != nil {
+			return err
+		}
+
+		if out.MatchedCount == 0 {
+			return store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *ReviewRepository) prepareArgs(data review.Entity) (args bson.M) {
+	args = bson.M{}
+
+	if data.Rating != nil {
+		args["rating"] = data.Rating
+	}
+
+	if data.Text != nil {
+		args["text"] = data.Text
+	}
+
+	if data.Status != nil {
+		args["status"] = data.Status
+	}
+
+	return
+}
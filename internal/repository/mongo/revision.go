@@ -0,0 +1,61 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/store"
+)
+
+type RevisionRepository struct {
+	db *mongo.Collection
+}
+
+func NewRevisionRepository(db *mongo.Database) *RevisionRepository {
+	return &RevisionRepository{
+		db: db.Collection("book_revisions"),
+	}
+}
+
+func (r *RevisionRepository) List(ctx context.Context, bookID string) (dest []book.Revision, err error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cur, err := r.db.Find(ctx, bson.M{"book_id": bookID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &dest); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (r *RevisionRepository) Add(ctx context.Context, data book.Revision) (id string, err error) {
+	data.CreatedAt = time.Now()
+
+	res, err := r.db.InsertOne(ctx, data)
+	if err != nil {
+		return "", err
+	}
+
+	return res.InsertedID.(primitive.ObjectID).String(), nil
+}
+
+func (r *RevisionRepository) Get(ctx context.Context, id string) (dest book.Revision, err error) {
+	if err = r.db.FindOne(ctx, bson.M{"_id": id}).Decode(&dest); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
@@ -0,0 +1,102 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"library-service/internal/domain/series"
+	"library-service/pkg/store"
+)
+
+type SeriesRepository struct {
+	db *mongo.Collection
+}
+
+func NewSeriesRepository(db *mongo.Database) *SeriesRepository {
+	return &SeriesRepository{
+		db: db.Collection("series"),
+	}
+}
+
+func (r *SeriesRepository) List(ctx context.Context) (dest []series.Entity, err error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cur, err := r.db.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &dest); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (r *SeriesRepository) Add(ctx context.Context, data series.Entity) (id string, err error) {
+	res, err := r.db.InsertOne(ctx, data)
+	if err != nil {
+		return "", err
+	}
+
+	return res.InsertedID.(primitive.ObjectID).String(), nil
+}
+
+func (r *SeriesRepository) Get(ctx context.Context, id string) (dest series.Entity, err error) {
+	if err = r.db.FindOne(ctx, bson.M{"_id": id}).Decode(&dest); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *SeriesRepository) Update(ctx context.Context, id string, data series.Entity) (err error) {
+	args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		out, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": args})
+		if err != nil {
+			return err
+		}
+
+		if out.MatchedCount == 0 {
+			return store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *SeriesRepository) prepareArgs(data series.Entity) (args bson.M) {
+	args = bson.M{}
+
+	if data.Name != nil {
+		args["name"] = data.Name
+	}
+
+	if data.Description != nil {
+		args["description"] = data.Description
+	}
+
+	return
+}
+
+func (r *SeriesRepository) Delete(ctx context.Context, id string) (err error) {
+	out, err := r.db.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if out.DeletedCount == 0 {
+		return store.ErrorNotFound
+	}
+
+	return
+}
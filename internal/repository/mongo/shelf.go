@@ -0,0 +1,138 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"library-service/internal/domain/shelf"
+	"library-service/pkg/store"
+)
+
+type ShelfRepository struct {
+	db *mongo.Collection
+}
+
+func NewShelfRepository(db *mongo.Database) *ShelfRepository {
+	return &ShelfRepository{
+		db: db.Collection("member_shelves"),
+	}
+}
+
+func (r *ShelfRepository) List(ctx context.Context, memberID string) (dest []shelf.Entity, err error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cur, err := r.db.Find(ctx, bson.M{"member_id": memberID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &dest); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// Add reports a violated unique index over member_id and name as shelf.ErrorExists
+func (r *ShelfRepository) Add(ctx context.Context, data shelf.Entity) (id string, err error) {
+	if data.Books == nil {
+		data.Books = []string{}
+	}
+	data.CreatedAt = time.Now()
+
+	res, err := r.db.InsertOne(ctx, data)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			err = shelf.ErrorExists
+		}
+		return "", err
+	}
+
+	return res.InsertedID.(primitive.ObjectID).String(), nil
+}
+
+func (r *ShelfRepository) Get(ctx context.Context, id string) (dest shelf.Entity, err error) {
+	return r.findOne(ctx, bson.M{"_id": id})
+}
+
+func (r *ShelfRepository) GetByToken(ctx context.Context, token string) (dest shelf.Entity, err error) {
+	return r.findOne(ctx, bson.M{"share_token": token})
+}
+
+func (r *ShelfRepository) findOne(ctx context.Context, filter bson.M) (dest shelf.Entity, err error) {
+	if err = r.db.FindOne(ctx, filter).Decode(&dest); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+// Update unsets the share token of a shelf that stops being shared, so that the unique index
+// over the tokens only covers the shared shelves
+func (r *ShelfRepository) Update(ctx context.Context, id string, data shelf.Entity) (err error) {
+	update := r.prepareArgs(data)
+	if len(update) > 0 {
+
+		out, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, update)
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				err = shelf.ErrorExists
+			}
+			return err
+		}
+
+		if out.MatchedCount == 0 {
+			return store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *ShelfRepository) Delete(ctx context.Context, id string) (err error) {
+	out, err := r.db.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if out.DeletedCount == 0 {
+		return store.ErrorNotFound
+	}
+
+	return
+}
+
+func (r *ShelfRepository) prepareArgs(data shelf.Entity) (update bson.M) {
+	update = bson.M{}
+	sets := bson.M{}
+
+	if data.Name != nil {
+		sets["name"] = data.Name
+	}
+
+	if data.Books != nil {
+		sets["books"] = data.Books
+	}
+
+	if data.ShareToken != nil {
+		if *data.ShareToken == "" {
+			update["$unset"] = bson.M{"share_token": ""}
+		} else {
+			sets["share_token"] = data.ShareToken
+		}
+	}
+
+	if len(sets) > 0 {
+		update["$set"] = sets
+	}
+
+	return
+}
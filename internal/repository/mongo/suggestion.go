@@ -0,0 +1,127 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"library-service/internal/domain/suggestion"
+	"library-service/pkg/store"
+)
+
+type SuggestionRepository struct {
+	db *mongo.Collection
+}
+
+func NewSuggestionRepository(db *mongo.Database) *SuggestionRepository {
+	return &SuggestionRepository{
+		db: db.Collection("book_suggestions"),
+	}
+}
+
+func (r *SuggestionRepository) List(ctx context.Context, status suggestion.Status) (dest []suggestion.Entity, err error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$addFields", Value: bson.M{"votes": bson.M{"$size": bson.M{"$ifNull": bson.A{"$voters", bson.A{}}}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "votes", Value: -1}, {Key: "created_at", Value: 1}}}},
+	}
+
+	cur, err := r.db.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &dest); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (r *SuggestionRepository) Add(ctx context.Context, data suggestion.Entity) (id string, err error) {
+	data.CreatedAt = time.Now()
+
+	res, err := r.db.InsertOne(ctx, data)
+	if err != nil {
+		return "", err
+	}
+
+	return res.InsertedID.(primitive.ObjectID).String(), nil
+}
+
+func (r *SuggestionRepository) Get(ctx context.Context, id string) (dest suggestion.Entity, err error) {
+	if err = r.db.FindOne(ctx, bson.M{"_id": id}).Decode(&dest); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *SuggestionRepository) Update(ctx context.Context, id string, data suggestion.Entity) (err error) {
+	args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		out, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": args})
+		if err != nil {
+			return err
+		}
+
+		if out.MatchedCount == 0 {
+			return store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+// Vote only matches the suggestion while the member is not among its voters
+func (r *SuggestionRepository) Vote(ctx context.Context, id, memberID string) (err error) {
+	out, err := r.db.UpdateOne(ctx, bson.M{"_id": id, "voters": bson.M{"$ne": memberID}}, bson.M{"$push": bson.M{"voters": memberID}})
+	if err != nil {
+		return
+	}
+
+	if out.MatchedCount > 0 {
+		return
+	}
+
+	count, err := r.db.CountDocuments(ctx, bson.M{"_id": id})
+	if err != nil {
+		return
+	}
+
+	if count == 0 {
+		return store.ErrorNotFound
+	}
+
+	return suggestion.ErrorVoted
+}
+
+func (r *SuggestionRepository) prepareArgs(data suggestion.Entity) (args bson.M) {
+	args = bson.M{}
+
+	if data.Status != nil {
+		args["status"] = data.Status
+	}
+
+	if data.Reason != nil {
+		args["reason"] = data.Reason
+	}
+
+	if data.BookID != nil {
+		args["book_id"] = data.BookID
+	}
+
+	return
+}
@@ -0,0 +1,64 @@
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"library-service/internal/domain/watch"
+	"library-service/pkg/store"
+)
+
+type WatchRepository struct {
+	db *mongo.Collection
+}
+
+func NewWatchRepository(db *mongo.Database) *WatchRepository {
+	return &WatchRepository{
+		db: db.Collection("book_watches"),
+	}
+}
+
+func (r *WatchRepository) List(ctx context.Context, bookID string) (dest []watch.Entity, err error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cur, err := r.db.Find(ctx, bson.M{"book_id": bookID}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cur.All(ctx, &dest); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// Add reports a violated unique index over book_id and member_id as watch.ErrorWatched
+func (r *WatchRepository) Add(ctx context.Context, data watch.Entity) (id string, err error) {
+	res, err := r.db.InsertOne(ctx, data)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			err = watch.ErrorWatched
+		}
+		return "", err
+	}
+
+	return res.InsertedID.(primitive.ObjectID).String(), nil
+}
+
+func (r *WatchRepository) Delete(ctx context.Context, bookID, memberID string) (err error) {
+	out, err := r.db.DeleteOne(ctx, bson.M{"book_id": bookID, "member_id": memberID})
+	if err != nil {
+		return err
+	}
+
+	if out.DeletedCount == 0 {
+		return store.ErrorNotFound
+	}
+
+	return
+}
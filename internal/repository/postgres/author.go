@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"library-service/internal/domain/author"
 	"library-service/pkg/store"
@@ -25,7 +26,7 @@ func NewAuthorRepository(db *sqlx.DB) *AuthorRepository {
 
 func (r *AuthorRepository) List(ctx context.Context) (dest []author.Entity, err error) {
 	query := `
-		SELECT id, full_name, pseudonym, specialty
+		SELECT id, full_name, pseudonym, specialty, orcid, viaf, wikipedia, biography, photo_url, edited
 		FROM authors
 		ORDER BY id`
 
@@ -36,11 +37,11 @@ func (r *AuthorRepository) List(ctx context.Context) (dest []author.Entity, err
 
 func (r *AuthorRepository) Add(ctx context.Context, data author.Entity) (id string, err error) {
 	query := `
-		INSERT INTO authors (full_name, pseudonym, specialty)
-		VALUES ($1, $2, $3)
+		INSERT INTO authors (full_name, pseudonym, specialty, orcid, viaf, wikipedia, biography, photo_url, edited)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE($9::VARCHAR[], '{}'))
 		RETURNING id`
 
-	args := []any{data.FullName, data.Pseudonym, data.Specialty}
+	args := []any{data.FullName, data.Pseudonym, data.Specialty, data.ORCID, data.VIAF, data.Wikipedia, data.Biography, data.Photo, pq.Array(data.Edited)}
 
 	err = r.db.QueryRowContext(ctx, query, args...).Scan(&id)
 	if err != nil {
@@ -54,7 +55,7 @@ func (r *AuthorRepository) Add(ctx context.Context, data author.Entity) (id stri
 
 func (r *AuthorRepository) Get(ctx context.Context, id string) (dest author.Entity, err error) {
 	query := `
-		SELECT id, full_name, pseudonym, specialty
+		SELECT id, full_name, pseudonym, specialty, orcid, viaf, wikipedia, biography, photo_url, edited
 		FROM authors
 		WHERE id=$1`
 
@@ -75,7 +76,7 @@ func (r *AuthorRepository) Update(ctx context.Context, id string, data author.En
 
 		args = append(args, id)
 		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
-		query := fmt.Sprintf("UPDATE authors SET %r WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+		query := fmt.Sprintf("UPDATE authors SET %s WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
 
 		if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
@@ -103,6 +104,36 @@ func (r *AuthorRepository) prepareArgs(data author.Entity) (sets []string, args
 		sets = append(sets, fmt.Sprintf("specialty=$%d", len(args)))
 	}
 
+	if data.ORCID != nil {
+		args = append(args, data.ORCID)
+		sets = append(sets, fmt.Sprintf("orcid=$%d", len(args)))
+	}
+
+	if data.VIAF != nil {
+		args = append(args, data.VIAF)
+		sets = append(sets, fmt.Sprintf("viaf=$%d", len(args)))
+	}
+
+	if data.Wikipedia != nil {
+		args = append(args, data.Wikipedia)
+		sets = append(sets, fmt.Sprintf("wikipedia=$%d", len(args)))
+	}
+
+	if data.Biography != nil {
+		args = append(args, data.Biography)
+		sets = append(sets, fmt.Sprintf("biography=$%d", len(args)))
+	}
+
+	if data.Photo != nil {
+		args = append(args, data.Photo)
+		sets = append(sets, fmt.Sprintf("photo_url=$%d", len(args)))
+	}
+
+	if data.Edited != nil {
+		args = append(args, pq.Array(data.Edited))
+		sets = append(sets, fmt.Sprintf("edited=$%d", len(args)))
+	}
+
 	return
 }
 
@@ -26,8 +26,9 @@ func NewBookRepository(db *sqlx.DB) *BookRepository {
 
 func (r *BookRepository) List(ctx context.Context) (dest []book.Entity, err error) {
 	query := `
-		SELECT id, name, genre, isbn, authors
+		SELECT id, name, genre, isbn, authors, categories, tags, year, cover_url, description, cover_key, rating, review_count, series_id, volume, deleted_at, created_at
 		FROM books
+		WHERE deleted_at IS NULL
 		ORDER BY id`
 
 	err = r.db.SelectContext(ctx, &dest, query)
@@ -35,13 +36,95 @@ func (r *BookRepository) List(ctx context.Context) (dest []book.Entity, err erro
 	return
 }
 
+func (r *BookRepository) ListByIDs(ctx context.Context, ids []string) (dest []book.Entity, err error) {
+	query := `
+		SELECT id, name, genre, isbn, authors, categories, tags, year, cover_url, description, cover_key, rating, review_count, series_id, volume, deleted_at, created_at
+		FROM books
+		WHERE id=ANY($1::UUID[]) AND deleted_at IS NULL`
+
+	args := []any{pq.Array(ids)}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+// Filter matches the categories with && and the tags with @>, both use the GIN indexes of migration 00009
+func (r *BookRepository) Filter(ctx context.Context, filter book.Filter, limit, offset int) (dest []book.Entity, err error) {
+	where, args := []string{"deleted_at IS NULL"}, []any{}
+
+	if len(filter.Categories) > 0 {
+		args = append(args, pq.Array(filter.Categories))
+		where = append(where, fmt.Sprintf("categories && $%d::UUID[]", len(args)))
+	}
+
+	if len(filter.Tags) > 0 {
+		args = append(args, pq.Array(filter.Tags))
+		where = append(where, fmt.Sprintf("tags @> $%d::VARCHAR[]", len(args)))
+	}
+
+	if filter.Series != "" {
+		args = append(args, filter.Series)
+		where = append(where, fmt.Sprintf("series_id=$%d", len(args)))
+	}
+
+	if isbns := filter.ISBNs(); isbns != nil {
+		args = append(args, pq.Array(isbns))
+		where = append(where, fmt.Sprintf("isbn=ANY($%d)", len(args)))
+	}
+
+	order := "id"
+	if filter.Series != "" {
+		order = "COALESCE(volume, 0), id"
+	}
+
+	args = append(args, limit, offset)
+	query := fmt.Sprintf(`
+		SELECT id, name, genre, isbn, authors, categories, tags, year, cover_url, description, cover_key, rating, review_count, series_id, volume, deleted_at, created_at
+		FROM books
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d`, strings.Join(where, " AND "), order, len(args)-1, len(args))
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *BookRepository) Each(ctx context.Context, fn func(data book.Entity) error) (err error) {
+	query := `
+		SELECT id, name, genre, isbn, authors, categories, tags, year, cover_url, description, cover_key, rating, review_count, series_id, volume, deleted_at, created_at
+		FROM books
+		WHERE deleted_at IS NULL
+		ORDER BY id`
+
+	rows, err := r.db.QueryxContext(ctx, query)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var data book.Entity
+		if err = rows.StructScan(&data); err != nil {
+			return
+		}
+
+		if err = fn(data); err != nil {
+			return
+		}
+	}
+
+	return rows.Err()
+}
+
 func (r *BookRepository) Add(ctx context.Context, data book.Entity) (id string, err error) {
 	query := `
-		INSERT INTO books (name, genre, isbn, authors)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO books (name, genre, isbn, authors, categories, tags, year, cover_url, description, series_id, volume)
+		VALUES ($1, $2, $3, $4, COALESCE($5::UUID[], '{}'), COALESCE($6::VARCHAR[], '{}'), $7, $8, $9, NULLIF($10, '')::UUID, $11)
 		RETURNING id`
 
-	args := []any{data.Name, data.Genre, data.ISBN, pq.Array(data.Authors)}
+	args := []any{data.Name, data.Genre, data.ISBN, pq.Array(data.Authors), pq.Array(data.Categories), pq.Array(data.Tags), data.Year, data.Cover, data.Description, data.SeriesID, data.Volume}
 
 	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -54,9 +137,9 @@ func (r *BookRepository) Add(ctx context.Context, data book.Entity) (id string,
 
 func (r *BookRepository) Get(ctx context.Context, id string) (dest book.Entity, err error) {
 	query := `
-		SELECT id, name, genre, isbn, authors
+		SELECT id, name, genre, isbn, authors, categories, tags, year, cover_url, description, cover_key, rating, review_count, series_id, volume, deleted_at, created_at
 		FROM books
-		WHERE id=$1`
+		WHERE id=$1 AND deleted_at IS NULL`
 
 	args := []any{id}
 
@@ -75,7 +158,7 @@ func (r *BookRepository) Update(ctx context.Context, id string, data book.Entity
 
 		args = append(args, id)
 		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
-		query := fmt.Sprintf("UPDATE books SET %r WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+		query := fmt.Sprintf("UPDATE books SET %s WHERE id=$%d AND deleted_at IS NULL RETURNING id", strings.Join(sets, ", "), len(args))
 
 		if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
@@ -103,18 +186,119 @@ func (r *BookRepository) prepareArgs(data book.Entity) (sets []string, args []an
 		sets = append(sets, fmt.Sprintf("isbn=$%d", len(args)))
 	}
 
-	if len(data.Authors) > 0 {
+	if data.Authors != nil {
 		args = append(args, pq.Array(data.Authors))
 		sets = append(sets, fmt.Sprintf("authors=$%d", len(args)))
 	}
 
+	if data.Categories != nil {
+		args = append(args, pq.Array(data.Categories))
+		sets = append(sets, fmt.Sprintf("categories=$%d", len(args)))
+	}
+
+	if data.Tags != nil {
+		args = append(args, pq.Array(data.Tags))
+		sets = append(sets, fmt.Sprintf("tags=$%d", len(args)))
+	}
+
+	if data.SeriesID != nil {
+		args = append(args, data.SeriesID, data.Volume)
+		sets = append(sets, fmt.Sprintf("series_id=NULLIF($%d, '')::UUID, volume=$%d", len(args)-1, len(args)))
+	}
+
+	if data.Year != nil {
+		args = append(args, data.Year)
+		sets = append(sets, fmt.Sprintf("year=$%d", len(args)))
+	}
+
+	if data.Cover != nil {
+		args = append(args, data.Cover)
+		sets = append(sets, fmt.Sprintf("cover_url=$%d", len(args)))
+	}
+
+	if data.Description != nil {
+		args = append(args, data.Description)
+		sets = append(sets, fmt.Sprintf("description=$%d", len(args)))
+	}
+
+	if data.CoverKey != nil {
+		args = append(args, data.CoverKey)
+		sets = append(sets, fmt.Sprintf("cover_key=$%d", len(args)))
+	}
+
+	if data.Rating != nil {
+		args = append(args, data.Rating)
+		sets = append(sets, fmt.Sprintf("rating=$%d", len(args)))
+	}
+
+	if data.ReviewCount != nil {
+		args = append(args, data.ReviewCount)
+		sets = append(sets, fmt.Sprintf("review_count=$%d", len(args)))
+	}
+
 	return
 }
 
+// Search matches the terms as prefixes against the search_vector of the books, see migration 00002
+func (r *BookRepository) Search(ctx context.Context, query string, limit, offset int) (dest []book.Entity, err error) {
+	terms := book.SearchTerms(query)
+	if len(terms) == 0 {
+		return
+	}
+
+	for i := range terms {
+		terms[i] += ":*"
+	}
+
+	search := `
+		SELECT id, name, genre, isbn, authors, categories, tags, year, cover_url, description, cover_key, rating, review_count, series_id, volume, deleted_at, created_at
+		FROM books, to_tsquery('simple', $1) query
+		WHERE search_vector @@ query AND deleted_at IS NULL
+		ORDER BY ts_rank(search_vector, query) DESC, id
+		LIMIT $2 OFFSET $3`
+
+	args := []any{strings.Join(terms, " & "), limit, offset}
+
+	err = r.db.SelectContext(ctx, &dest, search, args...)
+
+	return
+}
+
+// Delete only marks the book as deleted, its copies and reviews are kept for Restore
 func (r *BookRepository) Delete(ctx context.Context, id string) (err error) {
 	query := `
-		DELETE FROM books
-		WHERE id=$1
+		UPDATE books
+		SET deleted_at=CURRENT_TIMESTAMP, updated_at=CURRENT_TIMESTAMP
+		WHERE id=$1 AND deleted_at IS NULL
+		RETURNING id`
+
+	args := []any{id}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *BookRepository) ListWithDeleted(ctx context.Context) (dest []book.Entity, err error) {
+	query := `
+		SELECT id, name, genre, isbn, authors, categories, tags, year, cover_url, description, cover_key, rating, review_count, series_id, volume, deleted_at, created_at
+		FROM books
+		ORDER BY id`
+
+	err = r.db.SelectContext(ctx, &dest, query)
+
+	return
+}
+
+func (r *BookRepository) Restore(ctx context.Context, id string) (err error) {
+	query := `
+		UPDATE books
+		SET deleted_at=NULL, updated_at=CURRENT_TIMESTAMP
+		WHERE id=$1 AND deleted_at IS NOT NULL
 		RETURNING id`
 
 	args := []any{id}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"library-service/internal/domain/category"
+	"library-service/pkg/store"
+)
+
+type CategoryRepository struct {
+	db *sqlx.DB
+}
+
+func NewCategoryRepository(db *sqlx.DB) *CategoryRepository {
+	return &CategoryRepository{
+		db: db,
+	}
+}
+
+func (r *CategoryRepository) List(ctx context.Context) (dest []category.Entity, err error) {
+	query := `
+		SELECT id, name, slug, parent_id
+		FROM categories
+		ORDER BY name`
+
+	err = r.db.SelectContext(ctx, &dest, query)
+
+	return
+}
+
+func (r *CategoryRepository) Add(ctx context.Context, data category.Entity) (id string, err error) {
+	query := `
+		INSERT INTO categories (name, slug, parent_id)
+		VALUES ($1, $2, NULLIF($3, '')::UUID)
+		RETURNING id`
+
+	args := []any{data.Name, data.Slug, data.ParentID}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *CategoryRepository) Get(ctx context.Context, id string) (dest category.Entity, err error) {
+	query := `
+		SELECT id, name, slug, parent_id
+		FROM categories
+		WHERE id=$1`
+
+	args := []any{id}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *CategoryRepository) Update(ctx context.Context, id string, data category.Entity) (err error) {
+	sets, args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		args = append(args, id)
+		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
+		query := fmt.Sprintf("UPDATE categories SET %s WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+
+		if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = store.ErrorNotFound
+			}
+		}
+	}
+
+	return
+}
+
+func (r *CategoryRepository) prepareArgs(data category.Entity) (sets []string, args []any) {
+	if data.Name != nil {
+		args = append(args, data.Name)
+		sets = append(sets, fmt.Sprintf("name=$%d", len(args)))
+	}
+
+	if data.Slug != nil {
+		args = append(args, data.Slug)
+		sets = append(sets, fmt.Sprintf("slug=$%d", len(args)))
+	}
+
+	if data.ParentID != nil {
+		args = append(args, data.ParentID)
+		sets = append(sets, fmt.Sprintf("parent_id=NULLIF($%d, '')::UUID", len(args)))
+	}
+
+	return
+}
+
+func (r *CategoryRepository) Delete(ctx context.Context, id string) (err error) {
+	query := `
+		DELETE FROM categories
+		WHERE id=$1
+		RETURNING id`
+
+	args := []any{id}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
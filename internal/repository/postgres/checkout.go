@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/store"
+)
+
+type CheckoutRepository struct {
+	db *sqlx.DB
+}
+
+func NewCheckoutRepository(db *sqlx.DB) *CheckoutRepository {
+	return &CheckoutRepository{
+		db: db,
+	}
+}
+
+func (r *CheckoutRepository) Add(ctx context.Context, data book.Checkout) (id string, err error) {
+	query := `
+		INSERT INTO book_checkouts (book_id, copy_id)
+		VALUES ($1, $2)
+		RETURNING id`
+
+	args := []any{data.BookID, data.CopyID}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *CheckoutRepository) Count(ctx context.Context, since time.Time) (dest map[string]int, err error) {
+	query := `
+		SELECT book_id, COUNT(*) AS checkouts
+		FROM book_checkouts
+		WHERE created_at >= $1
+		GROUP BY book_id`
+
+	args := []any{since}
+
+	var rows []struct {
+		BookID    string `db:"book_id"`
+		Checkouts int    `db:"checkouts"`
+	}
+	if err = r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return
+	}
+
+	dest = make(map[string]int, len(rows))
+	for _, row := range rows {
+		dest[row.BookID] = row.Checkouts
+	}
+
+	return
+}
@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/store"
+)
+
+type CopyRepository struct {
+	db *sqlx.DB
+}
+
+func NewCopyRepository(db *sqlx.DB) *CopyRepository {
+	return &CopyRepository{
+		db: db,
+	}
+}
+
+func (r *CopyRepository) List(ctx context.Context, bookID string) (dest []book.Copy, err error) {
+	query := `
+		SELECT id, book_id, barcode, condition, location, status
+		FROM book_copies
+		WHERE book_id=$1
+		ORDER BY barcode`
+
+	args := []any{bookID}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *CopyRepository) ListByBooks(ctx context.Context, bookIDs []string) (dest []book.Copy, err error) {
+	query := `
+		SELECT id, book_id, barcode, condition, location, status
+		FROM book_copies
+		WHERE book_id=ANY($1::UUID[])`
+
+	args := []any{pq.Array(bookIDs)}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *CopyRepository) Add(ctx context.Context, data book.Copy) (id string, err error) {
+	query := `
+		INSERT INTO book_copies (book_id, barcode, condition, location, status)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	args := []any{data.BookID, data.Barcode, data.Condition, data.Location, data.Status}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *CopyRepository) Get(ctx context.Context, id string) (dest book.Copy, err error) {
+	query := `
+		SELECT id, book_id, barcode, condition, location, status
+		FROM book_copies
+		WHERE id=$1`
+
+	args := []any{id}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *CopyRepository) GetByBarcode(ctx context.Context, barcode string) (dest book.Copy, err error) {
+	query := `
+		SELECT id, book_id, barcode, condition, location, status
+		FROM book_copies
+		WHERE barcode=$1`
+
+	args := []any{barcode}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *CopyRepository) Update(ctx context.Context, id string, data book.Copy) (err error) {
+	sets, args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		args = append(args, id)
+		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
+		query := fmt.Sprintf("UPDATE book_copies SET %s WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+
+		if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = store.ErrorNotFound
+			}
+		}
+	}
+
+	return
+}
+
+func (r *CopyRepository) prepareArgs(data book.Copy) (sets []string, args []any) {
+	if data.Barcode != nil {
+		args = append(args, data.Barcode)
+		sets = append(sets, fmt.Sprintf("barcode=$%d", len(args)))
+	}
+
+	if data.Condition != nil {
+		args = append(args, data.Condition)
+		sets = append(sets, fmt.Sprintf("condition=$%d", len(args)))
+	}
+
+	if data.Location != nil {
+		args = append(args, data.Location)
+		sets = append(sets, fmt.Sprintf("location=$%d", len(args)))
+	}
+
+	if data.Status != nil {
+		args = append(args, data.Status)
+		sets = append(sets, fmt.Sprintf("status=$%d", len(args)))
+	}
+
+	return
+}
+
+func (r *CopyRepository) Delete(ctx context.Context, id string) (err error) {
+	query := `
+		DELETE FROM book_copies
+		WHERE id=$1
+		RETURNING id`
+
+	args := []any{id}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
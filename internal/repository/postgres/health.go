@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"library-service/internal/domain/health"
+	"library-service/pkg/store"
+)
+
+type IncidentRepository struct {
+	db *sqlx.DB
+}
+
+func NewIncidentRepository(db *sqlx.DB) *IncidentRepository {
+	return &IncidentRepository{
+		db: db,
+	}
+}
+
+func (r *IncidentRepository) List(ctx context.Context, current bool) (dest []health.Incident, err error) {
+	query := `
+		SELECT id, title, description, impact, state, components, created_at, resolved_at
+		FROM incidents
+		WHERE NOT $1 OR state<>'resolved'
+		ORDER BY created_at DESC`
+
+	args := []any{current}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *IncidentRepository) Add(ctx context.Context, data health.Incident) (id string, err error) {
+	query := `
+		INSERT INTO incidents (title, description, impact, state, components, resolved_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	args := []any{data.Title, data.Description, data.Impact, data.State, pq.Array(data.Components), data.ResolvedAt}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *IncidentRepository) Get(ctx context.Context, id string) (dest health.Incident, err error) {
+	query := `
+		SELECT id, title, description, impact, state, components, created_at, resolved_at
+		FROM incidents
+		WHERE id=$1`
+
+	args := []any{id}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *IncidentRepository) Update(ctx context.Context, id string, data health.Incident) (err error) {
+	sets, args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		args = append(args, id)
+		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
+		query := fmt.Sprintf("UPDATE incidents SET %s WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+
+		if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = store.ErrorNotFound
+			}
+		}
+	}
+
+	return
+}
+
+func (r *IncidentRepository) Delete(ctx context.Context, id string) (err error) {
+	query := `
+		DELETE FROM incidents
+		WHERE id=$1
+		RETURNING id`
+
+	args := []any{id}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *IncidentRepository) prepareArgs(data health.Incident) (sets []string, args []any) {
+	if data.Title != nil {
+		args = append(args, data.Title)
+		sets = append(sets, fmt.Sprintf("title=$%d", len(args)))
+	}
+
+	if data.Description != nil {
+		args = append(args, data.Description)
+		sets = append(sets, fmt.Sprintf("description=$%d", len(args)))
+	}
+
+	if data.Impact != nil {
+		args = append(args, data.Impact)
+		sets = append(sets, fmt.Sprintf("impact=$%d", len(args)))
+	}
+
+	if data.State != nil {
+		args = append(args, data.State)
+		sets = append(sets, fmt.Sprintf("state=$%d", len(args)))
+	}
+
+	if len(data.Components) > 0 {
+		args = append(args, pq.Array(data.Components))
+		sets = append(sets, fmt.Sprintf("components=$%d", len(args)))
+	}
+
+	if data.ResolvedAt != nil {
+		args = append(args, data.ResolvedAt)
+		sets = append(sets, fmt.Sprintf("resolved_at=$%d", len(args)))
+	}
+
+	return
+}
+
+type CheckRepository struct {
+	db *sqlx.DB
+}
+
+func NewCheckRepository(db *sqlx.DB) *CheckRepository {
+	return &CheckRepository{
+		db: db,
+	}
+}
+
+// Record counts the check on the row of the component and day, the row is created by the first check of the day
+func (r *CheckRepository) Record(ctx context.Context, component string, day time.Time, passed bool) (err error) {
+	query := `
+		INSERT INTO health_checks (component, day, total, passed)
+		VALUES ($1, $2, 1, $3::BOOLEAN::INTEGER)
+		ON CONFLICT (component, day) DO UPDATE
+		SET total=health_checks.total+1, passed=health_checks.passed+EXCLUDED.passed`
+
+	args := []any{component, day, passed}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+
+	return
+}
+
+func (r *CheckRepository) List(ctx context.Context, since time.Time) (dest []health.Day, err error) {
+	query := `
+		SELECT component, day, total, passed
+		FROM health_checks
+		WHERE day>=$1`
+
+	args := []any{since}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"library-service/internal/domain/loan"
+	"library-service/pkg/store"
+)
+
+type LoanRepository struct {
+	db *sqlx.DB
+}
+
+func NewLoanRepository(db *sqlx.DB) *LoanRepository {
+	return &LoanRepository{
+		db: db,
+	}
+}
+
+func (r *LoanRepository) List(ctx context.Context, memberID string) (dest []loan.Entity, err error) {
+	query := `
+		SELECT id, member_id, book_id, copy_id, due_at, renewals, returned_at, created_at
+		FROM member_loans
+		WHERE member_id=$1
+		ORDER BY created_at DESC`
+
+	args := []any{memberID}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+// Add locks the member so that their parallel checkouts are counted one after the other and inserts the loan
+// only while the member has fewer than limit books on loan, the insert that doesn't happen is loan.ErrorLimit.
+// A violated unique index over the copies of the active loans is reported as loan.ErrorUnavailable.
+func (r *LoanRepository) Add(ctx context.Context, data loan.Entity, limit int) (id string, err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `
+		SELECT id
+		FROM members
+		WHERE id=$1
+		FOR UPDATE`
+
+	args := []any{data.MemberID}
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return
+	}
+
+	query = `
+		INSERT INTO member_loans (member_id, book_id, copy_id, due_at)
+		SELECT $1, $2, $3, $4
+		WHERE (SELECT COUNT(*) FROM member_loans WHERE member_id=$1 AND returned_at IS NULL) < $5
+		RETURNING id`
+
+	args = []any{data.MemberID, data.BookID, data.CopyID, data.DueAt, limit}
+
+	if err = tx.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		var pqErr *pq.Error
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			err = loan.ErrorLimit
+		case errors.As(err, &pqErr) && pqErr.Code == uniqueViolation:
+			err = loan.ErrorUnavailable
+		}
+	}
+
+	return
+}
+
+func (r *LoanRepository) Get(ctx context.Context, id string) (dest loan.Entity, err error) {
+	query := `
+		SELECT id, member_id, book_id, copy_id, due_at, renewals, returned_at, created_at
+		FROM member_loans
+		WHERE id=$1`
+
+	args := []any{id}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *LoanRepository) Update(ctx context.Context, id string, data loan.Entity) (err error) {
+	query := `
+		UPDATE member_loans
+		SET due_at=$1, renewals=$2, returned_at=$3, updated_at=CURRENT_TIMESTAMP
+		WHERE id=$4
+		RETURNING id`
+
+	args := []any{data.DueAt, data.Renewals, data.ReturnedAt, id}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *LoanRepository) Delete(ctx context.Context, id string) (err error) {
+	query := `
+		DELETE FROM member_loans
+		WHERE id=$1
+		RETURNING id`
+
+	args := []any{id}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
@@ -26,7 +26,7 @@ func NewMemberRepository(db *sqlx.DB) *MemberRepository {
 
 func (r *MemberRepository) List(ctx context.Context) (dest []member.Entity, err error) {
 	query := `
-		SELECT id, full_name, books
+		SELECT id, full_name, books, tier
 		FROM members
 		ORDER BY id`
 
@@ -37,11 +37,11 @@ func (r *MemberRepository) List(ctx context.Context) (dest []member.Entity, err
 
 func (r *MemberRepository) Add(ctx context.Context, data member.Entity) (id string, err error) {
 	query := `
-		INSERT INTO members (full_name, books)
-		VALUES ($1, $2)
+		INSERT INTO members (full_name, books, tier)
+		VALUES ($1, $2, $3)
 		RETURNING id`
 
-	args := []any{data.FullName, pq.Array(data.Books)}
+	args := []any{data.FullName, pq.Array(data.Books), data.Subscription()}
 
 	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -54,7 +54,7 @@ func (r *MemberRepository) Add(ctx context.Context, data member.Entity) (id stri
 
 func (r *MemberRepository) Get(ctx context.Context, id string) (dest member.Entity, err error) {
 	query := `
-		SELECT id, full_name, books
+		SELECT id, full_name, books, tier
 		FROM members
 		WHERE id=$1`
 
@@ -75,7 +75,7 @@ func (r *MemberRepository) Update(ctx context.Context, id string, data member.En
 
 		args = append(args, id)
 		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
-		query := fmt.Sprintf("UPDATE members SET %r WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+		query := fmt.Sprintf("UPDATE members SET %s WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
 
 		if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
@@ -93,11 +93,16 @@ func (r *MemberRepository) prepareArgs(data member.Entity) (sets []string, args
 		sets = append(sets, fmt.Sprintf("full_name=$%d", len(args)))
 	}
 
-	if len(data.Books) > 0 {
+	if data.Books != nil {
 		args = append(args, pq.Array(data.Books))
 		sets = append(sets, fmt.Sprintf("books=$%d", len(args)))
 	}
 
+	if data.Tier != nil {
+		args = append(args, data.Tier)
+		sets = append(sets, fmt.Sprintf("tier=$%d", len(args)))
+	}
+
 	return
 }
 
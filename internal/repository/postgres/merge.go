@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"library-service/pkg/store"
+)
+
+type MergeRepository struct {
+	db *sqlx.DB
+}
+
+func NewMergeRepository(db *sqlx.DB) *MergeRepository {
+	return &MergeRepository{
+		db: db,
+	}
+}
+
+// Merge runs in a single transaction that locks both books until it is committed
+func (r *MergeRepository) Merge(ctx context.Context, id, duplicateID string) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := `
+		SELECT id
+		FROM books
+		WHERE id=ANY($1::UUID[]) AND deleted_at IS NULL
+		FOR UPDATE`
+
+	var ids []string
+	if err = tx.SelectContext(ctx, &ids, query, pq.Array([]string{id, duplicateID})); err != nil {
+		return
+	}
+
+	if len(ids) < 2 {
+		err = store.ErrorNotFound
+		return
+	}
+
+	queries := []string{`
+		UPDATE book_copies
+		SET book_id=$1, updated_at=CURRENT_TIMESTAMP
+		WHERE book_id=$2`, `
+		UPDATE book_checkouts
+		SET book_id=$1
+		WHERE book_id=$2`, `
+		UPDATE member_loans
+		SET book_id=$1, updated_at=CURRENT_TIMESTAMP
+		WHERE book_id=$2`, `
+		DELETE FROM book_reviews d
+		WHERE d.book_id=$2 AND EXISTS (SELECT 1 FROM book_reviews c WHERE c.book_id=$1 AND c.member_id=d.member_id)`, `
+		UPDATE book_reviews
+		SET book_id=$1, updated_at=CURRENT_TIMESTAMP
+		WHERE book_id=$2`, `
+		DELETE FROM book_watches d
+		WHERE d.book_id=$2 AND EXISTS (SELECT 1 FROM book_watches c WHERE c.book_id=$1 AND c.member_id=d.member_id)`, `
+		UPDATE book_watches
+		SET book_id=$1
+		WHERE book_id=$2`, `
+		UPDATE members
+		SET books=ARRAY(
+			SELECT b FROM UNNEST(ARRAY_REPLACE(books, $2, $1)) WITH ORDINALITY AS t(b, n)
+			GROUP BY b ORDER BY MIN(n)
+		), updated_at=CURRENT_TIMESTAMP
+		WHERE $2=ANY(books)`, `
+		UPDATE books
+		SET deleted_at=CURRENT_TIMESTAMP, updated_at=CURRENT_TIMESTAMP
+		WHERE id=$2`,
+	}
+
+	for _, query = range queries {
+		if _, err = tx.ExecContext(ctx, query, id, duplicateID); err != nil {
+			return
+		}
+	}
+
+	return
+}
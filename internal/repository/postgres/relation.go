@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"library-service/internal/domain/book"
+)
+
+type RelationRepository struct {
+	db *sqlx.DB
+}
+
+func NewRelationRepository(db *sqlx.DB) *RelationRepository {
+	return &RelationRepository{
+		db: db,
+	}
+}
+
+// List leaves out the related books that were deleted since the relations were built
+func (r *RelationRepository) List(ctx context.Context, bookID string) (dest []book.Relation, err error) {
+	query := `
+		SELECT r.book_id, r.related_id, r.borrowers
+		FROM book_relations r
+		JOIN books b ON b.id=r.related_id AND b.deleted_at IS NULL
+		WHERE r.book_id=$1
+		ORDER BY r.borrowers DESC, r.related_id`
+
+	args := []any{bookID}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+// Replace runs in a single transaction, the relations of books that are no longer in the catalog are dropped
+func (r *RelationRepository) Replace(ctx context.Context, data []book.Relation) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM book_relations`); err != nil {
+		return
+	}
+
+	bookIDs, relatedIDs, borrowers := make([]string, len(data)), make([]string, len(data)), make([]int64, len(data))
+	for i, object := range data {
+		bookIDs[i], relatedIDs[i], borrowers[i] = object.BookID, object.RelatedID, int64(object.Borrowers)
+	}
+
+	query := `
+		INSERT INTO book_relations (book_id, related_id, borrowers)
+		SELECT r.book_id, r.related_id, r.borrowers
+		FROM UNNEST($1::UUID[], $2::UUID[], $3::INTEGER[]) AS r (book_id, related_id, borrowers)
+		WHERE r.book_id IN (SELECT id FROM books) AND r.related_id IN (SELECT id FROM books)`
+
+	args := []any{pq.Array(bookIDs), pq.Array(relatedIDs), pq.Array(borrowers)}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+
+	return
+}
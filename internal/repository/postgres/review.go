@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"library-service/internal/domain/review"
+	"library-service/pkg/store"
+)
+
+// uniqueViolation is the postgres error code of a unique constraint violation
+const uniqueViolation = "23505"
+
+type ReviewRepository struct {
+	db *sqlx.DB
+}
+
+func NewReviewRepository(db *sqlx.DB) *ReviewRepository {
+	return &ReviewRepository{
+		db: db,
+	}
+}
+
+func (r *ReviewRepository) List(ctx context.Context, bookID string) (dest []review.Entity, err error) {
+	query := `
+		SELECT id, book_id, member_id, rating, text, status, created_at
+		FROM book_reviews
+		WHERE book_id=$1
+		ORDER BY created_at DESC`
+
+	args := []any{bookID}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *ReviewRepository) ListByStatus(ctx context.Context, status review.Status) (dest []review.Entity, err error) {
+	query := `
+		SELECT id, book_id, member_id, rating, text, status, created_at
+		FROM book_reviews
+		WHERE status=$1
+		ORDER BY created_at DESC`
+
+	args := []any{status}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *ReviewRepository) Add(ctx context.Context, data review.Entity) (id string, err error) {
+	query := `
+		INSERT INTO book_reviews (book_id, member_id, rating, text, status)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	args := []any{data.BookID, data.MemberID, data.Rating, data.Text, data.Status}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		var pqErr *pq.Error
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			err = store.ErrorNotFound
+		case errors.As(err, &pqErr) && pqErr.Code == uniqueViolation:
+			err = review.ErrorExists
+		}
+	}
+
+	return
+}
+
+func (r *ReviewRepository) Get(ctx context.Context, id string) (dest review.Entity, err error) {
+	query := `
+		SELECT id, book_id, member_id, rating, text, status, created_at
+		FROM book_reviews
+		WHERE id=$1`
+
+	args := []any{id}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *ReviewRepository) Update(ctx context.Context, id string, data review.Entity) (err error) {
+	sets, args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		args = append(args, id)
+		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
+		query := fmt.Sprintf("UPDATE book_reviews SET %s WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+
+		if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = store.ErrorNotFound
+			}
+		}
+	}
+
+	return
+}
+
+func (r *ReviewRepository) prepareArgs(data review.Entity) (sets []string, args []any) {
+	if data.Rating != nil {
+		args = append(args, data.Rating)
+		sets = append(sets, fmt.Sprintf("rating=$%d", len(args)))
+	}
+
+	if data.Text != nil {
+		args = append(args, data.Text)
+		sets = append(sets, fmt.Sprintf("text=$%d", len(args)))
+	}
+
+	if data.Status != nil {
+		args = append(args, data.Status)
+		sets = append(sets, fmt.Sprintf("status=$%d", len(args)))
+	}
+
+	return
+}
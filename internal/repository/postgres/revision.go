@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/store"
+)
+
+type RevisionRepository struct {
+	db *sqlx.DB
+}
+
+func NewRevisionRepository(db *sqlx.DB) *RevisionRepository {
+	return &RevisionRepository{
+		db: db,
+	}
+}
+
+func (r *RevisionRepository) List(ctx context.Context, bookID string) (dest []book.Revision, err error) {
+	query := `
+		SELECT id, book_id, action, actor, before, after, created_at
+		FROM book_revisions
+		WHERE book_id=$1
+		ORDER BY created_at DESC`
+
+	args := []any{bookID}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *RevisionRepository) Add(ctx context.Context, data book.Revision) (id string, err error) {
+	query := `
+		INSERT INTO book_revisions (book_id, action, actor, before, after)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	args := []any{data.BookID, data.Action, data.Actor, data.Before, data.After}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *RevisionRepository) Get(ctx context.Context, id string) (dest book.Revision, err error) {
+	query := `
+		SELECT id, book_id, action, actor, before, after, created_at
+		FROM book_revisions
+		WHERE id=$1`
+
+	args := []any{id}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
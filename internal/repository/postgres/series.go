@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"library-service/internal/domain/series"
+	"library-service/pkg/store"
+)
+
+type SeriesRepository struct {
+	db *sqlx.DB
+}
+
+func NewSeriesRepository(db *sqlx.DB) *SeriesRepository {
+	return &SeriesRepository{
+		db: db,
+	}
+}
+
+func (r *SeriesRepository) List(ctx context.Context) (dest []series.Entity, err error) {
+	query := `
+		SELECT id, name, description
+		FROM series
+		ORDER BY name`
+
+	err = r.db.SelectContext(ctx, &dest, query)
+
+	return
+}
+
+func (r *SeriesRepository) Add(ctx context.Context, data series.Entity) (id string, err error) {
+	query := `
+		INSERT INTO series (name, description)
+		VALUES ($1, $2)
+		RETURNING id`
+
+	args := []any{data.Name, data.Description}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *SeriesRepository) Get(ctx context.Context, id string) (dest series.Entity, err error) {
+	query := `
+		SELECT id, name, description
+		FROM series
+		WHERE id=$1`
+
+	args := []any{id}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *SeriesRepository) Update(ctx context.Context, id string, data series.Entity) (err error) {
+	sets, args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		args = append(args, id)
+		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
+		query := fmt.Sprintf("UPDATE series SET %s WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+
+		if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = store.ErrorNotFound
+			}
+		}
+	}
+
+	return
+}
+
+func (r *SeriesRepository) prepareArgs(data series.Entity) (sets []string, args []any) {
+	if data.Name != nil {
+		args = append(args, data.Name)
+		sets = append(sets, fmt.Sprintf("name=$%d", len(args)))
+	}
+
+	if data.Description != nil {
+		args = append(args, data.Description)
+		sets = append(sets, fmt.Sprintf("description=$%d", len(args)))
+	}
+
+	return
+}
+
+func (r *SeriesRepository) Delete(ctx context.Context, id string) (err error) {
+	query := `
+		DELETE FROM series
+		WHERE id=$1
+		RETURNING id`
+
+	args := []any{id}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"library-service/internal/domain/shelf"
+	"library-service/pkg/store"
+)
+
+type ShelfRepository struct {
+	db *sqlx.DB
+}
+
+func NewShelfRepository(db *sqlx.DB) *ShelfRepository {
+	return &ShelfRepository{
+		db: db,
+	}
+}
+
+func (r *ShelfRepository) List(ctx context.Context, memberID string) (dest []shelf.Entity, err error) {
+	query := `
+		SELECT id, member_id, name, books, share_token, created_at
+		FROM member_shelves
+		WHERE member_id=$1
+		ORDER BY created_at`
+
+	args := []any{memberID}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *ShelfRepository) Add(ctx context.Context, data shelf.Entity) (id string, err error) {
+	query := `
+		INSERT INTO member_shelves (member_id, name, books)
+		VALUES ($1, $2, COALESCE($3::UUID[], '{}'))
+		RETURNING id`
+
+	args := []any{data.MemberID, data.Name, pq.Array(data.Books)}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		var pqErr *pq.Error
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			err = store.ErrorNotFound
+		case errors.As(err, &pqErr) && pqErr.Code == uniqueViolation:
+			err = shelf.ErrorExists
+		}
+	}
+
+	return
+}
+
+func (r *ShelfRepository) Get(ctx context.Context, id string) (dest shelf.Entity, err error) {
+	query := `
+		SELECT id, member_id, name, books, share_token, created_at
+		FROM member_shelves
+		WHERE id=$1`
+
+	args := []any{id}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *ShelfRepository) GetByToken(ctx context.Context, token string) (dest shelf.Entity, err error) {
+	query := `
+		SELECT id, member_id, name, books, share_token, created_at
+		FROM member_shelves
+		WHERE share_token=$1`
+
+	args := []any{token}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *ShelfRepository) Update(ctx context.Context, id string, data shelf.Entity) (err error) {
+	sets, args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		args = append(args, id)
+		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
+		query := fmt.Sprintf("UPDATE member_shelves SET %s WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+
+		if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+			var pqErr *pq.Error
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				err = store.ErrorNotFound
+			case errors.As(err, &pqErr) && pqErr.Code == uniqueViolation:
+				err = shelf.ErrorExists
+			}
+		}
+	}
+
+	return
+}
+
+func (r *ShelfRepository) Delete(ctx context.Context, id string) (err error) {
+	query := `
+		DELETE FROM member_shelves
+		WHERE id=$1
+		RETURNING id`
+
+	args := []any{id}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *ShelfRepository) prepareArgs(data shelf.Entity) (sets []string, args []any) {
+	if data.Name != nil {
+		args = append(args, data.Name)
+		sets = append(sets, fmt.Sprintf("name=$%d", len(args)))
+	}
+
+	if data.Books != nil {
+		args = append(args, pq.Array(data.Books))
+		sets = append(sets, fmt.Sprintf("books=$%d::UUID[]", len(args)))
+	}
+
+	if data.ShareToken != nil {
+		args = append(args, data.ShareToken)
+		sets = append(sets, fmt.Sprintf("share_token=NULLIF($%d, '')", len(args)))
+	}
+
+	return
+}
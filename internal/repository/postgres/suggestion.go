@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"library-service/internal/domain/suggestion"
+	"library-service/pkg/store"
+)
+
+type SuggestionRepository struct {
+	db *sqlx.DB
+}
+
+func NewSuggestionRepository(db *sqlx.DB) *SuggestionRepository {
+	return &SuggestionRepository{
+		db: db,
+	}
+}
+
+func (r *SuggestionRepository) List(ctx context.Context, status suggestion.Status) (dest []suggestion.Entity, err error) {
+	query := `
+		SELECT id, member_id, title, author, isbn, note, status, reason, book_id, voters, created_at
+		FROM book_suggestions
+		WHERE $1='' OR status=$1
+		ORDER BY CARDINALITY(voters) DESC, created_at`
+
+	args := []any{status}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *SuggestionRepository) Add(ctx context.Context, data suggestion.Entity) (id string, err error) {
+	query := `
+		INSERT INTO book_suggestions (member_id, title, author, isbn, note, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	args := []any{data.MemberID, data.Title, data.Author, data.ISBN, data.Note, data.Status}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *SuggestionRepository) Get(ctx context.Context, id string) (dest suggestion.Entity, err error) {
+	query := `
+		SELECT id, member_id, title, author, isbn, note, status, reason, book_id, voters, created_at
+		FROM book_suggestions
+		WHERE id=$1`
+
+	args := []any{id}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *SuggestionRepository) Update(ctx context.Context, id string, data suggestion.Entity) (err error) {
+	sets, args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		args = append(args, id)
+		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
+		query := fmt.Sprintf("UPDATE book_suggestions SET %s WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+
+		if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = store.ErrorNotFound
+			}
+		}
+	}
+
+	return
+}
+
+// Vote appends the member in a single statement, the voters are checked again on the locked row
+// so that concurrent votes of the member count once
+func (r *SuggestionRepository) Vote(ctx context.Context, id, memberID string) (err error) {
+	query := `
+		WITH target AS (
+			SELECT id, $2=ANY(voters) AS voted
+			FROM book_suggestions
+			WHERE id=$1
+		), updated AS (
+			UPDATE book_suggestions s
+			SET voters=ARRAY_APPEND(s.voters, $2), updated_at=CURRENT_TIMESTAMP
+			FROM target t
+			WHERE s.id=t.id AND NOT $2=ANY(s.voters)
+		)
+		SELECT voted
+		FROM target`
+
+	args := []any{id, memberID}
+
+	var voted bool
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&voted); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+		return
+	}
+
+	if voted {
+		err = suggestion.ErrorVoted
+	}
+
+	return
+}
+
+func (r *SuggestionRepository) prepareArgs(data suggestion.Entity) (sets []string, args []any) {
+	if data.Status != nil {
+		args = append(args, data.Status)
+		sets = append(sets, fmt.Sprintf("status=$%d", len(args)))
+	}
+
+	if data.Reason != nil {
+		args = append(args, data.Reason)
+		sets = append(sets, fmt.Sprintf("reason=$%d", len(args)))
+	}
+
+	if data.BookID != nil {
+		args = append(args, data.BookID)
+		sets = append(sets, fmt.Sprintf("book_id=$%d", len(args)))
+	}
+
+	return
+}
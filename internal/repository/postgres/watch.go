@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"library-service/internal/domain/watch"
+	"library-service/pkg/store"
+)
+
+type WatchRepository struct {
+	db *sqlx.DB
+}
+
+func NewWatchRepository(db *sqlx.DB) *WatchRepository {
+	return &WatchRepository{
+		db: db,
+	}
+}
+
+func (r *WatchRepository) List(ctx context.Context, bookID string) (dest []watch.Entity, err error) {
+	query := `
+		SELECT id, book_id, member_id, created_at
+		FROM book_watches
+		WHERE book_id=$1
+		ORDER BY created_at`
+
+	args := []any{bookID}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *WatchRepository) Add(ctx context.Context, data watch.Entity) (id string, err error) {
+	query := `
+		INSERT INTO book_watches (book_id, member_id, created_at)
+		VALUES ($1, $2, $3)
+		RETURNING id`
+
+	args := []any{data.BookID, data.MemberID, data.CreatedAt}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		var pqErr *pq.Error
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			err = store.ErrorNotFound
+		case errors.As(err, &pqErr) && pqErr.Code == uniqueViolation:
+			err = watch.ErrorWatched
+		}
+	}
+
+	return
+}
+
+func (r *WatchRepository) Delete(ctx context.Context, bookID, memberID string) (err error) {
+	query := `
+		DELETE FROM book_watches
+		WHERE book_id=$1 AND member_id=$2
+		RETURNING id`
+
+	args := []any{bookID, memberID}
+
+	var id string
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
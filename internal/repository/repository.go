@@ -1,9 +1,19 @@
 package repository
 
 import (
+	"context"
+
 	"library-service/internal/domain/author"
 	"library-service/internal/domain/book"
+	"library-service/internal/domain/category"
+	"library-service/internal/domain/health"
+	"library-service/internal/domain/loan"
 	"library-service/internal/domain/member"
+	"library-service/internal/domain/review"
+	"library-service/internal/domain/series"
+	"library-service/internal/domain/shelf"
+	"library-service/internal/domain/suggestion"
+	"library-service/internal/domain/watch"
 	"library-service/internal/repository/memory"
 	"library-service/internal/repository/mongo"
 	"library-service/internal/repository/postgres"
@@ -18,9 +28,23 @@ type Repository struct {
 	mongo    store.Mongo
 	postgres store.SQLX
 
-	Author author.Repository
-	Book   book.Repository
-	Member member.Repository
+	Author     author.Repository
+	Book       book.Repository
+	Category   category.Repository
+	Copy       book.CopyRepository
+	Checkout   book.CheckoutRepository
+	Relation   book.RelationRepository
+	Revision   book.RevisionRepository
+	Member     member.Repository
+	Loan       loan.Repository
+	Review     review.Repository
+	Series     series.Repository
+	Shelf      shelf.Repository
+	Suggestion suggestion.Repository
+	Watch      watch.Repository
+	Incident   health.IncidentRepository
+	Check      health.CheckRepository
+	Merge      book.MergeRepository
 }
 
 // New takes a variable amount of Configuration functions and returns a new Repository
@@ -52,13 +76,49 @@ func (r *Repository) Close() {
 	}
 }
 
+// Ping checks that the store answers, the memory store always does
+func (r *Repository) Ping(ctx context.Context) (err error) {
+	if r.postgres.Client != nil {
+		if err = r.postgres.Client.PingContext(ctx); err != nil {
+			return
+		}
+	}
+
+	if r.mongo.Client != nil {
+		if err = r.mongo.Client.Ping(ctx, nil); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
 // WithMemoryStore applies a memory store to the Repository
 func WithMemoryStore() Configuration {
 	return func(s *Repository) (err error) {
 		// Create the memory store, if we needed parameters, such as connection strings they could be inputted here
-		s.Author = memory.NewAuthorRepository()
-		s.Book = memory.NewBookRepository()
-		s.Member = memory.NewMemberRepository()
+		authors := memory.NewAuthorRepository()
+		books, copies, checkouts := memory.NewBookRepository(authors), memory.NewCopyRepository(), memory.NewCheckoutRepository()
+		reviews, members, watches := memory.NewReviewRepository(), memory.NewMemberRepository(), memory.NewWatchRepository()
+		loans := memory.NewLoanRepository()
+
+		s.Author = authors
+		s.Book = books
+		s.Category = memory.NewCategoryRepository()
+		s.Copy = copies
+		s.Checkout = checkouts
+		s.Relation = memory.NewRelationRepository()
+		s.Revision = memory.NewRevisionRepository()
+		s.Member = members
+		s.Loan = loans
+		s.Review = reviews
+		s.Series = memory.NewSeriesRepository()
+		s.Shelf = memory.NewShelfRepository()
+		s.Suggestion = memory.NewSuggestionRepository()
+		s.Watch = watches
+		s.Incident = memory.NewIncidentRepository()
+		s.Check = memory.NewCheckRepository()
+		s.Merge = memory.NewMergeRepository(books, copies, checkouts, loans, reviews, watches, members)
 
 		return
 	}
@@ -76,7 +136,21 @@ func WithMongoStore(uri, name string) Configuration {
 
 		s.Author = mongo.NewAuthorRepository(database)
 		s.Book = mongo.NewBookRepository(database)
+		s.Category = mongo.NewCategoryRepository(database)
+		s.Copy = mongo.NewCopyRepository(database)
+		s.Checkout = mongo.NewCheckoutRepository(database)
+		s.Relation = mongo.NewRelationRepository(database)
+		s.Revision = mongo.NewRevisionRepository(database)
 		s.Member = mongo.NewMemberRepository(database)
+		s.Loan = mongo.NewLoanRepository(database)
+		s.Review = mongo.NewReviewRepository(database)
+		s.Series = mongo.NewSeriesRepository(database)
+		s.Shelf = mongo.NewShelfRepository(database)
+		s.Suggestion = mongo.NewSuggestionRepository(database)
+		s.Watch = mongo.NewWatchRepository(database)
+		s.Incident = mongo.NewIncidentRepository(database)
+		s.Check = mongo.NewCheckRepository(database)
+		s.Merge = mongo.NewMergeRepository(database)
 
 		return
 	}
@@ -97,7 +171,21 @@ func WithPostgresStore(dataSourceName string) Configuration {
 
 		s.Author = postgres.NewAuthorRepository(s.postgres.Client)
 		s.Book = postgres.NewBookRepository(s.postgres.Client)
+		s.Category = postgres.NewCategoryRepository(s.postgres.Client)
+		s.Copy = postgres.NewCopyRepository(s.postgres.Client)
+		s.Checkout = postgres.NewCheckoutRepository(s.postgres.Client)
+		s.Relation = postgres.NewRelationRepository(s.postgres.Client)
+		s.Revision = postgres.NewRevisionRepository(s.postgres.Client)
 		s.Member = postgres.NewMemberRepository(s.postgres.Client)
+		s.Loan = postgres.NewLoanRepository(s.postgres.Client)
+		s.Review = postgres.NewReviewRepository(s.postgres.Client)
+		s.Series = postgres.NewSeriesRepository(s.postgres.Client)
+		s.Shelf = postgres.NewShelfRepository(s.postgres.Client)
+		s.Suggestion = postgres.NewSuggestionRepository(s.postgres.Client)
+		s.Watch = postgres.NewWatchRepository(s.postgres.Client)
+		s.Incident = postgres.NewIncidentRepository(s.postgres.Client)
+		s.Check = postgres.NewCheckRepository(s.postgres.Client)
+		s.Merge = postgres.NewMergeRepository(s.postgres.Client)
 
 		return
 	}
@@ -1,10 +1,18 @@
 package auth
 
+import (
+	"context"
+
+	"github.com/go-chi/oauth"
+)
+
 // Configuration is an alias for a function that will take in a pointer to a Service and modify it
 type Configuration func(s *Service) error
 
 // Service is an implementation of the Service
-type Service struct{}
+type Service struct {
+	sessions *Sessions
+}
 
 // New takes a variable amount of Configuration functions and returns a new Service
 // Each Configuration will be called in the order they are passed in
@@ -21,3 +29,30 @@ func New(configs ...Configuration) (s *Service, err error) {
 	}
 	return
 }
+
+// WithSessions switches the Service to opaque session tokens kept in the given sessions
+func WithSessions(sessions *Sessions) Configuration {
+	return func(s *Service) error {
+		s.sessions = sessions
+		return nil
+	}
+}
+
+// TokenFormatter returns the formatter the tokens are issued and checked with, nil
+// leaves the oauth default of self-contained encrypted tokens
+func (s *Service) TokenFormatter() oauth.TokenSecureFormatter {
+	if s.sessions == nil {
+		return nil
+	}
+
+	return s.sessions
+}
+
+// RevokeToken ends the sessions of the access token and of the refresh token issued with it
+func (s *Service) RevokeToken(ctx context.Context, token string) error {
+	if s.sessions == nil {
+		return ErrSessionsDisabled
+	}
+
+	return s.sessions.Revoke(ctx, token)
+}
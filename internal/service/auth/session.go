@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	sessionKeyPrefix = "session:"
+	tokenKeyPrefix   = "token:"
+	sessionIDSize    = 32
+	sessionTimeout   = 3 * time.Second
+)
+
+var (
+	// ErrSessionNotFound is returned for tokens that expired, were revoked or never issued
+	ErrSessionNotFound = errors.New("auth: session not found")
+
+	// ErrSessionsDisabled is returned when revoking a token without the session token mode
+	ErrSessionsDisabled = errors.New("auth: tokens can only be revoked in the session token mode")
+)
+
+// Sessions is an oauth.TokenSecureFormatter that hands out opaque tokens instead of encrypted ones.
+// The token is kept in redis under a random id that is the token, each use of it slides its expiry
+// and deleting it revokes the token at once. The access and the refresh token issued together share
+// the token id, their sessions are listed under it so that they are revoked together.
+type Sessions struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewSessions(client *redis.Client, ttl time.Duration) *Sessions {
+	return &Sessions{client: client, ttl: ttl}
+}
+
+// CryptToken stores the token and returns its session id, the session is listed under the token id
+func (s *Sessions) CryptToken(source []byte) ([]byte, error) {
+	data := make([]byte, sessionIDSize)
+	if _, err := rand.Read(data); err != nil {
+		return nil, err
+	}
+	id := hex.EncodeToString(data)
+
+	var token struct {
+		ID string `json:"id_token"`
+	}
+	if err := json.Unmarshal(source, &token); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sessionTimeout)
+	defer cancel()
+
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, sessionKeyPrefix+id, source, s.ttl)
+		pipe.SAdd(ctx, tokenKeyPrefix+token.ID, id)
+		pipe.Expire(ctx, tokenKeyPrefix+token.ID, s.ttl)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(id), nil
+}
+
+// DecryptToken loads the token of the session and extends it along with the list of its token id,
+// the creation date of the token is moved to now as the oauth middleware checks it against the expiry as well
+func (s *Sessions) DecryptToken(source []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sessionTimeout)
+	defer cancel()
+
+	data, err := s.client.GetEx(ctx, sessionKeyPrefix+string(source), s.ttl).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			err = ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	token := make(map[string]any)
+	if err = json.Unmarshal(data, &token); err != nil {
+		return nil, err
+	}
+	token["date"] = time.Now().UTC()
+
+	if id, ok := token["id_token"].(string); ok {
+		if err = s.client.Expire(ctx, tokenKeyPrefix+id, s.ttl).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(token)
+}
+
+// Revoke deletes the session of the access token as it is sent in the Authorization header,
+// and with it the session of the refresh token issued along, so it can't be refreshed either
+func (s *Sessions) Revoke(ctx context.Context, token string) (err error) {
+	id, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return ErrSessionNotFound
+	}
+
+	data, err := s.client.Get(ctx, sessionKeyPrefix+string(id)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			err = ErrSessionNotFound
+		}
+		return
+	}
+
+	var source struct {
+		ID string `json:"id_token"`
+	}
+	if err = json.Unmarshal(data, &source); err != nil {
+		return
+	}
+
+	sessions, err := s.client.SMembers(ctx, tokenKeyPrefix+source.ID).Result()
+	if err != nil {
+		return
+	}
+
+	keys := []string{sessionKeyPrefix + string(id), tokenKeyPrefix + source.ID}
+	for _, session := range sessions {
+		keys = append(keys, sessionKeyPrefix+session)
+	}
+
+	n, err := s.client.Del(ctx, keys...).Result()
+	if err != nil {
+		return
+	}
+
+	if n == 0 {
+		err = ErrSessionNotFound
+	}
+
+	return
+}
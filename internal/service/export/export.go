@@ -0,0 +1,181 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"library-service/internal/domain/book"
+	"library-service/internal/domain/export"
+	"library-service/pkg/log"
+	"library-service/pkg/store"
+	"library-service/pkg/worker"
+)
+
+// exportsPrefix is where the files of the exports are stored, as exports/<id>.csv
+const exportsPrefix = "exports/"
+
+// CreateExport registers the job and runs it in the background, the result is polled by GetExport
+func (s *Service) CreateExport(ctx context.Context, req export.Request) (res export.Response, err error) {
+	data := &export.Entity{
+		ID:        uuid.New().String(),
+		Kind:      req.Kind,
+		Status:    export.StatusPending,
+		CreatedAt: time.Now(),
+	}
+	logger := log.LoggerFromContext(ctx).Named("CreateExport").With(zap.String("id", data.ID))
+
+	s.Lock()
+	s.jobs[data.ID] = data
+	res = export.ParseFromEntity(*data)
+	s.Unlock()
+
+	// the job outlives the request, so it only keeps the logger of the request context
+	go s.run(log.ContextWithLogger(context.Background(), logger), data)
+
+	return
+}
+
+func (s *Service) GetExport(ctx context.Context, id string) (res export.Response, err error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	data, ok := s.jobs[id]
+	if !ok {
+		err = store.ErrorNotFound
+		return
+	}
+	res = export.ParseFromEntity(*data)
+
+	if data.Status == export.StatusCompleted {
+		res.URL = s.urlSigner.URL(data.Key)
+	}
+
+	return
+}
+
+func (s *Service) run(ctx context.Context, data *export.Entity) {
+	logger := log.LoggerFromContext(ctx)
+
+	s.update(data, func() { data.Status = export.StatusRunning })
+
+	key, err := s.exportCatalog(ctx, data)
+	if err != nil {
+		logger.Error("failed to export", zap.Error(err))
+		s.update(data, func() {
+			data.Status = export.StatusFailed
+			data.Error = err.Error()
+			data.CompletedAt = time.Now()
+		})
+		return
+	}
+
+	s.update(data, func() {
+		data.Status = export.StatusCompleted
+		data.Key = key
+		data.CompletedAt = time.Now()
+	})
+}
+
+func (s *Service) update(data *export.Entity, fn func()) {
+	s.Lock()
+	defer s.Unlock()
+
+	fn()
+}
+
+// exportCatalog streams the books into the storage as they are read, so that the catalog is never held whole.
+// A failed export leaves no file behind, the storage only keeps a file once it is written completely.
+func (s *Service) exportCatalog(ctx context.Context, data *export.Entity) (key string, err error) {
+	if data.Kind != export.KindCatalog {
+		err = errors.New("unknown export kind " + data.Kind)
+		return
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		w := csv.NewWriter(pw)
+		w.Write([]string{"id", "name", "genre", "isbn", "authors"})
+
+		processed := 0
+		err := s.libraryService.EachBook(ctx, book.Filter{}, func(res book.Response) error {
+			w.Write([]string{res.ID, res.Name, res.Genre, res.ISBN.String(), strings.Join(res.Authors, ";")})
+
+			processed++
+			s.update(data, func() { data.Processed = processed })
+
+			return w.Error()
+		})
+
+		w.Flush()
+		if err == nil {
+			err = w.Error()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	key = exportsPrefix + data.ID + ".csv"
+	err = s.storage.Put(ctx, key, pr)
+
+	// stops the writer when the storage failed before reading it all
+	pr.CloseWithError(err)
+
+	return
+}
+
+// RunPrune drops the finished jobs and removes the files of the exports every interval once they are older
+// than the ttl, the files left behind by a restart are removed along with them
+func (s *Service) RunPrune(ctx context.Context, interval time.Duration, w *worker.Worker) {
+	logger := log.LoggerFromContext(ctx).Named("RunPrune")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := w.Run(func() error {
+			return s.Prune(ctx)
+		})
+		if err != nil && ctx.Err() == nil {
+			logger.Error("failed to prune exports", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Prune drops the jobs that finished more than the ttl ago and removes the files written before then
+func (s *Service) Prune(ctx context.Context) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("Prune")
+	before := time.Now().Add(-s.ttl)
+
+	s.Lock()
+	jobs := 0
+	for id, data := range s.jobs {
+		if !data.CompletedAt.IsZero() && data.CompletedAt.Before(before) {
+			delete(s.jobs, id)
+			jobs++
+		}
+	}
+	s.Unlock()
+
+	files, err := s.storage.Prune(ctx, exportsPrefix, before)
+	if err != nil {
+		return
+	}
+
+	if jobs > 0 || files > 0 {
+		logger.Info("pruned exports", zap.Int("jobs", jobs), zap.Int("files", files))
+	}
+
+	return
+}
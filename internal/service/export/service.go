@@ -0,0 +1,77 @@
+package export
+
+import (
+	"sync"
+	"time"
+
+	"library-service/internal/domain/export"
+	"library-service/internal/service/library"
+	"library-service/pkg/storage"
+)
+
+// Configuration is an alias for a function that will take in a pointer to a Service and modify it
+type Configuration func(s *Service) error
+
+// Service is an implementation of the Service
+type Service struct {
+	libraryService *library.Service
+	storage        storage.Storage
+	urlSigner      *storage.URLSigner
+
+	// ttl is how long the finished jobs and their files are kept, see RunPrune
+	ttl time.Duration
+
+	// jobs live as long as the process that runs them, or until they are pruned
+	sync.RWMutex
+	jobs map[string]*export.Entity
+}
+
+// New takes a variable amount of Configuration functions and returns a new Service
+// Each Configuration will be called in the order they are passed in
+func New(configs ...Configuration) (s *Service, err error) {
+	// Add the service
+	s = &Service{
+		jobs: make(map[string]*export.Entity),
+	}
+
+	// Apply all Configurations passed in
+	for _, cfg := range configs {
+		// Pass the service into the configuration function
+		if err = cfg(s); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// WithLibraryService applies a given library service to the Service
+func WithLibraryService(libraryService *library.Service) Configuration {
+	return func(s *Service) error {
+		s.libraryService = libraryService
+		return nil
+	}
+}
+
+// WithStorage applies a given storage the completed exports are written to
+func WithStorage(storage storage.Storage) Configuration {
+	return func(s *Service) error {
+		s.storage = storage
+		return nil
+	}
+}
+
+// WithURLSigner applies a given signer for the download urls of completed exports
+func WithURLSigner(urlSigner *storage.URLSigner) Configuration {
+	return func(s *Service) error {
+		s.urlSigner = urlSigner
+		return nil
+	}
+}
+
+// WithTTL applies how long the finished jobs and their files are kept before they are pruned
+func WithTTL(ttl time.Duration) Configuration {
+	return func(s *Service) error {
+		s.ttl = ttl
+		return nil
+	}
+}
@@ -27,11 +27,8 @@ func (s *Service) ListAuthors(ctx context.Context) (res []author.Response, err e
 func (s *Service) AddAuthor(ctx context.Context, req author.Request) (res author.Response, err error) {
 	logger := log.LoggerFromContext(ctx).Named("AddAuthor")
 
-	data := author.Entity{
-		FullName:  &req.FullName,
-		Pseudonym: &req.Pseudonym,
-		Specialty: &req.Specialty,
-	}
+	data := parseAuthorRequest(req)
+	data.Edited = author.EditedFields(author.Entity{}, data)
 
 	data.ID, err = s.authorRepository.Add(ctx, data)
 	if err != nil {
@@ -47,8 +44,10 @@ func (s *Service) GetAuthor(ctx context.Context, id string) (res author.Response
 	logger := log.LoggerFromContext(ctx).Named("GetAuthor").With(zap.String("id", id))
 
 	data, err := s.authorRepository.Get(ctx, id)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
-		logger.Error("failed to get by id", zap.Error(err))
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
 		return
 	}
 	res = author.ParseFromEntity(data)
@@ -59,12 +58,17 @@ func (s *Service) GetAuthor(ctx context.Context, id string) (res author.Response
 func (s *Service) UpdateAuthor(ctx context.Context, id string, req author.Request) (err error) {
 	logger := log.LoggerFromContext(ctx).Named("UpdateAuthor").With(zap.String("id", id))
 
-	data := author.Entity{
-		FullName:  &req.FullName,
-		Pseudonym: &req.Pseudonym,
-		Specialty: &req.Specialty,
+	current, err := s.authorRepository.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
 	}
 
+	data := parseAuthorRequest(req)
+	data.Edited = author.EditedFields(current, data)
+
 	err = s.authorRepository.Update(ctx, id, data)
 	if err != nil && !errors.Is(err, store.ErrorNotFound) {
 		logger.Error("failed to update by id", zap.Error(err))
@@ -85,3 +89,66 @@ func (s *Service) DeleteAuthor(ctx context.Context, id string) (err error) {
 
 	return
 }
+
+// EnrichAuthor fills in the identifiers, biography and photo of the author from its profile
+// in the external catalog, the fields edited by hand are left as they are
+func (s *Service) EnrichAuthor(ctx context.Context, id string) (res author.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("EnrichAuthor").With(zap.String("id", id))
+
+	if s.profileProvider == nil {
+		err = author.ErrorEnrichmentDisabled
+		return
+	}
+
+	data, err := s.authorRepository.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+
+	ids := author.IdentifiersOf(data)
+	if ids.Empty() {
+		err = author.ErrorNoIdentifier
+		return
+	}
+
+	profile, err := s.profileProvider.Lookup(ctx, ids)
+	if err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			err = author.ErrorNoProfile
+			return
+		}
+		logger.Error("failed to look up profile", zap.Error(err))
+		return
+	}
+
+	if changes, ok := author.Enrich(data, profile); ok {
+		if err = s.authorRepository.Update(ctx, id, changes); err != nil {
+			logger.Error("failed to update by id", zap.Error(err))
+			return
+		}
+
+		if data, err = s.authorRepository.Get(ctx, id); err != nil {
+			logger.Error("failed to get by id", zap.Error(err))
+			return
+		}
+	}
+	res = author.ParseFromEntity(data)
+
+	return
+}
+
+func parseAuthorRequest(req author.Request) author.Entity {
+	return author.Entity{
+		FullName:  &req.FullName,
+		Pseudonym: &req.Pseudonym,
+		Specialty: &req.Specialty,
+		ORCID:     &req.ORCID,
+		VIAF:      &req.VIAF,
+		Wikipedia: &req.Wikipedia,
+		Biography: &req.Biography,
+		Photo:     &req.Photo,
+	}
+}
@@ -3,11 +3,15 @@ package library
 import (
 	"context"
 	"errors"
+	"sort"
+	"strings"
 
 	"go.uber.org/zap"
 
 	"library-service/internal/domain/author"
 	"library-service/internal/domain/book"
+	"library-service/internal/domain/category"
+	"library-service/internal/domain/series"
 	"library-service/pkg/log"
 	"library-service/pkg/store"
 )
@@ -20,19 +24,160 @@ func (s *Service) ListBooks(ctx context.Context) (res []book.Response, err error
 		logger.Error("failed to select", zap.Error(err))
 		return
 	}
-	res = book.ParseFromEntities(data)
+	res = s.withCovers(data, book.ParseFromEntities(data))
+
+	return
+}
+
+// FilterBooks lists a page of the books in the category path, subcategories included, that have every tag and the ISBN
+// of the filter, the books of a series are listed in reading order
+func (s *Service) FilterBooks(ctx context.Context, filter book.Filter, limit, offset int) (res []book.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("FilterBooks").With(zap.String("category", filter.Category), zap.Strings("tags", filter.Tags), zap.String("series", filter.Series), zap.String("isbn", filter.ISBN.String()))
+
+	if err = s.prepareFilter(ctx, &filter); err != nil {
+		if !errors.Is(err, category.ErrorUnknown) && !errors.Is(err, series.ErrorUnknown) {
+			logger.Error("failed to prepare filter", zap.Error(err))
+		}
+		return
+	}
+
+	data, err := s.bookRepository.Filter(ctx, filter, limit, offset)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = s.withCovers(data, book.ParseFromEntities(data))
+
+	return
+}
+
+// EachBook calls fn with every book of the filter in the order of their ids, the books are read one at a time
+// so that exporting the catalog doesn't load it whole. The filter is checked before fn is first called,
+// and an error of fn stops the iteration and is returned.
+func (s *Service) EachBook(ctx context.Context, filter book.Filter, fn func(data book.Response) error) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("EachBook").With(zap.String("category", filter.Category), zap.Strings("tags", filter.Tags), zap.String("series", filter.Series), zap.String("isbn", filter.ISBN.String()))
+
+	if err = s.prepareFilter(ctx, &filter); err != nil {
+		if !errors.Is(err, category.ErrorUnknown) && !errors.Is(err, series.ErrorUnknown) {
+			logger.Error("failed to prepare filter", zap.Error(err))
+		}
+		return
+	}
+
+	var failed error
+	err = s.bookRepository.Each(ctx, func(data book.Entity) error {
+		if !filter.Match(data) {
+			return nil
+		}
+
+		failed = fn(s.withCover(data, book.ParseFromEntity(data)))
+		return failed
+	})
+	if err != nil && failed == nil {
+		logger.Error("failed to select", zap.Error(err))
+	}
+
+	return
+}
+
+// prepareFilter normalizes the tags of the filter, checks its category and series and sets
+// the ids of the category and its subcategories
+func (s *Service) prepareFilter(ctx context.Context, filter *book.Filter) (err error) {
+	if filter.Category != "" {
+		categories, err := s.categoryRepository.List(ctx)
+		if err != nil {
+			return err
+		}
+		tree := category.NewTree(categories)
+
+		id, ok := tree.Find(filter.Category)
+		if !ok {
+			return category.ErrorUnknown
+		}
+
+		filter.Categories = make([]string, 0)
+		for id := range tree.Subtree(id) {
+			filter.Categories = append(filter.Categories, id)
+		}
+		sort.Strings(filter.Categories)
+	}
+	filter.Tags = book.NormalizeTags(filter.Tags)
+
+	if filter.Series != "" {
+		if err = s.checkSeries(ctx, filter.Series); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (s *Service) SearchBooks(ctx context.Context, query string, limit, offset int) (res []book.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("SearchBooks").With(zap.String("query", query))
+
+	data, err := s.bookRepository.Search(ctx, query, limit, offset)
+	if err != nil {
+		logger.Error("failed to search", zap.Error(err))
+		return
+	}
+	res = s.withCovers(data, book.ParseFromEntities(data))
 
 	return
 }
 
 func (s *Service) CreateBook(ctx context.Context, req book.Request) (res book.Response, err error) {
-	logger := log.LoggerFromContext(ctx).Named("CreateBook")
+	logger := log.LoggerFromContext(ctx).Named("CreateBook").With(zap.String("isbn", req.ISBN.String()))
+
+	if (req.Name == "" || req.Genre == "") && s.metadataProvider != nil {
+		if req, err = s.fillFromMetadata(ctx, req); err != nil {
+			logger.Error("failed to fill from metadata", zap.Error(err))
+			return
+		}
+	}
+
+	if req.Name == "" || req.Genre == "" {
+		err = book.ErrorIncomplete
+		return
+	}
+
+	if err = s.checkCategories(ctx, req.Categories); err != nil {
+		if !errors.Is(err, category.ErrorUnknown) {
+			logger.Error("failed to check categories", zap.Error(err))
+		}
+		return
+	}
 
 	data := book.Entity{
 		Name:    &req.Name,
 		Genre:   &req.Genre,
 		ISBN:    &req.ISBN,
 		Authors: req.Authors,
+
+		Categories: req.Categories,
+		Tags:       book.NormalizeTags(req.Tags),
+	}
+
+	if req.Year > 0 {
+		data.Year = &req.Year
+	}
+
+	if req.Cover != "" {
+		data.Cover = &req.Cover
+	}
+
+	if req.Description != "" {
+		data.Description = &req.Description
+	}
+
+	if req.SeriesID != "" {
+		data.SeriesID, data.Volume = &req.SeriesID, &req.Volume
+	}
+
+	if err = s.checkVolume(ctx, "", req.SeriesID, data.Volume); err != nil {
+		if !isSeriesError(err) {
+			logger.Error("failed to check volume", zap.Error(err))
+		}
+		return
 	}
 
 	data.ID, err = s.bookRepository.Add(ctx, data)
@@ -40,7 +185,8 @@ func (s *Service) CreateBook(ctx context.Context, req book.Request) (res book.Re
 		logger.Error("failed to create", zap.Error(err))
 		return
 	}
-	res = book.ParseFromEntity(data)
+	s.recordRevision(ctx, data.ID, book.ActionCreate, nil)
+	res = s.withCover(data, book.ParseFromEntity(data))
 
 	return
 }
@@ -49,11 +195,31 @@ func (s *Service) GetBook(ctx context.Context, id string) (res book.Response, er
 	logger := log.LoggerFromContext(ctx).Named("GetBook").With(zap.String("id", id))
 
 	data, err := s.bookRepository.Get(ctx, id)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
-		logger.Error("failed to get by id", zap.Error(err))
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
 		return
 	}
-	res = book.ParseFromEntity(data)
+	res = s.withCover(data, book.ParseFromEntity(data))
+
+	if data.SeriesID != nil {
+		volumes, err := s.listVolumes(ctx, *data.SeriesID)
+		if err != nil {
+			logger.Error("failed to select volumes", zap.Error(err))
+			return res, err
+		}
+		res.Prev, res.Next = book.Neighbours(volumes, id)
+	}
+
+	// the related books are extra to the detail, the book is returned without them when they fail
+	if s.relationRepository != nil {
+		related, err := s.relatedBooks(ctx, id)
+		if err != nil {
+			logger.Error("failed to select related books", zap.Error(err))
+		}
+		res.Related = related
+	}
 
 	return
 }
@@ -61,18 +227,127 @@ func (s *Service) GetBook(ctx context.Context, id string) (res book.Response, er
 func (s *Service) UpdateBook(ctx context.Context, id string, req book.Request) (err error) {
 	logger := log.LoggerFromContext(ctx).Named("UpdateBook").With(zap.String("id", id))
 
+	if req.Name == "" || req.Genre == "" {
+		err = book.ErrorIncomplete
+		return
+	}
+
+	if err = s.checkCategories(ctx, req.Categories); err != nil {
+		if !errors.Is(err, category.ErrorUnknown) {
+			logger.Error("failed to check categories", zap.Error(err))
+		}
+		return
+	}
+
 	data := book.Entity{
 		Name:    &req.Name,
 		Genre:   &req.Genre,
 		ISBN:    &req.ISBN,
 		Authors: req.Authors,
+
+		Categories: req.Categories,
+		Tags:       book.NormalizeTags(req.Tags),
 	}
 
+	// the book is replaced, so the lists left out of the request are cleared
+	if data.Authors == nil {
+		data.Authors = []string{}
+	}
+
+	if data.Categories == nil {
+		data.Categories = []string{}
+	}
+
+	if data.Tags == nil {
+		data.Tags = []string{}
+	}
+
+	if req.Year > 0 {
+		data.Year = &req.Year
+	}
+
+	if req.Cover != "" {
+		data.Cover = &req.Cover
+	}
+
+	if req.Description != "" {
+		data.Description = &req.Description
+	}
+
+	// a blank series takes the book out of its series
+	data.SeriesID = &req.SeriesID
+	if req.Volume > 0 {
+		data.Volume = &req.Volume
+	}
+
+	if err = s.checkVolume(ctx, id, req.SeriesID, data.Volume); err != nil {
+		if !isSeriesError(err) {
+			logger.Error("failed to check volume", zap.Error(err))
+		}
+		return
+	}
+
+	before := s.snapshotOf(ctx, id)
 	err = s.bookRepository.Update(ctx, id, data)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
-		logger.Error("failed to update by id", zap.Error(err))
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to update by id", zap.Error(err))
+		}
 		return
 	}
+	s.recordRevision(ctx, id, book.ActionUpdate, before)
+
+	return
+}
+
+func (s *Service) PatchBook(ctx context.Context, id string, req book.PatchRequest) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("PatchBook").With(zap.String("id", id))
+
+	data := book.Entity{
+		Name:  req.Name,
+		Genre: req.Genre,
+		ISBN:  req.ISBN,
+		Year:  req.Year,
+		Cover: req.Cover,
+
+		Description: req.Description,
+	}
+	if req.Authors != nil {
+		data.Authors = *req.Authors
+	}
+
+	if req.Categories != nil {
+		if err = s.checkCategories(ctx, *req.Categories); err != nil {
+			if !errors.Is(err, category.ErrorUnknown) {
+				logger.Error("failed to check categories", zap.Error(err))
+			}
+			return
+		}
+		data.Categories = *req.Categories
+	}
+
+	if req.Tags != nil {
+		data.Tags = book.NormalizeTags(*req.Tags)
+	}
+
+	if req.SeriesID != nil || req.Volume != nil {
+		if data.SeriesID, data.Volume, err = s.patchVolume(ctx, id, req); err != nil {
+			if !errors.Is(err, store.ErrorNotFound) && !isSeriesError(err) {
+				logger.Error("failed to check volume", zap.Error(err))
+			}
+			return
+		}
+	}
+
+	before := s.snapshotOf(ctx, id)
+	err = s.bookRepository.Update(ctx, id, data)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to patch by id", zap.Error(err))
+		}
+		return
+	}
+	s.recordRevision(ctx, id, book.ActionUpdate, before)
 
 	return
 }
@@ -80,11 +355,44 @@ func (s *Service) UpdateBook(ctx context.Context, id string, req book.Request) (
 func (s *Service) DeleteBook(ctx context.Context, id string) (err error) {
 	logger := log.LoggerFromContext(ctx).Named("DeleteBook").With(zap.String("id", id))
 
+	before := s.snapshotOf(ctx, id)
 	err = s.bookRepository.Delete(ctx, id)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
-		logger.Error("failed to delete by id", zap.Error(err))
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to delete by id", zap.Error(err))
+		}
 		return
 	}
+	s.recordRevision(ctx, id, book.ActionDelete, before)
+
+	return
+}
+
+// ListBooksWithDeleted lists the deleted books along with the rest for the admins
+func (s *Service) ListBooksWithDeleted(ctx context.Context) (res []book.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListBooksWithDeleted")
+
+	data, err := s.bookRepository.ListWithDeleted(ctx)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = s.withCovers(data, book.ParseFromEntities(data))
+
+	return
+}
+
+func (s *Service) RestoreBook(ctx context.Context, id string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("RestoreBook").With(zap.String("id", id))
+
+	err = s.bookRepository.Restore(ctx, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to restore by id", zap.Error(err))
+		}
+		return
+	}
+	s.recordRevision(ctx, id, book.ActionRestore, nil)
 
 	return
 }
@@ -93,8 +401,10 @@ func (s *Service) ListBookAuthors(ctx context.Context, id string) (res []author.
 	logger := log.LoggerFromContext(ctx).Named("ListBookAuthors").With(zap.String("id", id))
 
 	data, err := s.bookRepository.Get(ctx, id)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
-		logger.Error("failed to get by id", zap.Error(err))
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
 		return
 	}
 	res = make([]author.Response, len(data.Authors))
@@ -109,3 +419,105 @@ func (s *Service) ListBookAuthors(ctx context.Context, id string) (res []author.
 
 	return
 }
+
+// patchVolume merges the series and volume of the patch into the ones of the book, they are updated together
+func (s *Service) patchVolume(ctx context.Context, id string, req book.PatchRequest) (seriesID *string, volume *int, err error) {
+	current, err := s.bookRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+	seriesID, volume = current.SeriesID, current.Volume
+
+	if req.SeriesID != nil {
+		seriesID = req.SeriesID
+		if *req.SeriesID == "" {
+			volume = nil
+		}
+	}
+
+	if req.Volume != nil {
+		volume = req.Volume
+	}
+
+	if seriesID == nil {
+		if volume != nil {
+			err = series.ErrorUnknown
+		}
+		return
+	}
+	err = s.checkVolume(ctx, id, *seriesID, volume)
+
+	return
+}
+
+func isSeriesError(err error) bool {
+	return errors.Is(err, series.ErrorUnknown) || errors.Is(err, series.ErrorNoVolume) || errors.Is(err, series.ErrorVolumeTaken)
+}
+
+// fillFromMetadata completes the blank fields of the request from the ISBN metadata, an unknown ISBN leaves it as is
+func (s *Service) fillFromMetadata(ctx context.Context, req book.Request) (res book.Request, err error) {
+	res = req
+
+	data, err := s.metadataProvider.Lookup(ctx, req.ISBN)
+	if err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			err = nil
+		}
+		return
+	}
+
+	if res.Name == "" {
+		res.Name = data.Title
+	}
+
+	if res.Genre == "" {
+		res.Genre = data.Genre
+	}
+
+	if res.Year == 0 {
+		res.Year = data.Year
+	}
+
+	if res.Cover == "" {
+		res.Cover = data.CoverURL
+	}
+
+	if len(res.Authors) == 0 && len(data.Authors) > 0 {
+		res.Authors, err = s.resolveAuthors(ctx, data.Authors)
+	}
+
+	return
+}
+
+// resolveAuthors returns the ids of the authors with the given names, adding the ones that aren't known yet
+func (s *Service) resolveAuthors(ctx context.Context, names []string) (ids []string, err error) {
+	authors, err := s.authorRepository.List(ctx)
+	if err != nil {
+		return
+	}
+
+	known := make(map[string]string, len(authors))
+	for _, data := range authors {
+		known[strings.ToLower(*data.FullName)] = data.ID
+	}
+
+	for _, name := range names {
+		id, ok := known[strings.ToLower(name)]
+		if !ok {
+			fullName, specialty := name, ""
+			data := author.Entity{
+				FullName:  &fullName,
+				Pseudonym: &fullName,
+				Specialty: &specialty,
+			}
+
+			if id, err = s.authorRepository.Add(ctx, data); err != nil {
+				return
+			}
+			known[strings.ToLower(name)] = id
+		}
+		ids = append(ids, id)
+	}
+
+	return
+}
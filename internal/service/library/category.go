@@ -0,0 +1,183 @@
+package library
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"library-service/internal/domain/category"
+	"library-service/pkg/log"
+	"library-service/pkg/store"
+)
+
+func (s *Service) ListCategories(ctx context.Context) (res []category.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListCategories")
+
+	data, err := s.categoryRepository.List(ctx)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = category.ParseFromEntities(data)
+
+	return
+}
+
+func (s *Service) CreateCategory(ctx context.Context, req category.Request) (res category.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("CreateCategory").With(zap.String("name", req.Name))
+
+	categories, err := s.categoryRepository.List(ctx)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+
+	slug := category.Slugify(req.Name)
+	if err = checkCategoryParent(categories, "", req.ParentID, slug); err != nil {
+		return
+	}
+
+	data := category.Entity{
+		Name:     &req.Name,
+		Slug:     &slug,
+		ParentID: &req.ParentID,
+	}
+
+	data.ID, err = s.categoryRepository.Add(ctx, data)
+	if err != nil {
+		logger.Error("failed to create", zap.Error(err))
+		return
+	}
+
+	if req.ParentID == "" {
+		data.ParentID = nil
+	}
+	res = category.ParseFromEntity(data, category.NewTree(append(categories, data)))
+
+	return
+}
+
+// UpdateCategory renames the category and moves it under the parent, a blank parent makes it a root
+func (s *Service) UpdateCategory(ctx context.Context, id string, req category.Request) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("UpdateCategory").With(zap.String("id", id))
+
+	categories, err := s.categoryRepository.List(ctx)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+
+	if !category.NewTree(categories).Has(id) {
+		err = store.ErrorNotFound
+		return
+	}
+
+	slug := category.Slugify(req.Name)
+	if err = checkCategoryParent(categories, id, req.ParentID, slug); err != nil {
+		return
+	}
+
+	data := category.Entity{
+		Name:     &req.Name,
+		Slug:     &slug,
+		ParentID: &req.ParentID,
+	}
+
+	err = s.categoryRepository.Update(ctx, id, data)
+	if err != nil && !errors.Is(err, store.ErrorNotFound) {
+		logger.Error("failed to update by id", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// DeleteCategory refuses to delete a category that still has subcategories or books
+func (s *Service) DeleteCategory(ctx context.Context, id string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("DeleteCategory").With(zap.String("id", id))
+
+	categories, err := s.categoryRepository.List(ctx)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+
+	if category.NewTree(categories).HasChildren(id) {
+		err = category.ErrorInUse
+		return
+	}
+
+	books, err := s.bookRepository.ListWithDeleted(ctx)
+	if err != nil {
+		logger.Error("failed to select books", zap.Error(err))
+		return
+	}
+
+	for _, data := range books {
+		for _, categoryID := range data.Categories {
+			if categoryID == id {
+				err = category.ErrorInUse
+				return
+			}
+		}
+	}
+
+	err = s.categoryRepository.Delete(ctx, id)
+	if err != nil && !errors.Is(err, store.ErrorNotFound) {
+		logger.Error("failed to delete by id", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// checkCategories makes sure the books are only put in existing categories
+func (s *Service) checkCategories(ctx context.Context, ids []string) (err error) {
+	if len(ids) == 0 {
+		return
+	}
+
+	categories, err := s.categoryRepository.List(ctx)
+	if err != nil {
+		return
+	}
+	tree := category.NewTree(categories)
+
+	for _, id := range ids {
+		if !tree.Has(id) {
+			return category.ErrorUnknown
+		}
+	}
+
+	return
+}
+
+// checkCategoryParent makes sure the parent exists, isn't the category or one of its
+// subcategories and has no other subcategory with the slug
+func checkCategoryParent(categories []category.Entity, id, parentID, slug string) error {
+	tree := category.NewTree(categories)
+
+	if parentID != "" {
+		if !tree.Has(parentID) {
+			return category.ErrorUnknown
+		}
+
+		if id != "" && tree.Subtree(id)[parentID] {
+			return category.ErrorCycle
+		}
+	}
+
+	for _, data := range categories {
+		parent := ""
+		if data.ParentID != nil {
+			parent = *data.ParentID
+		}
+
+		if data.ID != id && parent == parentID && *data.Slug == slug {
+			return category.ErrorExists
+		}
+	}
+
+	return nil
+}
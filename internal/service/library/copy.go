@@ -0,0 +1,244 @@
+package library
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/log"
+	"library-service/pkg/store"
+)
+
+// ListBookCopies returns the copies of the book, the copies of a deleted book are not found
+func (s *Service) ListBookCopies(ctx context.Context, bookID string) (res []book.CopyResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListBookCopies").With(zap.String("book_id", bookID))
+
+	if _, err = s.bookRepository.Get(ctx, bookID); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get book by id", zap.Error(err))
+		}
+		return
+	}
+
+	data, err := s.copyRepository.List(ctx, bookID)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = book.ParseFromCopies(data)
+
+	return
+}
+
+func (s *Service) AddBookCopy(ctx context.Context, bookID string, req book.CopyRequest) (res book.CopyResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("AddBookCopy").With(zap.String("book_id", bookID))
+
+	if _, err = s.bookRepository.Get(ctx, bookID); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get book by id", zap.Error(err))
+		}
+		return
+	}
+
+	data := book.Copy{
+		BookID:    bookID,
+		Barcode:   &req.Barcode,
+		Condition: &req.Condition,
+		Location:  &req.Location,
+		Status:    &req.Status,
+	}
+
+	data.ID, err = s.copyRepository.Add(ctx, data)
+	if err != nil {
+		logger.Error("failed to create", zap.Error(err))
+		return
+	}
+	s.forgetAvailability(ctx, bookID)
+	res = book.ParseFromCopy(data)
+
+	if req.Status == book.CopyAvailable {
+		s.notifyWatchers(ctx, bookID)
+	}
+
+	return
+}
+
+func (s *Service) GetBookCopy(ctx context.Context, bookID, id string) (res book.CopyResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetBookCopy").With(zap.String("book_id", bookID), zap.String("id", id))
+
+	data, err := s.getBookCopy(ctx, bookID, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+	res = book.ParseFromCopy(data)
+
+	return
+}
+
+func (s *Service) UpdateBookCopy(ctx context.Context, bookID, id string, req book.CopyRequest) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("UpdateBookCopy").With(zap.String("book_id", bookID), zap.String("id", id))
+
+	current, err := s.getBookCopy(ctx, bookID, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+
+	data := book.Copy{
+		Barcode:   &req.Barcode,
+		Condition: &req.Condition,
+		Location:  &req.Location,
+		Status:    &req.Status,
+	}
+
+	err = s.copyRepository.Update(ctx, id, data)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to update by id", zap.Error(err))
+		}
+		return
+	}
+	s.forgetAvailability(ctx, bookID)
+
+	if req.Status == book.CopyCheckedOut && (current.Status == nil || *current.Status != book.CopyCheckedOut) {
+		s.recordCheckout(ctx, bookID, id)
+	}
+
+	// a returned copy, or one found again, is free for the members watching the book
+	if req.Status == book.CopyAvailable && (current.Status == nil || *current.Status != book.CopyAvailable) {
+		s.notifyWatchers(ctx, bookID)
+	}
+
+	return
+}
+
+func (s *Service) DeleteBookCopy(ctx context.Context, bookID, id string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("DeleteBookCopy").With(zap.String("book_id", bookID), zap.String("id", id))
+
+	current, err := s.getBookCopy(ctx, bookID, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+
+	// the loan of a checked out copy is returned onto it
+	if current.Status != nil && *current.Status == book.CopyCheckedOut {
+		err = book.ErrorCheckedOut
+		return
+	}
+
+	err = s.copyRepository.Delete(ctx, id)
+	if err != nil && !errors.Is(err, store.ErrorNotFound) {
+		logger.Error("failed to delete by id", zap.Error(err))
+		return
+	}
+	s.forgetAvailability(ctx, bookID)
+
+	return
+}
+
+// GetBookAvailability counts the copies that can be checked out, lost and withdrawn copies are left out
+func (s *Service) GetBookAvailability(ctx context.Context, bookID string) (res book.Availability, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetBookAvailability").With(zap.String("book_id", bookID))
+
+	if _, err = s.bookRepository.Get(ctx, bookID); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get book by id", zap.Error(err))
+		}
+		return
+	}
+
+	data, err := s.copyRepository.List(ctx, bookID)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = book.ParseAvailability(bookID, data)
+
+	return
+}
+
+// ListBooksAvailability counts the copies of many books at once, the books are returned
+// in the order of the ids with the repeated ones, the deleted ones and the ids of no book left out
+func (s *Service) ListBooksAvailability(ctx context.Context, bookIDs []string) (res []book.Availability, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListBooksAvailability").With(zap.Int("books", len(bookIDs)))
+
+	books, err := s.bookRepository.ListByIDs(ctx, bookIDs)
+	if err != nil {
+		logger.Error("failed to select books", zap.Error(err))
+		return
+	}
+
+	live := make(map[string]bool, len(books))
+	for _, object := range books {
+		live[object.ID] = true
+	}
+
+	ids := make([]string, 0, len(bookIDs))
+	seen := make(map[string]bool, len(bookIDs))
+	for _, id := range bookIDs {
+		if live[id] && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	var data map[string]book.Availability
+	if s.availabilityCache != nil {
+		data, err = s.availabilityCache.GetMany(ctx, ids)
+	} else {
+		var copies []book.Copy
+		if copies, err = s.copyRepository.ListByBooks(ctx, ids); err == nil {
+			data = book.ParseAvailabilities(ids, copies)
+		}
+	}
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+
+	res = make([]book.Availability, len(ids))
+	for i, id := range ids {
+		res[i] = data[id]
+	}
+
+	return
+}
+
+// getBookCopy treats a copy of another book, or of a deleted one, as not found
+// forgetAvailability drops the cached availability of the book whose copies changed, so that the catalog
+// doesn't show a copy that was just checked out as available for the ttl of the cache
+func (s *Service) forgetAvailability(ctx context.Context, bookID string) {
+	if s.availabilityCache == nil {
+		return
+	}
+
+	if err := s.availabilityCache.Delete(ctx, bookID); err != nil {
+		log.LoggerFromContext(ctx).Named("forgetAvailability").Error("failed to delete from cache", zap.String("book_id", bookID), zap.Error(err))
+	}
+}
+
+func (s *Service) getBookCopy(ctx context.Context, bookID, id string) (dest book.Copy, err error) {
+	dest, err = s.copyRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+
+	if dest.BookID != bookID {
+		err = store.ErrorNotFound
+		return
+	}
+
+	_, err = s.bookRepository.Get(ctx, bookID)
+
+	return
+}
@@ -0,0 +1,95 @@
+package library
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+
+	"go.uber.org/zap"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/log"
+	"library-service/pkg/store"
+	"library-service/pkg/thumbnail"
+)
+
+const coverQuality = 85
+
+// UploadBookCover resizes the image to the cover variants, stores them and points the book at them
+func (s *Service) UploadBookCover(ctx context.Context, id string, src io.Reader) (res book.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("UploadBookCover").With(zap.String("id", id))
+
+	data, err := s.bookRepository.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+
+	content, err := io.ReadAll(src)
+	if err != nil {
+		logger.Error("failed to read", zap.Error(err))
+		return
+	}
+
+	// the size is read from the header first, so that the image is only decoded if it fits
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil || cfg.Width > book.MaxCoverWidth || cfg.Height > book.MaxCoverHeight {
+		err = book.ErrorInvalidCover
+		return
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		err = book.ErrorInvalidCover
+		return
+	}
+
+	prefix := book.CoverPrefix(id, content)
+	for _, variant := range []book.CoverVariant{book.CoverThumbnail, book.CoverDetail} {
+		var buf bytes.Buffer
+		if err = jpeg.Encode(&buf, thumbnail.Fit(img, variant.Width, variant.Height), &jpeg.Options{Quality: coverQuality}); err != nil {
+			logger.Error("failed to encode", zap.String("variant", variant.Name), zap.Error(err))
+			return
+		}
+
+		if err = s.coverStorage.Put(ctx, book.CoverKey(prefix, variant), &buf); err != nil {
+			logger.Error("failed to store", zap.String("variant", variant.Name), zap.Error(err))
+			return
+		}
+	}
+
+	if err = s.bookRepository.Update(ctx, id, book.Entity{CoverKey: &prefix}); err != nil {
+		logger.Error("failed to update by id", zap.Error(err))
+		return
+	}
+	data.CoverKey = &prefix
+	res = s.withCover(data, book.ParseFromEntity(data))
+
+	return
+}
+
+// withCover replaces the cover of the response with signed urls of the uploaded cover, if any.
+// The variants are only encoded as jpeg, so there is no other format to pick by the Accept header.
+func (s *Service) withCover(data book.Entity, res book.Response) book.Response {
+	if data.CoverKey == nil || *data.CoverKey == "" || s.coverSigner == nil {
+		return res
+	}
+	res.Cover = s.coverSigner.URL(book.CoverKey(*data.CoverKey, book.CoverDetail))
+	res.Thumbnail = s.coverSigner.URL(book.CoverKey(*data.CoverKey, book.CoverThumbnail))
+
+	return res
+}
+
+func (s *Service) withCovers(data []book.Entity, res []book.Response) []book.Response {
+	for i := range res {
+		res[i] = s.withCover(data[i], res[i])
+	}
+
+	return res
+}
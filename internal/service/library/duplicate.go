@@ -0,0 +1,61 @@
+package library
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/log"
+	"library-service/pkg/store"
+)
+
+// FindDuplicateBooks groups the books that are likely entered more than once, for the admins to merge
+func (s *Service) FindDuplicateBooks(ctx context.Context) (res []book.DuplicatesResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("FindDuplicateBooks")
+
+	data, err := s.bookRepository.List(ctx)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+
+	res = make([]book.DuplicatesResponse, 0)
+	for _, group := range book.FindDuplicates(data) {
+		res = append(res, book.ParseFromDuplicates(group, s.withCovers(group.Books, book.ParseFromEntities(group.Books))))
+	}
+
+	return
+}
+
+// MergeBooks folds the duplicate into the book, the copies, reviews and loans of the duplicate
+// move over to the book and the duplicate is deleted
+func (s *Service) MergeBooks(ctx context.Context, id, duplicateID string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("MergeBooks").With(zap.String("id", id), zap.String("duplicate_id", duplicateID))
+
+	if id == duplicateID {
+		err = book.ErrorSelfMerge
+		return
+	}
+
+	before := s.snapshotOf(ctx, duplicateID)
+	if err = s.mergeRepository.Merge(ctx, id, duplicateID); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to merge", zap.Error(err))
+		}
+		return
+	}
+	s.recordRevision(ctx, duplicateID, book.ActionDelete, before)
+
+	// the copies of the duplicate are the book's now
+	s.forgetAvailability(ctx, id)
+	s.forgetAvailability(ctx, duplicateID)
+
+	// the books are merged already, a stale rating is fixed by the next review
+	if err := s.refreshRating(ctx, id); err != nil {
+		logger.Error("failed to refresh rating", zap.Error(err))
+	}
+
+	return
+}
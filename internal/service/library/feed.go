@@ -0,0 +1,163 @@
+package library
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/log"
+	"library-service/pkg/worker"
+)
+
+// feedState holds the feeds of the last aggregation, the books are kept as entities
+// so that the signed urls of their covers are fresh on every read
+type feedState struct {
+	sync.RWMutex
+
+	size        int
+	newArrivals []book.Entity
+	trending    map[string][]book.Entity
+	updatedAt   time.Time
+}
+
+// ListNewArrivals returns a page of the books added to the catalog most recently
+func (s *Service) ListNewArrivals(ctx context.Context, limit, offset int) (res []book.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListNewArrivals")
+
+	if err = s.ensureFeeds(ctx); err != nil {
+		logger.Error("failed to aggregate feeds", zap.Error(err))
+		return
+	}
+
+	s.feeds.RLock()
+	data := pageOf(s.feeds.newArrivals, limit, offset)
+	s.feeds.RUnlock()
+	res = s.withCovers(data, book.ParseFromEntities(data))
+
+	return
+}
+
+// ListTrendingBooks returns a page of the books checked out most over the window, one of the book.TrendingWindows
+func (s *Service) ListTrendingBooks(ctx context.Context, window string, limit, offset int) (res []book.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListTrendingBooks").With(zap.String("window", window))
+
+	if _, ok := book.TrendingWindows[window]; !ok {
+		err = book.ErrorTrendingWindow
+		return
+	}
+
+	if err = s.ensureFeeds(ctx); err != nil {
+		logger.Error("failed to aggregate feeds", zap.Error(err))
+		return
+	}
+
+	s.feeds.RLock()
+	data := pageOf(s.feeds.trending[window], limit, offset)
+	s.feeds.RUnlock()
+	res = s.withCovers(data, book.ParseFromEntities(data))
+
+	return
+}
+
+// RunFeeds aggregates the feeds right away and then every interval until the context is done,
+// a failed aggregation keeps the feeds of the last one that succeeded. Every run is recorded with the worker.
+func (s *Service) RunFeeds(ctx context.Context, interval time.Duration, w *worker.Worker) {
+	logger := log.LoggerFromContext(ctx).Named("RunFeeds")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := w.Run(func() error {
+			return s.RefreshFeeds(ctx)
+		})
+		if err != nil && ctx.Err() == nil {
+			logger.Error("failed to aggregate feeds", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RefreshFeeds aggregates the new arrivals and counts the checkouts of every trending window
+func (s *Service) RefreshFeeds(ctx context.Context) (err error) {
+	data, err := s.bookRepository.List(ctx)
+	if err != nil {
+		return
+	}
+
+	size := s.feeds.size
+	if size <= 0 {
+		size = book.DefaultFeedSize
+	}
+
+	trending := make(map[string][]book.Entity, len(book.TrendingWindows))
+	if s.checkoutRepository != nil {
+		now := time.Now()
+		for window, period := range book.TrendingWindows {
+			counts, err := s.checkoutRepository.Count(ctx, now.Add(-period))
+			if err != nil {
+				return err
+			}
+			trending[window] = book.Trending(data, counts, size)
+		}
+	}
+	newArrivals := book.NewArrivals(data, size)
+
+	s.feeds.Lock()
+	s.feeds.newArrivals, s.feeds.trending, s.feeds.updatedAt = newArrivals, trending, time.Now()
+	s.feeds.Unlock()
+
+	return
+}
+
+// ensureFeeds aggregates the feeds on the first read if the background aggregation hasn't run yet
+func (s *Service) ensureFeeds(ctx context.Context) error {
+	s.feeds.RLock()
+	updated := !s.feeds.updatedAt.IsZero()
+	s.feeds.RUnlock()
+
+	if updated {
+		return nil
+	}
+
+	return s.RefreshFeeds(ctx)
+}
+
+// recordCheckout adds the checkout of the copy to the log the trending books are counted from,
+// the copy is already checked out so a failure to record it is only logged
+func (s *Service) recordCheckout(ctx context.Context, bookID, copyID string) {
+	if s.checkoutRepository == nil {
+		return
+	}
+	logger := log.LoggerFromContext(ctx).Named("recordCheckout").With(zap.String("book_id", bookID), zap.String("copy_id", copyID))
+
+	data := book.Checkout{
+		BookID: bookID,
+		CopyID: copyID,
+	}
+
+	if _, err := s.checkoutRepository.Add(ctx, data); err != nil {
+		logger.Error("failed to record checkout", zap.Error(err))
+	}
+}
+
+func pageOf(data []book.Entity, limit, offset int) []book.Entity {
+	if offset >= len(data) {
+		return nil
+	}
+	data = data[offset:]
+
+	if limit < len(data) {
+		data = data[:limit]
+	}
+
+	return data
+}
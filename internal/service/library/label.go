@@ -0,0 +1,180 @@
+package library
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+
+	"go.uber.org/zap"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/barcode"
+	"library-service/pkg/log"
+	"library-service/pkg/pdf"
+	"library-service/pkg/store"
+)
+
+// the labels are sized for the 62x29mm rolls of the label printers at the desk
+const (
+	labelWidth  = 62 * pdf.MM
+	labelHeight = 29 * pdf.MM
+	labelMargin = 2 * pdf.MM
+
+	// labelName is how many characters of the book name fit under the barcode
+	labelName = 36
+)
+
+// GetCopyByBarcode returns the copy the scanner at the checkout desk read the barcode of,
+// the copies of a deleted book are not found
+func (s *Service) GetCopyByBarcode(ctx context.Context, code string) (res book.CopyResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetCopyByBarcode").With(zap.String("barcode", code))
+
+	data, err := s.copyRepository.GetByBarcode(ctx, code)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by barcode", zap.Error(err))
+		}
+		return
+	}
+
+	if _, err = s.bookRepository.Get(ctx, data.BookID); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get book by id", zap.Error(err))
+		}
+		return
+	}
+	res = book.ParseFromCopy(data)
+
+	return
+}
+
+// RenderCopyLabel draws the label of the copy, a png only has the barcode while a pdf
+// prints the barcode and the name of the book under it as well
+func (s *Service) RenderCopyLabel(ctx context.Context, id string, symbology book.Symbology, format book.LabelFormat) (res []byte, err error) {
+	logger := log.LoggerFromContext(ctx).Named("RenderCopyLabel").With(zap.String("id", id))
+
+	if format == book.LabelPDF {
+		return s.RenderCopyLabels(ctx, []string{id}, symbology)
+	}
+
+	data, err := s.copyRepository.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+
+	if _, err = s.bookRepository.Get(ctx, data.BookID); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get book by id", zap.Error(err))
+		}
+		return
+	}
+
+	img, err := symbolOf(*data.Barcode, symbology)
+	if err != nil {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err = png.Encode(&buf, img); err != nil {
+		logger.Error("failed to encode", zap.Error(err))
+		return
+	}
+	res = buf.Bytes()
+
+	return
+}
+
+// RenderCopyLabels draws the labels of the copies into a pdf with one label per page in the order of the ids
+func (s *Service) RenderCopyLabels(ctx context.Context, ids []string, symbology book.Symbology) (res []byte, err error) {
+	logger := log.LoggerFromContext(ctx).Named("RenderCopyLabels").With(zap.Int("copies", len(ids)))
+
+	doc := pdf.New()
+	names := make(map[string]string)
+	for _, id := range ids {
+		var data book.Copy
+		if data, err = s.copyRepository.Get(ctx, id); err != nil {
+			if !errors.Is(err, store.ErrorNotFound) {
+				logger.Error("failed to get by id", zap.String("id", id), zap.Error(err))
+			}
+			return
+		}
+
+		name, ok := names[data.BookID]
+		if !ok {
+			var object book.Entity
+			if object, err = s.bookRepository.Get(ctx, data.BookID); err != nil {
+				if !errors.Is(err, store.ErrorNotFound) {
+					logger.Error("failed to get book by id", zap.String("book_id", data.BookID), zap.Error(err))
+				}
+				return
+			}
+
+			if object.Name != nil {
+				name = *object.Name
+			}
+			names[data.BookID] = name
+		}
+
+		var img *image.Gray
+		if img, err = symbolOf(*data.Barcode, symbology); err != nil {
+			return
+		}
+		drawLabel(doc.AddPage(labelWidth, labelHeight), img, symbology, *data.Barcode, truncate(name, labelName))
+	}
+
+	var buf bytes.Buffer
+	if err = doc.Write(&buf); err != nil {
+		logger.Error("failed to render", zap.Error(err))
+		return
+	}
+	res = buf.Bytes()
+
+	return
+}
+
+// symbolOf draws the barcode with the symbology at a resolution that prints sharp at 300dpi
+func symbolOf(code string, symbology book.Symbology) (*image.Gray, error) {
+	if symbology == book.SymbologyQR {
+		modules, err := barcode.QR(code)
+		if err != nil {
+			return nil, book.ErrorBarcode
+		}
+		return barcode.Matrix(modules, 8), nil
+	}
+
+	modules, err := barcode.Code128(code)
+	if err != nil {
+		return nil, book.ErrorBarcode
+	}
+	return barcode.Bars(modules, 3, 120), nil
+}
+
+// drawLabel puts a linear barcode across the label with the text under it
+// and a QR code on the left with the text beside it
+func drawLabel(page *pdf.Page, img *image.Gray, symbology book.Symbology, code, name string) {
+	if symbology == book.SymbologyQR {
+		side := labelHeight - 2*labelMargin
+		page.Image(img, labelMargin, labelMargin, side, side)
+		page.Text(side+2*labelMargin, labelHeight/2+2*pdf.MM, 9, code)
+		page.Text(side+2*labelMargin, labelHeight/2-3*pdf.MM, 6, truncate(name, labelName/2))
+		return
+	}
+
+	page.Image(img, labelMargin, 10*pdf.MM, labelWidth-2*labelMargin, labelHeight-10*pdf.MM-labelMargin)
+	page.Text(labelMargin+3*pdf.MM, 6*pdf.MM, 9, code)
+	page.Text(labelMargin+3*pdf.MM, labelMargin+pdf.MM, 6, name)
+}
+
+func truncate(text string, n int) string {
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+
+	return string(runes[:n-3]) + "..."
+}
@@ -0,0 +1,223 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"library-service/internal/domain/book"
+	"library-service/internal/domain/loan"
+	"library-service/pkg/log"
+	"library-service/pkg/store"
+)
+
+// ListLoans returns the loans of the member, the latest first, with only the ones still out when active is set
+func (s *Service) ListLoans(ctx context.Context, memberID string, active bool) (res []loan.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListLoans").With(zap.String("member_id", memberID))
+
+	if _, err = s.memberRepository.Get(ctx, memberID); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get member by id", zap.Error(err))
+		}
+		return
+	}
+
+	data, err := s.loanRepository.List(ctx, memberID)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+
+	if active {
+		loans := make([]loan.Entity, 0, len(data))
+		for _, object := range data {
+			if object.Active() {
+				loans = append(loans, object)
+			}
+		}
+		data = loans
+	}
+	res = loan.ParseFromEntities(data)
+
+	return
+}
+
+func (s *Service) GetLoan(ctx context.Context, memberID, id string) (res loan.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetLoan").With(zap.String("member_id", memberID), zap.String("id", id))
+
+	data, err := s.loanOf(ctx, memberID, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+	res = loan.ParseFromEntity(data)
+
+	return
+}
+
+// CheckoutBook lends the copy to the member until the due date of their tier, a member with as many books
+// on loan as the tier allows has to return one first
+func (s *Service) CheckoutBook(ctx context.Context, memberID string, req loan.Request) (res loan.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("CheckoutBook").With(zap.String("member_id", memberID), zap.String("copy_id", req.CopyID))
+
+	borrower, err := s.memberRepository.Get(ctx, memberID)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get member by id", zap.Error(err))
+		}
+		return
+	}
+
+	object, err := s.copyRepository.Get(ctx, req.CopyID)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get copy by id", zap.Error(err))
+		}
+		return
+	}
+
+	if object.Status == nil || *object.Status != book.CopyAvailable {
+		err = loan.ErrorUnavailable
+		return
+	}
+
+	policy := loan.PolicyOf(borrower.Subscription())
+
+	now := time.Now()
+	data := loan.Entity{
+		MemberID:  memberID,
+		BookID:    object.BookID,
+		CopyID:    object.ID,
+		DueAt:     policy.DueDate(now),
+		CreatedAt: now,
+	}
+
+	// the limit is checked by the repository along with the loan added, so parallel checkouts can't exceed it
+	data.ID, err = s.loanRepository.Add(ctx, data, policy.Limit)
+	if err != nil {
+		if !errors.Is(err, loan.ErrorUnavailable) && !errors.Is(err, loan.ErrorLimit) {
+			logger.Error("failed to create", zap.Error(err))
+		}
+		return
+	}
+
+	// the loan is taken back when the copy can't be checked out, so that the copy isn't lent and available at once
+	status := book.CopyCheckedOut
+	if err = s.copyRepository.Update(ctx, object.ID, book.Copy{Status: &status}); err != nil {
+		logger.Error("failed to check out copy", zap.Error(err))
+		if deleteErr := s.loanRepository.Delete(ctx, data.ID); deleteErr != nil {
+			logger.Error("failed to delete the loan of the copy", zap.String("id", data.ID), zap.Error(deleteErr))
+		}
+		return
+	}
+	s.forgetAvailability(ctx, object.BookID)
+	s.recordCheckout(ctx, object.BookID, object.ID)
+	res = loan.ParseFromEntity(data)
+
+	return
+}
+
+// ReturnLoan takes the copy back from the member, it is free for the members watching the book again
+func (s *Service) ReturnLoan(ctx context.Context, memberID, id string) (res loan.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ReturnLoan").With(zap.String("member_id", memberID), zap.String("id", id))
+
+	data, err := s.loanOf(ctx, memberID, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+
+	if !data.Active() {
+		err = loan.ErrorReturned
+		return
+	}
+
+	// the copy is put back before the loan is returned, a copy deleted while it was out has nothing to be put back on
+	status := book.CopyAvailable
+	if err = s.copyRepository.Update(ctx, data.CopyID, book.Copy{Status: &status}); err != nil && !errors.Is(err, store.ErrorNotFound) {
+		logger.Error("failed to return copy", zap.Error(err))
+		return
+	}
+	s.forgetAvailability(ctx, data.BookID)
+
+	now := time.Now()
+	data.ReturnedAt = &now
+
+	if err = s.loanRepository.Update(ctx, id, data); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to update by id", zap.Error(err))
+		}
+		return
+	}
+
+	s.notifyWatchers(ctx, data.BookID)
+	res = loan.ParseFromEntity(data)
+
+	return
+}
+
+// RenewLoan moves the due date of the loan to a full period of the member's tier from now,
+// an overdue loan has to be returned instead
+func (s *Service) RenewLoan(ctx context.Context, memberID, id string) (res loan.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("RenewLoan").With(zap.String("member_id", memberID), zap.String("id", id))
+
+	borrower, err := s.memberRepository.Get(ctx, memberID)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get member by id", zap.Error(err))
+		}
+		return
+	}
+
+	data, err := s.loanOf(ctx, memberID, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+
+	now := time.Now()
+	policy := loan.PolicyOf(borrower.Subscription())
+	switch {
+	case !data.Active():
+		err = loan.ErrorReturned
+	case data.Overdue(now):
+		err = loan.ErrorOverdue
+	case data.Renewals >= policy.Renewals:
+		err = loan.ErrorRenewals
+	}
+	if err != nil {
+		return
+	}
+	data.DueAt, data.Renewals = policy.DueDate(now), data.Renewals+1
+
+	if err = s.loanRepository.Update(ctx, id, data); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to update by id", zap.Error(err))
+		}
+		return
+	}
+	res = loan.ParseFromEntity(data)
+
+	return
+}
+
+// loanOf returns the loan with the id, a loan of another member is not found
+func (s *Service) loanOf(ctx context.Context, memberID, id string) (data loan.Entity, err error) {
+	if data, err = s.loanRepository.Get(ctx, id); err != nil {
+		return
+	}
+
+	if data.MemberID != memberID {
+		err = store.ErrorNotFound
+	}
+
+	return
+}
@@ -0,0 +1,80 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/log"
+	"library-service/pkg/store"
+	"library-service/pkg/worker"
+)
+
+// RunRelations relates the books borrowed by the same members right away and then every interval
+// until the context is done, a failed run keeps the relations of the last one that succeeded.
+// Every run is recorded with the worker.
+func (s *Service) RunRelations(ctx context.Context, interval time.Duration, w *worker.Worker) {
+	logger := log.LoggerFromContext(ctx).Named("RunRelations")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := w.Run(func() error {
+			return s.RefreshRelations(ctx)
+		})
+		if err != nil && ctx.Err() == nil {
+			logger.Error("failed to relate books", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RefreshRelations pairs up the books on the lists of each member and replaces the related books with them
+func (s *Service) RefreshRelations(ctx context.Context) (err error) {
+	if s.relationRepository == nil {
+		return
+	}
+
+	members, err := s.memberRepository.List(ctx)
+	if err != nil {
+		return
+	}
+
+	borrowed := make([][]string, 0, len(members))
+	for _, object := range members {
+		borrowed = append(borrowed, object.Books)
+	}
+
+	return s.relationRepository.Replace(ctx, book.Relate(borrowed, book.MaxRelated))
+}
+
+// relatedBooks returns the books the readers of the book also borrowed, the books deleted from the
+// catalog since the relations were built are left out
+func (s *Service) relatedBooks(ctx context.Context, id string) (res []book.RelatedBook, err error) {
+	data, err := s.relationRepository.List(ctx, id)
+	if err != nil {
+		return
+	}
+
+	for _, relation := range data {
+		object, err := s.bookRepository.Get(ctx, relation.RelatedID)
+		if err != nil {
+			if errors.Is(err, store.ErrorNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		res = append(res, book.ParseFromRelation(s.withCover(object, book.ParseFromEntity(object)), relation))
+	}
+
+	return
+}
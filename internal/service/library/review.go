@@ -0,0 +1,205 @@
+package library
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"library-service/internal/domain/book"
+	"library-service/internal/domain/review"
+	"library-service/pkg/log"
+	"library-service/pkg/store"
+)
+
+// ListBookReviews returns the reviews of the book that are not hidden, the reviews of a deleted book are not found
+func (s *Service) ListBookReviews(ctx context.Context, bookID string) (res []review.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListBookReviews").With(zap.String("book_id", bookID))
+
+	if _, err = s.bookRepository.Get(ctx, bookID); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get book by id", zap.Error(err))
+		}
+		return
+	}
+
+	data, err := s.reviewRepository.List(ctx, bookID)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+
+	visible := make([]review.Entity, 0, len(data))
+	for _, object := range data {
+		if *object.Status != review.StatusHidden {
+			visible = append(visible, object)
+		}
+	}
+	res = review.ParseFromEntities(visible)
+
+	return
+}
+
+func (s *Service) AddBookReview(ctx context.Context, bookID string, req review.Request) (res review.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("AddBookReview").With(zap.String("book_id", bookID), zap.String("member_id", req.MemberID))
+
+	if _, err = s.bookRepository.Get(ctx, bookID); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get book by id", zap.Error(err))
+		}
+		return
+	}
+
+	if _, err = s.memberRepository.Get(ctx, req.MemberID); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get member by id", zap.Error(err))
+		}
+		return
+	}
+
+	reviews, err := s.reviewRepository.List(ctx, bookID)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+
+	for _, object := range reviews {
+		if object.MemberID == req.MemberID {
+			err = review.ErrorExists
+			return
+		}
+	}
+
+	status := review.StatusVisible
+	data := review.Entity{
+		BookID:   bookID,
+		MemberID: req.MemberID,
+		Rating:   &req.Rating,
+		Text:     &req.Text,
+		Status:   &status,
+	}
+
+	data.ID, err = s.reviewRepository.Add(ctx, data)
+	if err != nil {
+		if !errors.Is(err, review.ErrorExists) {
+			logger.Error("failed to create", zap.Error(err))
+		}
+		return
+	}
+
+	if err = s.refreshRating(ctx, bookID); err != nil {
+		logger.Error("failed to refresh rating", zap.Error(err))
+		return
+	}
+
+	if data, err = s.reviewRepository.Get(ctx, data.ID); err != nil {
+		logger.Error("failed to get by id", zap.Error(err))
+		return
+	}
+	res = review.ParseFromEntity(data)
+
+	return
+}
+
+// UpdateBookReview edits the rating and text of the review, only the member who wrote it may do so
+func (s *Service) UpdateBookReview(ctx context.Context, bookID, id string, req review.Request) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("UpdateBookReview").With(zap.String("book_id", bookID), zap.String("id", id))
+
+	current, err := s.getBookReview(ctx, bookID, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+
+	if current.MemberID != req.MemberID {
+		err = review.ErrorNotAuthor
+		return
+	}
+
+	data := review.Entity{
+		Rating: &req.Rating,
+		Text:   &req.Text,
+	}
+
+	if err = s.reviewRepository.Update(ctx, id, data); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to update by id", zap.Error(err))
+		}
+		return
+	}
+
+	if err = s.refreshRating(ctx, bookID); err != nil {
+		logger.Error("failed to refresh rating", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// ListReviewsByStatus returns the reviews of every book in the moderation state, e.g. the flagged ones
+func (s *Service) ListReviewsByStatus(ctx context.Context, status review.Status) (res []review.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListReviewsByStatus").With(zap.String("status", string(status)))
+
+	data, err := s.reviewRepository.ListByStatus(ctx, status)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = review.ParseFromEntities(data)
+
+	return
+}
+
+// ModerateReview flags, hides or restores the review, hidden reviews don't count towards the rating
+func (s *Service) ModerateReview(ctx context.Context, id string, req review.ModerationRequest) (res review.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ModerateReview").With(zap.String("id", id))
+
+	data, err := s.reviewRepository.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+
+	if err = s.reviewRepository.Update(ctx, id, review.Entity{Status: &req.Status}); err != nil {
+		logger.Error("failed to update by id", zap.Error(err))
+		return
+	}
+
+	if err = s.refreshRating(ctx, data.BookID); err != nil {
+		logger.Error("failed to refresh rating", zap.Error(err))
+		return
+	}
+	data.Status = &req.Status
+	res = review.ParseFromEntity(data)
+
+	return
+}
+
+// refreshRating stores the average rating and review count of the book on it
+func (s *Service) refreshRating(ctx context.Context, bookID string) (err error) {
+	data, err := s.reviewRepository.List(ctx, bookID)
+	if err != nil {
+		return
+	}
+	rating, count := review.Summarize(data)
+
+	return s.bookRepository.Update(ctx, bookID, book.Entity{Rating: &rating, ReviewCount: &count})
+}
+
+// getBookReview treats a review of another book as not found
+func (s *Service) getBookReview(ctx context.Context, bookID, id string) (dest review.Entity, err error) {
+	dest, err = s.reviewRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+
+	if dest.BookID != bookID {
+		err = store.ErrorNotFound
+	}
+
+	return
+}
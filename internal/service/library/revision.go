@@ -0,0 +1,103 @@
+package library
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-chi/oauth"
+	"go.uber.org/zap"
+
+	"library-service/internal/domain/book"
+	"library-service/pkg/log"
+	"library-service/pkg/store"
+)
+
+// ListBookRevisions returns the history of changes of the book, the newest first
+func (s *Service) ListBookRevisions(ctx context.Context, id string) (res []book.RevisionResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListBookRevisions").With(zap.String("id", id))
+
+	data, err := s.revisionRepository.List(ctx, id)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = book.ParseFromRevisions(data)
+
+	return
+}
+
+// RollbackBook undoes the change of the revision by putting the book back in its state before it
+func (s *Service) RollbackBook(ctx context.Context, id, revisionID string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("RollbackBook").With(zap.String("id", id), zap.String("revision_id", revisionID))
+
+	data, err := s.revisionRepository.Get(ctx, revisionID)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get revision by id", zap.Error(err))
+		}
+		return
+	}
+
+	if data.BookID != id {
+		err = store.ErrorNotFound
+		return
+	}
+
+	if data.Before == nil {
+		err = book.ErrorRollback
+		return
+	}
+
+	before := s.snapshotOf(ctx, id)
+	if data.Action == book.ActionDelete {
+		err = s.bookRepository.Restore(ctx, id)
+	} else {
+		err = s.bookRepository.Update(ctx, id, data.Before.Entity())
+	}
+
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to roll back by id", zap.Error(err))
+		}
+		return
+	}
+	s.recordRevision(ctx, id, book.ActionRollback, before)
+
+	return
+}
+
+// snapshotOf returns the current state of the book to record as the before of a revision
+func (s *Service) snapshotOf(ctx context.Context, id string) *book.Snapshot {
+	if s.revisionRepository == nil {
+		return nil
+	}
+
+	data, err := s.bookRepository.Get(ctx, id)
+	if err != nil {
+		return nil
+	}
+
+	return book.NewSnapshot(data)
+}
+
+// recordRevision adds the change of the book to its history, the change is already made
+// so a failure to record it is only logged
+func (s *Service) recordRevision(ctx context.Context, id string, action book.Action, before *book.Snapshot) {
+	if s.revisionRepository == nil {
+		return
+	}
+	logger := log.LoggerFromContext(ctx).Named("recordRevision").With(zap.String("id", id), zap.String("action", string(action)))
+
+	actor, _ := ctx.Value(oauth.CredentialContext).(string)
+	data := book.Revision{
+		BookID: id,
+		Action: action,
+		Actor:  actor,
+		Before: before,
+		After:  s.snapshotOf(ctx, id),
+	}
+
+	if _, err := s.revisionRepository.Add(ctx, data); err != nil {
+		logger.Error("failed to record revision", zap.Error(err))
+	}
+}
@@ -0,0 +1,177 @@
+package library
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"library-service/internal/domain/book"
+	"library-service/internal/domain/series"
+	"library-service/pkg/log"
+	"library-service/pkg/store"
+)
+
+func (s *Service) ListSeries(ctx context.Context) (res []series.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListSeries")
+
+	data, err := s.seriesRepository.List(ctx)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+
+	books, err := s.bookRepository.List(ctx)
+	if err != nil {
+		logger.Error("failed to select books", zap.Error(err))
+		return
+	}
+	res = series.ParseFromEntities(data, books)
+
+	return
+}
+
+func (s *Service) CreateSeries(ctx context.Context, req series.Request) (res series.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("CreateSeries").With(zap.String("name", req.Name))
+
+	data := series.Entity{
+		Name:        &req.Name,
+		Description: &req.Description,
+	}
+
+	data.ID, err = s.seriesRepository.Add(ctx, data)
+	if err != nil {
+		logger.Error("failed to create", zap.Error(err))
+		return
+	}
+	res = series.ParseFromEntity(data, nil)
+
+	return
+}
+
+// GetSeries returns the series with its volumes in reading order
+func (s *Service) GetSeries(ctx context.Context, id string) (res series.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetSeries").With(zap.String("id", id))
+
+	data, err := s.seriesRepository.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+
+	volumes, err := s.listVolumes(ctx, id)
+	if err != nil {
+		logger.Error("failed to select volumes", zap.Error(err))
+		return
+	}
+	res = series.ParseFromEntity(data, volumes)
+
+	return
+}
+
+func (s *Service) UpdateSeries(ctx context.Context, id string, req series.Request) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("UpdateSeries").With(zap.String("id", id))
+
+	data := series.Entity{
+		Name:        &req.Name,
+		Description: &req.Description,
+	}
+
+	err = s.seriesRepository.Update(ctx, id, data)
+	if err != nil && !errors.Is(err, store.ErrorNotFound) {
+		logger.Error("failed to update by id", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// DeleteSeries refuses to delete a series that still has volumes, deleted books included
+func (s *Service) DeleteSeries(ctx context.Context, id string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("DeleteSeries").With(zap.String("id", id))
+
+	books, err := s.bookRepository.ListWithDeleted(ctx)
+	if err != nil {
+		logger.Error("failed to select books", zap.Error(err))
+		return
+	}
+
+	for _, data := range books {
+		if data.SeriesID != nil && *data.SeriesID == id {
+			err = series.ErrorInUse
+			return
+		}
+	}
+
+	err = s.seriesRepository.Delete(ctx, id)
+	if err != nil && !errors.Is(err, store.ErrorNotFound) {
+		logger.Error("failed to delete by id", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// listVolumes lists the books of the series in reading order
+func (s *Service) listVolumes(ctx context.Context, seriesID string) (dest []book.Entity, err error) {
+	books, err := s.bookRepository.List(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, data := range books {
+		if data.SeriesID != nil && *data.SeriesID == seriesID {
+			dest = append(dest, data)
+		}
+	}
+	dest = book.ReadingOrder(dest)
+
+	return
+}
+
+// checkVolume makes sure the book with the id, blank for a new one, is put into an
+// existing series under a volume no other book of the series has
+func (s *Service) checkVolume(ctx context.Context, id, seriesID string, volume *int) (err error) {
+	if seriesID == "" {
+		return
+	}
+
+	if volume == nil || *volume <= 0 {
+		return series.ErrorNoVolume
+	}
+
+	if err = s.checkSeries(ctx, seriesID); err != nil {
+		return
+	}
+
+	volumes, err := s.listVolumes(ctx, seriesID)
+	if err != nil {
+		return
+	}
+
+	for _, data := range volumes {
+		if data.ID != id && data.Volume != nil && *data.Volume == *volume {
+			return series.ErrorVolumeTaken
+		}
+	}
+
+	return
+}
+
+// checkSeries makes sure the series exists
+func (s *Service) checkSeries(ctx context.Context, id string) (err error) {
+	data, err := s.seriesRepository.List(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, object := range data {
+		if object.ID == id {
+			return
+		}
+	}
+
+	return series.ErrorUnknown
+}
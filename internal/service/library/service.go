@@ -3,6 +3,15 @@ package library
 import (
 	"library-service/internal/domain/author"
 	"library-service/internal/domain/book"
+	"library-service/internal/domain/category"
+	"library-service/internal/domain/loan"
+	"library-service/internal/domain/member"
+	"library-service/internal/domain/review"
+	"library-service/internal/domain/series"
+	"library-service/internal/domain/shelf"
+	"library-service/internal/domain/suggestion"
+	"library-service/internal/domain/watch"
+	"library-service/pkg/storage"
 )
 
 // Configuration is an alias for a function that will take in a pointer to a Service and modify it
@@ -10,10 +19,35 @@ type Configuration func(s *Service) error
 
 // Service is an implementation of the Service
 type Service struct {
-	authorRepository author.Repository
-	bookRepository   book.Repository
-	authorCache      author.Cache
-	bookCache        book.Cache
+	authorRepository     author.Repository
+	bookRepository       book.Repository
+	categoryRepository   category.Repository
+	copyRepository       book.CopyRepository
+	checkoutRepository   book.CheckoutRepository
+	relationRepository   book.RelationRepository
+	revisionRepository   book.RevisionRepository
+	reviewRepository     review.Repository
+	memberRepository     member.Repository
+	loanRepository       loan.Repository
+	seriesRepository     series.Repository
+	mergeRepository      book.MergeRepository
+	suggestionRepository suggestion.Repository
+	watchRepository      watch.Repository
+	shelfRepository      shelf.Repository
+	authorCache          author.Cache
+	bookCache            book.Cache
+	availabilityCache    book.AvailabilityCache
+
+	metadataProvider book.MetadataProvider
+	profileProvider  author.ProfileProvider
+
+	suggestionNotifier suggestion.Notifier
+	watchNotifier      watch.Notifier
+
+	coverStorage storage.Storage
+	coverSigner  *storage.URLSigner
+
+	feeds feedState
 }
 
 // New takes a variable amount of Configuration functions and returns a new Service
@@ -51,6 +85,110 @@ func WithBookRepository(bookRepository book.Repository) Configuration {
 	}
 }
 
+// WithCategoryRepository applies a given category repository to the Service
+func WithCategoryRepository(categoryRepository category.Repository) Configuration {
+	return func(s *Service) error {
+		s.categoryRepository = categoryRepository
+		return nil
+	}
+}
+
+// WithSeriesRepository applies a given series repository to the Service
+func WithSeriesRepository(seriesRepository series.Repository) Configuration {
+	return func(s *Service) error {
+		s.seriesRepository = seriesRepository
+		return nil
+	}
+}
+
+// WithMergeRepository applies a given repository the duplicate books are merged with
+func WithMergeRepository(mergeRepository book.MergeRepository) Configuration {
+	return func(s *Service) error {
+		s.mergeRepository = mergeRepository
+		return nil
+	}
+}
+
+// WithSuggestionRepository applies a given repository the acquisition suggestions of the members are kept in
+func WithSuggestionRepository(suggestionRepository suggestion.Repository) Configuration {
+	return func(s *Service) error {
+		s.suggestionRepository = suggestionRepository
+		return nil
+	}
+}
+
+// WithWatchRepository applies a given repository the members waiting for a copy of a book are kept in
+func WithWatchRepository(watchRepository watch.Repository) Configuration {
+	return func(s *Service) error {
+		s.watchRepository = watchRepository
+		return nil
+	}
+}
+
+// WithShelfRepository applies a given reading list repository to the Service
+func WithShelfRepository(shelfRepository shelf.Repository) Configuration {
+	return func(s *Service) error {
+		s.shelfRepository = shelfRepository
+		return nil
+	}
+}
+
+// WithCopyRepository applies a given book copy repository to the Service
+func WithCopyRepository(copyRepository book.CopyRepository) Configuration {
+	return func(s *Service) error {
+		s.copyRepository = copyRepository
+		return nil
+	}
+}
+
+// WithCheckoutRepository applies a given repository the checkouts of the copies are logged in
+func WithCheckoutRepository(checkoutRepository book.CheckoutRepository) Configuration {
+	return func(s *Service) error {
+		s.checkoutRepository = checkoutRepository
+		return nil
+	}
+}
+
+// WithRelationRepository applies a given repository the books borrowed together are kept in
+func WithRelationRepository(relationRepository book.RelationRepository) Configuration {
+	return func(s *Service) error {
+		s.relationRepository = relationRepository
+		return nil
+	}
+}
+
+// WithRevisionRepository applies a given repository the changes of the books are recorded in
+func WithRevisionRepository(revisionRepository book.RevisionRepository) Configuration {
+	return func(s *Service) error {
+		s.revisionRepository = revisionRepository
+		return nil
+	}
+}
+
+// WithReviewRepository applies a given book review repository to the Service
+func WithReviewRepository(reviewRepository review.Repository) Configuration {
+	return func(s *Service) error {
+		s.reviewRepository = reviewRepository
+		return nil
+	}
+}
+
+// WithMemberRepository applies a given member repository the reviewers are looked up in
+func WithMemberRepository(memberRepository member.Repository) Configuration {
+	return func(s *Service) error {
+		s.memberRepository = memberRepository
+		return nil
+	}
+}
+
+// WithLoanRepository applies a given repository the copies lent to the members are kept in
+func WithLoanRepository(loanRepository loan.Repository) Configuration {
+	return func(s *Service) error {
+		s.loanRepository = loanRepository
+		return nil
+	}
+}
+
 // WithAuthorCache applies a given author cache to the Service
 func WithAuthorCache(authorCache author.Cache) Configuration {
 	// return a function that matches the Configuration alias,
@@ -70,3 +208,60 @@ func WithBookCache(bookCache book.Cache) Configuration {
 		return nil
 	}
 }
+
+// WithAvailabilityCache applies a given book availability cache to the Service
+func WithAvailabilityCache(availabilityCache book.AvailabilityCache) Configuration {
+	return func(s *Service) error {
+		s.availabilityCache = availabilityCache
+		return nil
+	}
+}
+
+// WithMetadataProvider applies a given provider the books created by ISBN alone are filled in from
+func WithMetadataProvider(metadataProvider book.MetadataProvider) Configuration {
+	return func(s *Service) error {
+		s.metadataProvider = metadataProvider
+		return nil
+	}
+}
+
+// WithProfileProvider applies a given provider the authors are enriched from
+func WithProfileProvider(profileProvider author.ProfileProvider) Configuration {
+	return func(s *Service) error {
+		s.profileProvider = profileProvider
+		return nil
+	}
+}
+
+// WithFeedSize applies how many books the new arrivals and each trending feed keep
+func WithFeedSize(size int) Configuration {
+	return func(s *Service) error {
+		s.feeds.size = size
+		return nil
+	}
+}
+
+// WithSuggestionNotifier applies a given notifier the members are told about their suggestions with
+func WithSuggestionNotifier(suggestionNotifier suggestion.Notifier) Configuration {
+	return func(s *Service) error {
+		s.suggestionNotifier = suggestionNotifier
+		return nil
+	}
+}
+
+// WithWatchNotifier applies a given notifier the watching members are told that a book is available with
+func WithWatchNotifier(watchNotifier watch.Notifier) Configuration {
+	return func(s *Service) error {
+		s.watchNotifier = watchNotifier
+		return nil
+	}
+}
+
+// WithCoverStorage applies a given storage and signer the uploaded book covers are kept and served with
+func WithCoverStorage(coverStorage storage.Storage, coverSigner *storage.URLSigner) Configuration {
+	return func(s *Service) error {
+		s.coverStorage = coverStorage
+		s.coverSigner = coverSigner
+		return nil
+	}
+}
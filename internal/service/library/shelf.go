@@ -0,0 +1,297 @@
+package library
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"library-service/internal/domain/book"
+	"library-service/internal/domain/shelf"
+	"library-service/pkg/log"
+	"library-service/pkg/store"
+)
+
+// ListShelves returns the reading lists of the member in the order they were created
+func (s *Service) ListShelves(ctx context.Context, memberID string) (res []shelf.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListShelves").With(zap.String("member_id", memberID))
+
+	if _, err = s.memberRepository.Get(ctx, memberID); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get member by id", zap.Error(err))
+		}
+		return
+	}
+
+	data, err := s.shelfRepository.List(ctx, memberID)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+
+	res = make([]shelf.Response, 0, len(data))
+	for _, object := range data {
+		var shelved shelf.Response
+		if shelved, err = s.parseShelf(ctx, object); err != nil {
+			logger.Error("failed to get books", zap.String("id", object.ID), zap.Error(err))
+			return
+		}
+		res = append(res, shelved)
+	}
+
+	return
+}
+
+func (s *Service) AddShelf(ctx context.Context, memberID string, req shelf.Request) (res shelf.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("AddShelf").With(zap.String("member_id", memberID))
+
+	if _, err = s.memberRepository.Get(ctx, memberID); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get member by id", zap.Error(err))
+		}
+		return
+	}
+
+	data := shelf.Entity{
+		MemberID: memberID,
+		Name:     &req.Name,
+		Books:    []string{},
+	}
+
+	data.ID, err = s.shelfRepository.Add(ctx, data)
+	if err != nil {
+		if !errors.Is(err, shelf.ErrorExists) {
+			logger.Error("failed to create", zap.Error(err))
+		}
+		return
+	}
+	res = shelf.ParseFromEntity(data, []book.Response{})
+
+	return
+}
+
+func (s *Service) GetShelf(ctx context.Context, memberID, id string) (res shelf.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetShelf").With(zap.String("member_id", memberID), zap.String("id", id))
+
+	data, err := s.shelfOf(ctx, memberID, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+
+	if res, err = s.parseShelf(ctx, data); err != nil {
+		logger.Error("failed to get books", zap.Error(err))
+	}
+
+	return
+}
+
+// GetSharedShelf returns the reading list shared with the token, without the member it belongs to
+func (s *Service) GetSharedShelf(ctx context.Context, token string) (res shelf.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetSharedShelf")
+
+	data, err := s.shelfRepository.GetByToken(ctx, token)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by token", zap.Error(err))
+		}
+		return
+	}
+
+	if res, err = s.parseShelf(ctx, data); err != nil {
+		logger.Error("failed to get books", zap.Error(err))
+		return
+	}
+	res = res.Shared()
+
+	return
+}
+
+// RenameShelf changes the name of the reading list, the books and the share link are kept
+func (s *Service) RenameShelf(ctx context.Context, memberID, id string, req shelf.Request) (res shelf.Response, err error) {
+	return s.updateShelf(ctx, memberID, id, func(data *shelf.Entity) error {
+		data.Name = &req.Name
+		return nil
+	})
+}
+
+func (s *Service) DeleteShelf(ctx context.Context, memberID, id string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("DeleteShelf").With(zap.String("member_id", memberID), zap.String("id", id))
+
+	if _, err = s.shelfOf(ctx, memberID, id); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+
+	if err = s.shelfRepository.Delete(ctx, id); err != nil && !errors.Is(err, store.ErrorNotFound) {
+		logger.Error("failed to delete", zap.Error(err))
+	}
+
+	return
+}
+
+// AddShelfBook puts the book at the end of the reading list
+func (s *Service) AddShelfBook(ctx context.Context, memberID, id string, req shelf.BookRequest) (res shelf.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("AddShelfBook").With(zap.String("member_id", memberID), zap.String("id", id), zap.String("book_id", req.BookID))
+
+	if _, err = s.bookRepository.Get(ctx, req.BookID); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get book by id", zap.Error(err))
+		}
+		return
+	}
+
+	return s.updateShelf(ctx, memberID, id, func(data *shelf.Entity) error {
+		if data.Has(req.BookID) {
+			return shelf.ErrorListed
+		}
+		data.Books = append(data.Books, req.BookID)
+		return nil
+	})
+}
+
+func (s *Service) RemoveShelfBook(ctx context.Context, memberID, id, bookID string) (res shelf.Response, err error) {
+	return s.updateShelf(ctx, memberID, id, func(data *shelf.Entity) error {
+		if !data.Has(bookID) {
+			return store.ErrorNotFound
+		}
+
+		books := make([]string, 0, len(data.Books)-1)
+		for _, object := range data.Books {
+			if object != bookID {
+				books = append(books, object)
+			}
+		}
+		data.Books = books
+
+		return nil
+	})
+}
+
+// OrderShelf puts the books of the reading list in the order of the request, which has every book of the list once
+func (s *Service) OrderShelf(ctx context.Context, memberID, id string, req shelf.OrderRequest) (res shelf.Response, err error) {
+	return s.updateShelf(ctx, memberID, id, func(data *shelf.Entity) error {
+		if len(req.Books) != len(data.Books) {
+			return shelf.ErrorOrder
+		}
+
+		seen := make(map[string]bool, len(req.Books))
+		for _, object := range req.Books {
+			if seen[object] || !data.Has(object) {
+				return shelf.ErrorOrder
+			}
+			seen[object] = true
+		}
+		data.Books = req.Books
+
+		return nil
+	})
+}
+
+// ShareShelf gives the reading list a public read-only link, a list that is shared already keeps its link
+func (s *Service) ShareShelf(ctx context.Context, memberID, id string) (res shelf.Response, err error) {
+	return s.updateShelf(ctx, memberID, id, func(data *shelf.Entity) error {
+		if data.ShareToken != nil {
+			return nil
+		}
+
+		token := make([]byte, 16)
+		if _, err := rand.Read(token); err != nil {
+			return err
+		}
+		value := hex.EncodeToString(token)
+		data.ShareToken = &value
+
+		return nil
+	})
+}
+
+// UnshareShelf takes the public link of the reading list down, sharing it again gives it a new link
+func (s *Service) UnshareShelf(ctx context.Context, memberID, id string) (res shelf.Response, err error) {
+	return s.updateShelf(ctx, memberID, id, func(data *shelf.Entity) error {
+		value := ""
+		data.ShareToken = &value
+		return nil
+	})
+}
+
+// updateShelf reads the shelf of the member, changes it with fn and stores it, the errors of fn
+// are returned as is
+func (s *Service) updateShelf(ctx context.Context, memberID, id string, fn func(data *shelf.Entity) error) (res shelf.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("updateShelf").With(zap.String("member_id", memberID), zap.String("id", id))
+
+	data, err := s.shelfOf(ctx, memberID, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+
+	if err = fn(&data); err != nil {
+		return
+	}
+
+	if err = s.shelfRepository.Update(ctx, id, data); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) && !errors.Is(err, shelf.ErrorExists) {
+			logger.Error("failed to update by id", zap.Error(err))
+		}
+		return
+	}
+
+	if data.ShareToken != nil && *data.ShareToken == "" {
+		data.ShareToken = nil
+	}
+
+	if res, err = s.parseShelf(ctx, data); err != nil {
+		logger.Error("failed to get books", zap.Error(err))
+	}
+
+	return
+}
+
+// shelfOf returns the shelf with the id, a shelf of another member is not found
+func (s *Service) shelfOf(ctx context.Context, memberID, id string) (data shelf.Entity, err error) {
+	if data, err = s.shelfRepository.Get(ctx, id); err != nil {
+		return
+	}
+
+	if data.MemberID != memberID {
+		err = store.ErrorNotFound
+	}
+
+	return
+}
+
+func (s *Service) parseShelf(ctx context.Context, data shelf.Entity) (res shelf.Response, err error) {
+	books, err := s.shelfBooks(ctx, data)
+	if err != nil {
+		return
+	}
+	res = shelf.ParseFromEntity(data, books)
+
+	return
+}
+
+// shelfBooks returns the books of the shelf in its order, the books deleted from the catalog are left out
+func (s *Service) shelfBooks(ctx context.Context, data shelf.Entity) (res []book.Response, err error) {
+	res = make([]book.Response, 0, len(data.Books))
+	for _, id := range data.Books {
+		object, err := s.bookRepository.Get(ctx, id)
+		if err != nil {
+			if errors.Is(err, store.ErrorNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		res = append(res, s.withCover(object, book.ParseFromEntity(object)))
+	}
+
+	return
+}
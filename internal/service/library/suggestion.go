@@ -0,0 +1,242 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"library-service/internal/domain/book"
+	"library-service/internal/domain/suggestion"
+	"library-service/pkg/log"
+	"library-service/pkg/store"
+)
+
+// ListSuggestions returns the suggestions in the status, every one for a blank status, the most voted first
+func (s *Service) ListSuggestions(ctx context.Context, status suggestion.Status) (res []suggestion.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListSuggestions").With(zap.String("status", string(status)))
+
+	data, err := s.suggestionRepository.List(ctx, status)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = suggestion.ParseFromEntities(data)
+
+	return
+}
+
+func (s *Service) GetSuggestion(ctx context.Context, id string) (res suggestion.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetSuggestion").With(zap.String("id", id))
+
+	data, err := s.suggestionRepository.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+	res = suggestion.ParseFromEntity(data)
+
+	return
+}
+
+// AddSuggestion asks the library to acquire a book it doesn't own, a book that is
+// already suggested and still in the pipeline has to be voted for instead
+func (s *Service) AddSuggestion(ctx context.Context, req suggestion.Request) (res suggestion.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("AddSuggestion").With(zap.String("member_id", req.MemberID))
+
+	if _, err = s.memberRepository.Get(ctx, req.MemberID); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get member by id", zap.Error(err))
+		}
+		return
+	}
+
+	if req.ISBN != "" {
+		if err = s.checkSuggested(ctx, req.ISBN); err != nil {
+			if !errors.Is(err, suggestion.ErrorOwned) && !errors.Is(err, suggestion.ErrorExists) {
+				logger.Error("failed to check isbn", zap.Error(err))
+			}
+			return
+		}
+	}
+
+	status := suggestion.StatusUnderReview
+	data := suggestion.Entity{
+		MemberID: req.MemberID,
+		Title:    &req.Title,
+		Author:   &req.Author,
+		ISBN:     &req.ISBN,
+		Note:     &req.Note,
+		Status:   &status,
+		Voters:   []string{},
+	}
+
+	data.ID, err = s.suggestionRepository.Add(ctx, data)
+	if err != nil {
+		logger.Error("failed to create", zap.Error(err))
+		return
+	}
+
+	if data, err = s.suggestionRepository.Get(ctx, data.ID); err != nil {
+		logger.Error("failed to get by id", zap.Error(err))
+		return
+	}
+	res = suggestion.ParseFromEntity(data)
+
+	return
+}
+
+// VoteSuggestion counts the vote of a member other than the one who made the suggestion, once per member
+func (s *Service) VoteSuggestion(ctx context.Context, id string, req suggestion.VoteRequest) (res suggestion.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("VoteSuggestion").With(zap.String("id", id), zap.String("member_id", req.MemberID))
+
+	data, err := s.suggestionRepository.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+
+	switch {
+	case !data.Open():
+		err = suggestion.ErrorClosed
+		return
+	case data.MemberID == req.MemberID:
+		err = suggestion.ErrorSelfVote
+		return
+	}
+
+	if _, err = s.memberRepository.Get(ctx, req.MemberID); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get member by id", zap.Error(err))
+		}
+		return
+	}
+
+	if err = s.suggestionRepository.Vote(ctx, id, req.MemberID); err != nil {
+		if !errors.Is(err, suggestion.ErrorVoted) && !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to vote by id", zap.Error(err))
+		}
+		return
+	}
+
+	if data, err = s.suggestionRepository.Get(ctx, id); err != nil {
+		logger.Error("failed to get by id", zap.Error(err))
+		return
+	}
+	res = suggestion.ParseFromEntity(data)
+
+	return
+}
+
+// MoveSuggestion moves the suggestion along the pipeline and notifies the members who made and voted for it.
+// An added suggestion gets its book created from the request and the suggestion, and linked back to it.
+func (s *Service) MoveSuggestion(ctx context.Context, id string, req suggestion.StatusRequest) (res suggestion.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("MoveSuggestion").With(zap.String("id", id), zap.String("status", string(req.Status)))
+
+	data, err := s.suggestionRepository.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+
+	if !data.CanMove(req.Status) {
+		err = suggestion.ErrorTransition
+		return
+	}
+
+	update := suggestion.Entity{
+		Status: &req.Status,
+		Reason: &req.Reason,
+	}
+
+	if req.Status == suggestion.StatusAdded {
+		bookReq := bookOf(data, req.Book)
+		if err = bookReq.Bind(nil); err != nil {
+			err = fmt.Errorf("%w: %s", suggestion.ErrorBook, err)
+			return
+		}
+
+		var created book.Response
+		if created, err = s.CreateBook(ctx, bookReq); err != nil {
+			return
+		}
+		update.BookID = &created.ID
+	}
+
+	if err = s.suggestionRepository.Update(ctx, id, update); err != nil {
+		logger.Error("failed to update by id", zap.Error(err))
+		return
+	}
+	data.Status, data.Reason = update.Status, update.Reason
+	if update.BookID != nil {
+		data.BookID = update.BookID
+	}
+	s.notifySuggestion(ctx, data)
+	res = suggestion.ParseFromEntity(data)
+
+	return
+}
+
+// checkSuggested refuses an ISBN the catalog already has or an open suggestion already asks for
+func (s *Service) checkSuggested(ctx context.Context, isbn book.ISBN) (err error) {
+	books, err := s.bookRepository.List(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, object := range books {
+		if object.ISBN != nil && object.ISBN.Equal(isbn) {
+			return suggestion.ErrorOwned
+		}
+	}
+
+	suggestions, err := s.suggestionRepository.List(ctx, "")
+	if err != nil {
+		return
+	}
+
+	for _, object := range suggestions {
+		if object.Open() && object.ISBN != nil && object.ISBN.Equal(isbn) {
+			return suggestion.ErrorExists
+		}
+	}
+
+	return
+}
+
+// notifySuggestion tells the audience of the suggestion about its new status, the status
+// has already changed so a failure to notify is only logged
+func (s *Service) notifySuggestion(ctx context.Context, data suggestion.Entity) {
+	if s.suggestionNotifier == nil {
+		return
+	}
+	logger := log.LoggerFromContext(ctx).Named("notifySuggestion").With(zap.String("id", data.ID))
+
+	if err := s.suggestionNotifier.Notify(ctx, data.Audience(), data); err != nil {
+		logger.Error("failed to notify", zap.Error(err))
+	}
+}
+
+// bookOf fills in the blank name and isbn of the book of an added suggestion from the suggestion
+func bookOf(data suggestion.Entity, req *suggestion.Book) (dest book.Request) {
+	if req != nil {
+		dest = book.Request(*req)
+	}
+
+	if dest.Name == "" && data.Title != nil {
+		dest.Name = *data.Title
+	}
+
+	if dest.ISBN == "" && data.ISBN != nil {
+		dest.ISBN = *data.ISBN
+	}
+
+	return
+}
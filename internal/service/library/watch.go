@@ -0,0 +1,114 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"library-service/internal/domain/book"
+	"library-service/internal/domain/watch"
+	"library-service/pkg/log"
+	"library-service/pkg/store"
+)
+
+// WatchBook asks for the member to be told once a copy of the book is free, it doesn't hold the copy.
+// A book with a copy available right now cannot be watched.
+func (s *Service) WatchBook(ctx context.Context, bookID string, req watch.Request) (res watch.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("WatchBook").With(zap.String("book_id", bookID), zap.String("member_id", req.MemberID))
+
+	if _, err = s.bookRepository.Get(ctx, bookID); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get book by id", zap.Error(err))
+		}
+		return
+	}
+
+	if _, err = s.memberRepository.Get(ctx, req.MemberID); err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get member by id", zap.Error(err))
+		}
+		return
+	}
+
+	copies, err := s.copyRepository.List(ctx, bookID)
+	if err != nil {
+		logger.Error("failed to select copies", zap.Error(err))
+		return
+	}
+
+	if book.ParseAvailability(bookID, copies).Available > 0 {
+		err = watch.ErrorAvailable
+		return
+	}
+
+	data := watch.Entity{
+		BookID:    bookID,
+		MemberID:  req.MemberID,
+		CreatedAt: time.Now(),
+	}
+
+	data.ID, err = s.watchRepository.Add(ctx, data)
+	if err != nil {
+		if !errors.Is(err, watch.ErrorWatched) {
+			logger.Error("failed to create", zap.Error(err))
+		}
+		return
+	}
+	res = watch.ParseFromEntity(data)
+
+	return
+}
+
+func (s *Service) UnwatchBook(ctx context.Context, bookID, memberID string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("UnwatchBook").With(zap.String("book_id", bookID), zap.String("member_id", memberID))
+
+	err = s.watchRepository.Delete(ctx, bookID, memberID)
+	if err != nil && !errors.Is(err, store.ErrorNotFound) {
+		logger.Error("failed to delete", zap.Error(err))
+	}
+
+	return
+}
+
+// notifyWatchers tells the members watching the book that a copy is free and drops their watches,
+// the copy is already free so a failure is only logged and the watches are kept for the next one
+func (s *Service) notifyWatchers(ctx context.Context, bookID string) {
+	if s.watchRepository == nil || s.watchNotifier == nil {
+		return
+	}
+	logger := log.LoggerFromContext(ctx).Named("notifyWatchers").With(zap.String("book_id", bookID))
+
+	watches, err := s.watchRepository.List(ctx, bookID)
+	if err != nil {
+		logger.Error("failed to select watches", zap.Error(err))
+		return
+	}
+
+	if len(watches) == 0 {
+		return
+	}
+
+	data, err := s.bookRepository.Get(ctx, bookID)
+	if err != nil {
+		logger.Error("failed to get book by id", zap.Error(err))
+		return
+	}
+
+	members := make([]string, len(watches))
+	for i, object := range watches {
+		members[i] = object.MemberID
+	}
+
+	if err = s.watchNotifier.NotifyAvailable(ctx, members, data); err != nil {
+		logger.Error("failed to notify", zap.Error(err))
+		return
+	}
+
+	for _, object := range watches {
+		if err = s.watchRepository.Delete(ctx, bookID, object.MemberID); err != nil && !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to delete watch", zap.String("member_id", object.MemberID), zap.Error(err))
+		}
+	}
+}
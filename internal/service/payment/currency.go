@@ -4,11 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
 	"go.uber.org/zap"
 
 	"library-service/internal/provider/currency"
+	"library-service/internal/provider/epay"
 	"library-service/pkg/log"
 )
 
@@ -47,3 +49,19 @@ func (s *Service) GetCurrencyRateFromCacheByID(ctx context.Context, id string) (
 
 	return
 }
+
+// Ping checks that the currency rates the payments are priced with can be fetched
+// and that the breaker of the epay gateway, when it is enabled, isn't open
+func (s *Service) Ping(ctx context.Context) (err error) {
+	if err = s.currencyClient.Ping(ctx); err != nil {
+		return
+	}
+
+	if s.epayClient != nil {
+		if state := s.epayClient.State(); state == epay.BreakerOpen {
+			return fmt.Errorf("%w: the breaker is %s", epay.ErrGatewayUnavailable, state)
+		}
+	}
+
+	return
+}
@@ -1 +1,60 @@
 package payment
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"library-service/internal/provider/epay"
+	"library-service/pkg/log"
+)
+
+// ErrPaymentsDisabled is returned when the epay gateway is not configured
+var ErrPaymentsDisabled = errors.New("payment: payments are disabled")
+
+// Gateway reports whether the payments go through the epay gateway
+func (s *Service) Gateway() bool {
+	return s.epayClient != nil
+}
+
+// Breaker returns the state of the circuit breaker of the epay gateway, the payments are checked
+// with ErrGatewayUnavailable while it is open
+func (s *Service) Breaker() string {
+	if s.epayClient == nil {
+		return ""
+	}
+
+	return string(s.epayClient.State())
+}
+
+// GetPaymentStatus checks the status of the invoice at the gateway with the global token,
+// repeated checks while the member waits are answered from the cache of the client
+func (s *Service) GetPaymentStatus(ctx context.Context, invoiceID string) (dest epay.StatusResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetPaymentStatus").With(zap.String("invoice_id", invoiceID))
+
+	if s.epayClient == nil {
+		err = ErrPaymentsDisabled
+		return
+	}
+
+	dest, err = s.epayClient.GetStatus(ctx, s.epayClient.GlobalToken().AccessToken, invoiceID)
+	if err != nil {
+		logger.Error("failed to get payment status", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// ReceivePaymentCallback drops the cached status of the invoice the gateway called back about, so that the
+// next check reads the new one. The body of the callback is not trusted, the status is always checked.
+func (s *Service) ReceivePaymentCallback(ctx context.Context, req epay.CallbackRequest) (err error) {
+	if s.epayClient == nil {
+		return ErrPaymentsDisabled
+	}
+
+	s.epayClient.InvalidateStatus(req.InvoiceID)
+
+	return
+}
@@ -2,6 +2,7 @@ package payment
 
 import (
 	"library-service/internal/provider/currency"
+	"library-service/internal/provider/epay"
 )
 
 // Configuration is an alias for a function that will take in a pointer to a Service and modify it
@@ -10,6 +11,7 @@ type Configuration func(s *Service) error
 // Service is an implementation of the Service
 type Service struct {
 	currencyClient *currency.Client
+	epayClient     *epay.Client
 }
 
 // New takes a variable amount of Configuration functions and returns a new Service
@@ -37,3 +39,11 @@ func WithCurrencyClient(currencyClient *currency.Client) Configuration {
 		return nil
 	}
 }
+
+// WithEpayClient applies a given epay client the payments are checked with
+func WithEpayClient(epayClient *epay.Client) Configuration {
+	return func(s *Service) error {
+		s.epayClient = epayClient
+		return nil
+	}
+}
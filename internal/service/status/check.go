@@ -0,0 +1,111 @@
+package status
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"library-service/internal/domain/health"
+	"library-service/pkg/log"
+	"library-service/pkg/worker"
+)
+
+// RunChecks checks the components right away and then every interval until the context is done,
+// a check that takes longer than the timeout fails. Every run is recorded with the worker.
+func (s *Service) RunChecks(ctx context.Context, interval, timeout time.Duration, w *worker.Worker) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		w.Run(func() error {
+			s.Check(ctx, timeout)
+			return nil
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check runs the health checks of the components and records their results by day
+func (s *Service) Check(ctx context.Context, timeout time.Duration) {
+	logger := log.LoggerFromContext(ctx).Named("Check")
+
+	day := time.Now().UTC().Truncate(24 * time.Hour)
+	for component, check := range s.checks {
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := check(checkCtx)
+		cancel()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		s.Lock()
+		s.results[component] = err
+		s.Unlock()
+
+		if err != nil {
+			logger.Warn("component is unhealthy", zap.String("component", component), zap.Error(err))
+		}
+
+		if err := s.checkRepository.Record(ctx, component, day, err == nil); err != nil {
+			logger.Error("failed to record check", zap.String("component", component), zap.Error(err))
+		}
+	}
+}
+
+// GetPage returns the status of the checked components with their uptime and the current incidents
+func (s *Service) GetPage(ctx context.Context) (res health.Page, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetPage")
+
+	incidents, err := s.incidentRepository.List(ctx, true)
+	if err != nil {
+		logger.Error("failed to list incidents", zap.Error(err))
+		return
+	}
+
+	days, err := s.checkRepository.List(ctx, time.Now().UTC().Add(-health.UptimeWindow).Truncate(24*time.Hour))
+	if err != nil {
+		logger.Error("failed to list checks", zap.Error(err))
+		return
+	}
+	uptime := health.Uptime(days)
+
+	res = health.Page{
+		Components: make([]health.ComponentResponse, 0, len(s.checks)),
+		Incidents:  health.ParseFromIncidents(incidents),
+		UpdatedAt:  time.Now().UTC(),
+	}
+
+	s.RLock()
+	statuses := make([]health.Status, 0, len(s.checks))
+	for _, component := range health.Components {
+		if _, ok := s.checks[component]; !ok {
+			continue
+		}
+
+		result, checked := s.results[component]
+		object := health.ComponentResponse{
+			Name:   component,
+			Status: health.ComponentStatus(component, checked, result == nil, incidents),
+		}
+		if value, ok := uptime[component]; ok {
+			object.Uptime = &value
+		}
+		if breaker, ok := s.breakers[component]; ok {
+			object.Breaker = breaker()
+		}
+
+		res.Components = append(res.Components, object)
+		statuses = append(statuses, object.Status)
+	}
+	s.RUnlock()
+	res.Status = health.Overall(statuses)
+
+	return
+}
@@ -0,0 +1,117 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"library-service/internal/domain/health"
+	"library-service/pkg/log"
+	"library-service/pkg/store"
+)
+
+// ListIncidents returns the incidents, the newest first, only the ones not resolved yet when current is set
+func (s *Service) ListIncidents(ctx context.Context, current bool) (res []health.IncidentResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListIncidents")
+
+	data, err := s.incidentRepository.List(ctx, current)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = health.ParseFromIncidents(data)
+
+	return
+}
+
+func (s *Service) AddIncident(ctx context.Context, req health.IncidentRequest) (res health.IncidentResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("AddIncident")
+
+	data := health.Incident{
+		Title:       &req.Title,
+		Description: &req.Description,
+		Impact:      &req.Impact,
+		State:       &req.State,
+		Components:  req.Components,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if req.State == health.StateResolved {
+		data.ResolvedAt = &data.CreatedAt
+	}
+
+	data.ID, err = s.incidentRepository.Add(ctx, data)
+	if err != nil {
+		logger.Error("failed to create", zap.Error(err))
+		return
+	}
+	res = health.ParseFromIncident(data)
+
+	return
+}
+
+func (s *Service) GetIncident(ctx context.Context, id string) (res health.IncidentResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetIncident").With(zap.String("id", id))
+
+	data, err := s.incidentRepository.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+	res = health.ParseFromIncident(data)
+
+	return
+}
+
+// UpdateIncident replaces the report of the incident, it is resolved at the time it moves to health.StateResolved
+// and cannot be reopened after that
+func (s *Service) UpdateIncident(ctx context.Context, id string, req health.IncidentRequest) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("UpdateIncident").With(zap.String("id", id))
+
+	current, err := s.incidentRepository.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+
+	if current.ResolvedAt != nil && req.State != health.StateResolved {
+		err = health.ErrorResolved
+		return
+	}
+
+	data := health.Incident{
+		Title:       &req.Title,
+		Description: &req.Description,
+		Impact:      &req.Impact,
+		State:       &req.State,
+		Components:  req.Components,
+		ResolvedAt:  current.ResolvedAt,
+	}
+	if data.ResolvedAt == nil && req.State == health.StateResolved {
+		now := time.Now().UTC()
+		data.ResolvedAt = &now
+	}
+
+	err = s.incidentRepository.Update(ctx, id, data)
+	if err != nil && !errors.Is(err, store.ErrorNotFound) {
+		logger.Error("failed to update by id", zap.Error(err))
+	}
+
+	return
+}
+
+func (s *Service) DeleteIncident(ctx context.Context, id string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("DeleteIncident").With(zap.String("id", id))
+
+	err = s.incidentRepository.Delete(ctx, id)
+	if err != nil && !errors.Is(err, store.ErrorNotFound) {
+		logger.Error("failed to delete by id", zap.Error(err))
+	}
+
+	return
+}
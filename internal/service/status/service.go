@@ -0,0 +1,75 @@
+package status
+
+import (
+	"sync"
+
+	"library-service/internal/domain/health"
+)
+
+// Configuration is an alias for a function that will take in a pointer to a Service and modify it
+type Configuration func(s *Service) error
+
+// Service is an implementation of the Service
+type Service struct {
+	incidentRepository health.IncidentRepository
+	checkRepository    health.CheckRepository
+
+	checks   map[string]health.Check
+	breakers map[string]health.Breaker
+
+	// results of the last checks live as long as the process that ran them
+	sync.RWMutex
+	results map[string]error
+}
+
+// New takes a variable amount of Configuration functions and returns a new Service
+// Each Configuration will be called in the order they are passed in
+func New(configs ...Configuration) (s *Service, err error) {
+	// Add the service
+	s = &Service{
+		checks:   make(map[string]health.Check),
+		breakers: make(map[string]health.Breaker),
+		results:  make(map[string]error),
+	}
+
+	// Apply all Configurations passed in
+	for _, cfg := range configs {
+		// Pass the service into the configuration function
+		if err = cfg(s); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// WithIncidentRepository applies a given incident repository to the Service
+func WithIncidentRepository(incidentRepository health.IncidentRepository) Configuration {
+	return func(s *Service) error {
+		s.incidentRepository = incidentRepository
+		return nil
+	}
+}
+
+// WithCheckRepository applies a given repository the history of the health checks is kept in
+func WithCheckRepository(checkRepository health.CheckRepository) Configuration {
+	return func(s *Service) error {
+		s.checkRepository = checkRepository
+		return nil
+	}
+}
+
+// WithCheck applies a given health check of the component, only the checked components are on the status page
+func WithCheck(component string, check health.Check) Configuration {
+	return func(s *Service) error {
+		s.checks[component] = check
+		return nil
+	}
+}
+
+// WithBreaker applies the circuit breaker in front of the component, its state is shown on the status page
+func WithBreaker(component string, breaker health.Breaker) Configuration {
+	return func(s *Service) error {
+		s.breakers[component] = breaker
+		return nil
+	}
+}
@@ -31,6 +31,12 @@ func (s *Service) CreateMember(ctx context.Context, req member.Request) (res mem
 	data := member.Entity{
 		FullName: &req.FullName,
 		Books:    req.Books,
+		Tier:     &req.Tier,
+	}
+
+	// the member is replaced, so the books left out of the request are cleared
+	if data.Books == nil {
+		data.Books = []string{}
 	}
 
 	data.ID, err = s.memberRepository.Add(ctx, data)
@@ -47,8 +53,10 @@ func (s *Service) GetMember(ctx context.Context, id string) (res member.Response
 	logger := log.LoggerFromContext(ctx).Named("GetMember").With(zap.String("id", id))
 
 	data, err := s.memberRepository.Get(ctx, id)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
-		logger.Error("failed to get by id", zap.Error(err))
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
 		return
 	}
 	res = member.ParseFromEntity(data)
@@ -62,6 +70,12 @@ func (s *Service) UpdateMember(ctx context.Context, id string, req member.Reques
 	data := member.Entity{
 		FullName: &req.FullName,
 		Books:    req.Books,
+		Tier:     &req.Tier,
+	}
+
+	// the member is replaced, so the books left out of the request are cleared
+	if data.Books == nil {
+		data.Books = []string{}
 	}
 
 	err = s.memberRepository.Update(ctx, id, data)
@@ -73,6 +87,26 @@ func (s *Service) UpdateMember(ctx context.Context, id string, req member.Reques
 	return
 }
 
+func (s *Service) PatchMember(ctx context.Context, id string, req member.PatchRequest) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("PatchMember").With(zap.String("id", id))
+
+	data := member.Entity{
+		FullName: req.FullName,
+		Tier:     req.Tier,
+	}
+	if req.Books != nil {
+		data.Books = *req.Books
+	}
+
+	err = s.memberRepository.Update(ctx, id, data)
+	if err != nil && !errors.Is(err, store.ErrorNotFound) {
+		logger.Error("failed to patch by id", zap.Error(err))
+		return
+	}
+
+	return
+}
+
 func (s *Service) DeleteMember(ctx context.Context, id string) (err error) {
 	logger := log.LoggerFromContext(ctx).Named("DeleteMember").With(zap.String("id", id))
 
@@ -89,8 +123,10 @@ func (s *Service) ListMemberBooks(ctx context.Context, id string) (res []book.Re
 	logger := log.LoggerFromContext(ctx).Named("ListMemberBooks").With(zap.String("id", id))
 
 	data, err := s.memberRepository.Get(ctx, id)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
-		logger.Error("failed to get by id", zap.Error(err))
+	if err != nil {
+		if !errors.Is(err, store.ErrorNotFound) {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
 		return
 	}
 	res = make([]book.Response, len(data.Books))
@@ -0,0 +1,9 @@
+BEGIN;
+    DROP TRIGGER IF EXISTS authors_search_vector_update ON authors;
+    DROP TRIGGER IF EXISTS books_search_vector_update ON books;
+    DROP FUNCTION IF EXISTS authors_search_vector_update();
+    DROP FUNCTION IF EXISTS books_search_vector_update();
+    DROP FUNCTION IF EXISTS books_search_vector(books);
+    DROP INDEX IF EXISTS books_search_vector_idx;
+    ALTER TABLE books DROP COLUMN IF EXISTS search_vector;
+END;
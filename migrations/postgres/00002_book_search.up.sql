@@ -0,0 +1,48 @@
+BEGIN;
+    -- COLUMNS --
+    ALTER TABLE books ADD COLUMN IF NOT EXISTS authors UUID[] NOT NULL DEFAULT '{}';
+    ALTER TABLE books ADD COLUMN IF NOT EXISTS search_vector TSVECTOR;
+
+    -- FUNCTIONS --
+    -- name weighs the most, then author names, genre and description
+    CREATE OR REPLACE FUNCTION books_search_vector(book books) RETURNS TSVECTOR AS $$
+        SELECT
+            SETWEIGHT(TO_TSVECTOR('simple', COALESCE(book.name, '')), 'A') ||
+            SETWEIGHT(TO_TSVECTOR('simple', COALESCE((
+                SELECT STRING_AGG(full_name || ' ' || pseudonym, ' ')
+                FROM authors
+                WHERE id = ANY(book.authors)), '')), 'B') ||
+            SETWEIGHT(TO_TSVECTOR('simple', COALESCE(book.genre, '')), 'C') ||
+            SETWEIGHT(TO_TSVECTOR('simple', COALESCE(book.description::TEXT, '')), 'D')
+    $$ LANGUAGE SQL STABLE;
+
+    CREATE OR REPLACE FUNCTION books_search_vector_update() RETURNS TRIGGER AS $$
+        BEGIN
+            NEW.search_vector := books_search_vector(NEW);
+            RETURN NEW;
+        END
+    $$ LANGUAGE PLPGSQL;
+
+    -- renaming an author changes the search vector of their books
+    CREATE OR REPLACE FUNCTION authors_search_vector_update() RETURNS TRIGGER AS $$
+        BEGIN
+            UPDATE books SET search_vector = books_search_vector(books) WHERE NEW.id = ANY(authors);
+            RETURN NEW;
+        END
+    $$ LANGUAGE PLPGSQL;
+
+    -- TRIGGERS --
+    CREATE TRIGGER books_search_vector_update
+        BEFORE INSERT OR UPDATE OF name, genre, description, authors ON books
+        FOR EACH ROW EXECUTE FUNCTION books_search_vector_update();
+
+    CREATE TRIGGER authors_search_vector_update
+        AFTER UPDATE OF full_name, pseudonym ON authors
+        FOR EACH ROW EXECUTE FUNCTION authors_search_vector_update();
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS books_search_vector_idx ON books USING GIN (search_vector);
+
+    -- DATA --
+    UPDATE books SET search_vector = books_search_vector(books);
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS book_copies (
+        created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        id          UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        book_id     UUID NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+        barcode     VARCHAR NOT NULL UNIQUE,
+        condition   VARCHAR NOT NULL DEFAULT 'good',
+        location    VARCHAR NOT NULL,
+        status      VARCHAR NOT NULL DEFAULT 'available'
+    );
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS book_copies_book_id_idx ON book_copies (book_id);
+COMMIT;
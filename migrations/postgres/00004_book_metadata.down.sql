@@ -0,0 +1,4 @@
+BEGIN;
+    ALTER TABLE books DROP COLUMN IF EXISTS cover_url;
+    ALTER TABLE books DROP COLUMN IF EXISTS year;
+END;
@@ -0,0 +1,5 @@
+BEGIN;
+    -- COLUMNS --
+    ALTER TABLE books ADD COLUMN IF NOT EXISTS year INTEGER;
+    ALTER TABLE books ADD COLUMN IF NOT EXISTS cover_url VARCHAR;
+COMMIT;
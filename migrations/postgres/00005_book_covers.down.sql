@@ -0,0 +1,3 @@
+BEGIN;
+    ALTER TABLE books DROP COLUMN IF EXISTS cover_key;
+END;
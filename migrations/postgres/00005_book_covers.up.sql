@@ -0,0 +1,4 @@
+BEGIN;
+    -- COLUMNS --
+    ALTER TABLE books ADD COLUMN IF NOT EXISTS cover_key VARCHAR;
+COMMIT;
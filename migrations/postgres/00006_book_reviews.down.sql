@@ -0,0 +1,5 @@
+BEGIN;
+    ALTER TABLE books DROP COLUMN IF EXISTS review_count;
+    ALTER TABLE books DROP COLUMN IF EXISTS rating;
+    DROP TABLE IF EXISTS book_reviews CASCADE;
+END;
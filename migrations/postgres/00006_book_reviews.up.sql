@@ -0,0 +1,21 @@
+BEGIN;
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS book_reviews (
+        created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        id          UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        book_id     UUID NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+        member_id   UUID NOT NULL REFERENCES members (id) ON DELETE CASCADE,
+        rating      INTEGER NOT NULL CHECK (rating BETWEEN 1 AND 5),
+        text        VARCHAR,
+        status      VARCHAR NOT NULL DEFAULT 'visible',
+        UNIQUE (book_id, member_id)
+    );
+
+    -- COLUMNS --
+    ALTER TABLE books ADD COLUMN IF NOT EXISTS rating NUMERIC(3, 2);
+    ALTER TABLE books ADD COLUMN IF NOT EXISTS review_count INTEGER;
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS book_reviews_status_idx ON book_reviews (status);
+COMMIT;
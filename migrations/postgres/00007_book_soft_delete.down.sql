@@ -0,0 +1,7 @@
+BEGIN;
+    ALTER TABLE books ADD COLUMN IF NOT EXISTS is_archived BOOLEAN NOT NULL DEFAULT FALSE;
+    UPDATE books SET is_archived=TRUE WHERE deleted_at IS NOT NULL;
+
+    DROP INDEX IF EXISTS books_deleted_at_idx;
+    ALTER TABLE books DROP COLUMN IF EXISTS deleted_at;
+END;
@@ -0,0 +1,11 @@
+BEGIN;
+    -- COLUMNS --
+    ALTER TABLE books ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+    -- archived books were the only soft delete so far
+    UPDATE books SET deleted_at=updated_at WHERE is_archived AND deleted_at IS NULL;
+    ALTER TABLE books DROP COLUMN IF EXISTS is_archived;
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS books_deleted_at_idx ON books (deleted_at) WHERE deleted_at IS NULL;
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS book_revisions (
+        created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        id          UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        book_id     UUID NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+        action      VARCHAR NOT NULL,
+        actor       VARCHAR NOT NULL,
+        before      JSONB,
+        after       JSONB
+    );
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS book_revisions_book_id_idx ON book_revisions (book_id, created_at DESC);
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+    DROP INDEX IF EXISTS books_tags_idx;
+    DROP INDEX IF EXISTS books_categories_idx;
+    ALTER TABLE books DROP COLUMN IF EXISTS tags;
+    ALTER TABLE books DROP COLUMN IF EXISTS categories;
+    DROP TABLE IF EXISTS categories CASCADE;
+END;
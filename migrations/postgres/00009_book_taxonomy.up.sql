@@ -0,0 +1,20 @@
+BEGIN;
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS categories (
+        created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        id          UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        name        VARCHAR NOT NULL,
+        slug        VARCHAR NOT NULL,
+        parent_id   UUID REFERENCES categories (id)
+    );
+
+    -- COLUMNS --
+    ALTER TABLE books ADD COLUMN IF NOT EXISTS categories UUID[] NOT NULL DEFAULT '{}';
+    ALTER TABLE books ADD COLUMN IF NOT EXISTS tags VARCHAR[] NOT NULL DEFAULT '{}';
+
+    -- INDEXES --
+    CREATE UNIQUE INDEX IF NOT EXISTS categories_parent_id_slug_idx ON categories (COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'), slug);
+    CREATE INDEX IF NOT EXISTS books_categories_idx ON books USING GIN (categories);
+    CREATE INDEX IF NOT EXISTS books_tags_idx ON books USING GIN (tags);
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+    DROP INDEX IF EXISTS books_series_id_idx;
+    ALTER TABLE books DROP COLUMN IF EXISTS volume;
+    ALTER TABLE books DROP COLUMN IF EXISTS series_id;
+    DROP TABLE IF EXISTS series CASCADE;
+END;
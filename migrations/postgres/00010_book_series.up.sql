@@ -0,0 +1,17 @@
+BEGIN;
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS series (
+        created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        id          UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        name        VARCHAR NOT NULL,
+        description VARCHAR
+    );
+
+    -- COLUMNS --
+    ALTER TABLE books ADD COLUMN IF NOT EXISTS series_id UUID REFERENCES series (id);
+    ALTER TABLE books ADD COLUMN IF NOT EXISTS volume INTEGER CHECK (volume > 0);
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS books_series_id_idx ON books (series_id, volume) WHERE series_id IS NOT NULL;
+COMMIT;
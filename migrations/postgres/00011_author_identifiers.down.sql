@@ -0,0 +1,8 @@
+BEGIN;
+    ALTER TABLE authors DROP COLUMN IF EXISTS edited;
+    ALTER TABLE authors DROP COLUMN IF EXISTS photo_url;
+    ALTER TABLE authors DROP COLUMN IF EXISTS biography;
+    ALTER TABLE authors DROP COLUMN IF EXISTS wikipedia;
+    ALTER TABLE authors DROP COLUMN IF EXISTS viaf;
+    ALTER TABLE authors DROP COLUMN IF EXISTS orcid;
+END;
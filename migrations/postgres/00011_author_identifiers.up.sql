@@ -0,0 +1,9 @@
+BEGIN;
+    -- COLUMNS --
+    ALTER TABLE authors ADD COLUMN IF NOT EXISTS orcid VARCHAR;
+    ALTER TABLE authors ADD COLUMN IF NOT EXISTS viaf VARCHAR;
+    ALTER TABLE authors ADD COLUMN IF NOT EXISTS wikipedia VARCHAR;
+    ALTER TABLE authors ADD COLUMN IF NOT EXISTS biography VARCHAR;
+    ALTER TABLE authors ADD COLUMN IF NOT EXISTS photo_url VARCHAR;
+    ALTER TABLE authors ADD COLUMN IF NOT EXISTS edited VARCHAR[] NOT NULL DEFAULT '{}';
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS book_checkouts (
+        created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        id          UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        book_id     UUID NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+        copy_id     UUID NOT NULL
+    );
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS book_checkouts_created_at_idx ON book_checkouts (created_at, book_id);
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS book_suggestions (
+        created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        id          UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        member_id   UUID NOT NULL REFERENCES members (id) ON DELETE CASCADE,
+        title       VARCHAR NOT NULL,
+        author      VARCHAR,
+        isbn        VARCHAR,
+        note        VARCHAR,
+        status      VARCHAR NOT NULL,
+        reason      VARCHAR,
+        book_id     UUID REFERENCES books (id) ON DELETE SET NULL,
+        voters      UUID[] NOT NULL DEFAULT '{}'
+    );
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS book_suggestions_status_idx ON book_suggestions (status);
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+    DROP TABLE IF EXISTS health_checks CASCADE;
+    DROP TABLE IF EXISTS incidents CASCADE;
+END;
@@ -0,0 +1,25 @@
+BEGIN;
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS incidents (
+        created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        id          UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        title       VARCHAR NOT NULL,
+        description VARCHAR,
+        impact      VARCHAR NOT NULL,
+        state       VARCHAR NOT NULL,
+        components  VARCHAR[] NOT NULL DEFAULT '{}',
+        resolved_at TIMESTAMP
+    );
+
+    CREATE TABLE IF NOT EXISTS health_checks (
+        component   VARCHAR NOT NULL,
+        day         DATE NOT NULL,
+        total       INTEGER NOT NULL DEFAULT 0,
+        passed      INTEGER NOT NULL DEFAULT 0,
+        PRIMARY KEY (component, day)
+    );
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS incidents_state_idx ON incidents (state);
+COMMIT;
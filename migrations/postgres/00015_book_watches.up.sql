@@ -0,0 +1,10 @@
+BEGIN;
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS book_watches (
+        created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        id          UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        book_id     UUID NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+        member_id   UUID NOT NULL REFERENCES members (id) ON DELETE CASCADE,
+        UNIQUE (book_id, member_id)
+    );
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS member_shelves (
+        created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        id          UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        member_id   UUID NOT NULL REFERENCES members (id) ON DELETE CASCADE,
+        name        VARCHAR NOT NULL,
+        books       UUID[] NOT NULL DEFAULT '{}',
+        share_token VARCHAR UNIQUE,
+        UNIQUE (member_id, name)
+    );
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+    DROP TABLE IF EXISTS book_relations CASCADE;
+END;
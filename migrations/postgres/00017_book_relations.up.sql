@@ -0,0 +1,9 @@
+BEGIN;
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS book_relations (
+        book_id     UUID NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+        related_id  UUID NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+        borrowers   INTEGER NOT NULL,
+        PRIMARY KEY (book_id, related_id)
+    );
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+    DROP TABLE IF EXISTS member_loans CASCADE;
+    ALTER TABLE members DROP COLUMN IF EXISTS tier;
+END;
@@ -0,0 +1,21 @@
+BEGIN;
+    -- TABLES --
+    ALTER TABLE members ADD COLUMN IF NOT EXISTS tier VARCHAR NOT NULL DEFAULT 'basic';
+
+    CREATE TABLE IF NOT EXISTS member_loans (
+        created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        id          UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        member_id   UUID NOT NULL REFERENCES members (id) ON DELETE CASCADE,
+        book_id     UUID NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+        copy_id     UUID NOT NULL REFERENCES book_copies (id) ON DELETE CASCADE,
+        due_at      TIMESTAMP NOT NULL,
+        renewals    INTEGER NOT NULL DEFAULT 0,
+        returned_at TIMESTAMP
+    );
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS member_loans_member_id_idx ON member_loans (member_id, created_at);
+    -- a copy is out on a single loan at a time
+    CREATE UNIQUE INDEX IF NOT EXISTS member_loans_copy_id_idx ON member_loans (copy_id) WHERE returned_at IS NULL;
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+    DROP TRIGGER IF EXISTS books_search_vector_update ON books;
+
+    ALTER TABLE books ALTER COLUMN description TYPE JSONB USING TO_JSONB(COALESCE(description, ''));
+    ALTER TABLE books ALTER COLUMN description SET NOT NULL;
+
+    CREATE TRIGGER books_search_vector_update
+        BEFORE INSERT OR UPDATE OF name, genre, description, authors ON books
+        FOR EACH ROW EXECUTE FUNCTION books_search_vector_update();
+
+    UPDATE books SET search_vector = books_search_vector(books);
+END;
@@ -0,0 +1,17 @@
+BEGIN;
+    -- the trigger lists the column, so it is recreated around the change of its type
+    DROP TRIGGER IF EXISTS books_search_vector_update ON books;
+
+    -- COLUMNS --
+    -- the description becomes the plain text of the api, a json string keeps its text and other json its source
+    ALTER TABLE books ALTER COLUMN description DROP NOT NULL;
+    ALTER TABLE books ALTER COLUMN description TYPE VARCHAR USING description #>> '{}';
+
+    -- TRIGGERS --
+    CREATE TRIGGER books_search_vector_update
+        BEFORE INSERT OR UPDATE OF name, genre, description, authors ON books
+        FOR EACH ROW EXECUTE FUNCTION books_search_vector_update();
+
+    -- DATA --
+    UPDATE books SET search_vector = books_search_vector(books);
+COMMIT;
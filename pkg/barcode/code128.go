@@ -0,0 +1,82 @@
+package barcode
+
+import (
+	"errors"
+)
+
+// code128 holds the widths of the bars and spaces of every Code128 symbol, alternating from a bar
+var code128 = []string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+const (
+	code128StartB = 104
+	code128StartC = 105
+	code128Stop   = 106
+)
+
+// ErrorCode128 is returned for data that isn't printable ASCII
+var ErrorCode128 = errors.New("code128: only printable ascii can be encoded")
+
+// Code128 encodes the data into the modules of a Code128 symbol, a module is set for a bar.
+// Code set C packs the digits in pairs, it is used when the data is an even number of digits.
+func Code128(data string) (modules []bool, err error) {
+	if data == "" {
+		err = ErrorCode128
+		return
+	}
+
+	var values []int
+	switch {
+	case len(data) >= 4 && len(data)%2 == 0 && digits(data):
+		values = append(values, code128StartC)
+		for i := 0; i < len(data); i += 2 {
+			values = append(values, int(data[i]-'0')*10+int(data[i+1]-'0'))
+		}
+	default:
+		values = append(values, code128StartB)
+		for i := 0; i < len(data); i++ {
+			if data[i] < 32 || data[i] > 127 {
+				err = ErrorCode128
+				return
+			}
+			values = append(values, int(data[i]-32))
+		}
+	}
+
+	sum := values[0]
+	for i, value := range values[1:] {
+		sum += (i + 1) * value
+	}
+	values = append(values, sum%103, code128Stop)
+
+	for _, value := range values {
+		for i, width := range code128[value] {
+			for n := 0; n < int(width-'0'); n++ {
+				modules = append(modules, i%2 == 0)
+			}
+		}
+	}
+
+	return
+}
+
+func digits(data string) bool {
+	for i := 0; i < len(data); i++ {
+		if data[i] < '0' || data[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,59 @@
+package barcode
+
+import (
+	"image"
+	"image/color"
+)
+
+// Bars draws the modules of a linear symbol scale pixels wide and height pixels tall,
+// with the quiet zone of ten modules the scanners need on either side
+func Bars(modules []bool, scale, height int) *image.Gray {
+	quiet := 10 * scale
+
+	img := blank(len(modules)*scale+2*quiet, height)
+	for i, dark := range modules {
+		if !dark {
+			continue
+		}
+
+		for x := quiet + i*scale; x < quiet+(i+1)*scale; x++ {
+			for y := 0; y < height; y++ {
+				img.SetGray(x, y, color.Gray{})
+			}
+		}
+	}
+
+	return img
+}
+
+// Matrix draws the modules of a two-dimensional symbol scale pixels square,
+// with the quiet zone of four modules around it
+func Matrix(modules [][]bool, scale int) *image.Gray {
+	quiet := 4 * scale
+
+	img := blank(len(modules)*scale+2*quiet, len(modules)*scale+2*quiet)
+	for y, row := range modules {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+
+			for py := quiet + y*scale; py < quiet+(y+1)*scale; py++ {
+				for px := quiet + x*scale; px < quiet+(x+1)*scale; px++ {
+					img.SetGray(px, py, color.Gray{})
+				}
+			}
+		}
+	}
+
+	return img
+}
+
+func blank(width, height int) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+
+	return img
+}
@@ -0,0 +1,473 @@
+package barcode
+
+import (
+	"errors"
+)
+
+// ErrorQR is returned for data too long for the largest version the encoder supports
+var ErrorQR = errors.New("qr: data is too long")
+
+// qrVersion is the layout of a version at error correction level M
+type qrVersion struct {
+	// ec is the number of error correction codewords of every block
+	ec int
+	// blocks holds the number of data codewords of each block
+	blocks []int
+	// align is the positions of the centers of the alignment patterns
+	align []int
+}
+
+// qrVersions are the versions 1 to 10, enough for about two hundred bytes
+var qrVersions = []qrVersion{
+	{ec: 10, blocks: []int{16}},
+	{ec: 16, blocks: []int{28}, align: []int{6, 18}},
+	{ec: 26, blocks: []int{44}, align: []int{6, 22}},
+	{ec: 18, blocks: []int{32, 32}, align: []int{6, 26}},
+	{ec: 24, blocks: []int{43, 43}, align: []int{6, 30}},
+	{ec: 16, blocks: []int{27, 27, 27, 27}, align: []int{6, 34}},
+	{ec: 18, blocks: []int{31, 31, 31, 31}, align: []int{6, 22, 38}},
+	{ec: 22, blocks: []int{38, 38, 39, 39}, align: []int{6, 24, 42}},
+	{ec: 22, blocks: []int{36, 36, 36, 37, 37}, align: []int{6, 26, 46}},
+	{ec: 26, blocks: []int{43, 43, 43, 43, 44}, align: []int{6, 28, 50}},
+}
+
+// QR encodes the data in byte mode at error correction level M into the modules of the smallest
+// QR code that holds it, a module is set when it is dark. The mask with the lowest penalty is applied.
+func QR(data string) (modules [][]bool, err error) {
+	version := 0
+	for ; version < len(qrVersions); version++ {
+		if len(data) <= qrCapacity(version) {
+			break
+		}
+	}
+	if version == len(qrVersions) {
+		err = ErrorQR
+		return
+	}
+
+	q := newQR(version + 1)
+	q.drawFunctions()
+	q.drawCodewords(q.interleave(q.encode(data)))
+
+	best, penalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormat(mask)
+
+		if p := q.penalty(); penalty < 0 || p < penalty {
+			best, penalty = mask, p
+		}
+		q.applyMask(mask)
+	}
+	q.applyMask(best)
+	q.drawFormat(best)
+
+	return q.modules, nil
+}
+
+// qrCapacity is the number of bytes the version holds next to the mode and the count
+func qrCapacity(version int) int {
+	total := 0
+	for _, n := range qrVersions[version].blocks {
+		total += n
+	}
+
+	return total - 2 - (version+1)/10
+}
+
+type qr struct {
+	version  int
+	size     int
+	modules  [][]bool
+	function [][]bool
+}
+
+func newQR(version int) *qr {
+	q := &qr{version: version, size: 17 + 4*version}
+
+	q.modules = make([][]bool, q.size)
+	q.function = make([][]bool, q.size)
+	for i := range q.modules {
+		q.modules[i] = make([]bool, q.size)
+		q.function[i] = make([]bool, q.size)
+	}
+
+	return q
+}
+
+func (q *qr) set(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.function[y][x] = true
+}
+
+func (q *qr) drawFunctions() {
+	for i := 0; i < q.size; i++ {
+		q.set(6, i, i%2 == 0)
+		q.set(i, 6, i%2 == 0)
+	}
+
+	q.drawFinder(3, 3)
+	q.drawFinder(q.size-4, 3)
+	q.drawFinder(3, q.size-4)
+
+	align := qrVersions[q.version-1].align
+	for i, y := range align {
+		for j, x := range align {
+			// the corners with the finders have no alignment pattern
+			last := len(align) - 1
+			if i == 0 && j == 0 || i == 0 && j == last || i == last && j == 0 {
+				continue
+			}
+			q.drawAlignment(x, y)
+		}
+	}
+
+	// the areas of the format are reserved until the mask is chosen
+	q.drawFormat(0)
+	q.drawVersion()
+}
+
+func (q *qr) drawFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || x >= q.size || y < 0 || y >= q.size {
+				continue
+			}
+
+			dist := max(abs(dx), abs(dy))
+			q.set(x, y, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (q *qr) drawAlignment(cx, cy int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			q.set(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormat draws both copies of the error correction level and the mask with their BCH code
+func (q *qr) drawFormat(mask int) {
+	// level M is 00
+	data := mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	for i := 0; i <= 5; i++ {
+		q.set(8, i, bit(bits, i))
+	}
+	q.set(8, 7, bit(bits, 6))
+	q.set(8, 8, bit(bits, 7))
+	q.set(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		q.set(14-i, 8, bit(bits, i))
+	}
+
+	for i := 0; i < 8; i++ {
+		q.set(q.size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		q.set(8, q.size-15+i, bit(bits, i))
+	}
+	q.set(8, q.size-8, true)
+}
+
+// drawVersion draws both copies of the version with its BCH code, versions below 7 have none
+func (q *qr) drawVersion() {
+	if q.version < 7 {
+		return
+	}
+
+	rem := q.version
+	for i := 0; i < 12; i++ {
+		rem = rem<<1 ^ (rem>>11)*0x1f25
+	}
+	bits := q.version<<12 | rem
+
+	for i := 0; i < 18; i++ {
+		a, b := q.size-11+i%3, i/3
+		q.set(a, b, bit(bits, i))
+		q.set(b, a, bit(bits, i))
+	}
+}
+
+// encode puts the data in byte mode and pads it to the data codewords of the version
+func (q *qr) encode(data string) []byte {
+	total := 0
+	for _, n := range qrVersions[q.version-1].blocks {
+		total += n
+	}
+
+	var bits []bool
+	push := func(value, n int) {
+		for i := n - 1; i >= 0; i-- {
+			bits = append(bits, bit(value, i))
+		}
+	}
+
+	push(0x4, 4)
+	if q.version < 10 {
+		push(len(data), 8)
+	} else {
+		push(len(data), 16)
+	}
+	for i := 0; i < len(data); i++ {
+		push(int(data[i]), 8)
+	}
+
+	push(0, min(4, total*8-len(bits)))
+	push(0, (8-len(bits)%8)%8)
+
+	codewords := make([]byte, 0, total)
+	for i := 0; i < len(bits); i += 8 {
+		var b byte
+		for j := 0; j < 8; j++ {
+			if bits[i+j] {
+				b |= 1 << (7 - j)
+			}
+		}
+		codewords = append(codewords, b)
+	}
+
+	for pad := byte(0xec); len(codewords) < total; pad ^= 0xec ^ 0x11 {
+		codewords = append(codewords, pad)
+	}
+
+	return codewords
+}
+
+// interleave splits the data codewords into blocks, adds the error correction of each
+// and takes the codewords of the blocks in turn
+func (q *qr) interleave(data []byte) (dest []byte) {
+	v := qrVersions[q.version-1]
+	divisor := rsDivisor(v.ec)
+
+	blocks := make([][]byte, len(v.blocks))
+	ecs := make([][]byte, len(v.blocks))
+	for i, n := range v.blocks {
+		blocks[i], data = data[:n], data[n:]
+		ecs[i] = rsRemainder(blocks[i], divisor)
+	}
+
+	for i := 0; i < v.blocks[len(v.blocks)-1]; i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				dest = append(dest, block[i])
+			}
+		}
+	}
+
+	for i := 0; i < v.ec; i++ {
+		for _, ec := range ecs {
+			dest = append(dest, ec[i])
+		}
+	}
+
+	return
+}
+
+// drawCodewords fills the modules left by the function patterns in the zigzag order of the standard
+func (q *qr) drawCodewords(data []byte) {
+	i := 0
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+
+		for vert := 0; vert < q.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = q.size - 1 - vert
+				}
+
+				if q.function[y][x] || i >= len(data)*8 {
+					continue
+				}
+				q.modules[y][x] = bit(int(data[i>>3]), 7-i&7)
+				i++
+			}
+		}
+	}
+}
+
+// applyMask flips the modules of the data by the mask, applying it twice undoes it
+func (q *qr) applyMask(mask int) {
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if q.function[y][x] {
+				continue
+			}
+
+			var flip bool
+			switch mask {
+			case 0:
+				flip = (x+y)%2 == 0
+			case 1:
+				flip = y%2 == 0
+			case 2:
+				flip = x%3 == 0
+			case 3:
+				flip = (x+y)%3 == 0
+			case 4:
+				flip = (x/3+y/2)%2 == 0
+			case 5:
+				flip = x*y%2+x*y%3 == 0
+			case 6:
+				flip = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				flip = ((x+y)%2+x*y%3)%2 == 0
+			}
+			q.modules[y][x] = q.modules[y][x] != flip
+		}
+	}
+}
+
+// penalty scores the symbol by the four rules of the standard, the lower the easier it scans
+func (q *qr) penalty() (score int) {
+	at := func(x, y int, transpose bool) bool {
+		if transpose {
+			return q.modules[x][y]
+		}
+		return q.modules[y][x]
+	}
+
+	finder := []bool{true, false, true, true, true, false, true}
+	for _, transpose := range []bool{false, true} {
+		for y := 0; y < q.size; y++ {
+			run := 1
+			for x := 1; x <= q.size; x++ {
+				if x < q.size && at(x, y, transpose) == at(x-1, y, transpose) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					score += 3 + run - 5
+				}
+				run = 1
+			}
+
+			for x := 0; x+len(finder) <= q.size; x++ {
+				match := true
+				for i, dark := range finder {
+					if at(x+i, y, transpose) != dark {
+						match = false
+						break
+					}
+				}
+
+				if match && (light(q, at, x-4, x, y, transpose) || light(q, at, x+7, x+11, y, transpose)) {
+					score += 40
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if q.modules[y][x] {
+				dark++
+			}
+
+			if x+1 < q.size && y+1 < q.size {
+				c := q.modules[y][x]
+				if c == q.modules[y][x+1] && c == q.modules[y+1][x] && c == q.modules[y+1][x+1] {
+					score += 3
+				}
+			}
+		}
+	}
+
+	total := q.size * q.size
+	score += (abs(dark*20-total*10)+total-1)/total*10 - 10
+
+	return
+}
+
+// light tells whether the modules from x0 to x1 of the row are light, those outside the symbol are
+func light(q *qr, at func(x, y int, transpose bool) bool, x0, x1, y int, transpose bool) bool {
+	for x := x0; x < x1; x++ {
+		if x >= 0 && x < q.size && at(x, y, transpose) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// rsDivisor is the generator polynomial of the Reed-Solomon code over GF(256) with the degree
+func rsDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+
+	return result
+}
+
+func rsRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+
+		for i, d := range divisor {
+			result[i] ^= gfMultiply(d, factor)
+		}
+	}
+
+	return result
+}
+
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = z<<1 ^ (z>>7)*0x11d
+		z ^= int(y>>i&1) * int(x)
+	}
+
+	return byte(z)
+}
+
+func bit(value, i int) bool {
+	return value>>i&1 != 0
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,182 @@
+package bulkhead
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrFull is returned when every slot of the bulkhead stayed taken for the whole wait
+var ErrFull = errors.New("bulkhead: dependency is saturated")
+
+// Stat is the saturation of a bulkhead, Active calls hold a slot and Rejected ones found none
+type Stat struct {
+	Name     string `json:"name" validate:"required"`
+	Limit    int    `json:"limit" validate:"required"`
+	Active   int    `json:"active" validate:"required"`
+	Served   int64  `json:"served" validate:"required"`
+	Rejected int64  `json:"rejected" validate:"required"`
+}
+
+// Bulkhead caps the concurrent calls to one outbound dependency, so a slow dependency
+// holds at most its own slots instead of every goroutine of the server
+type Bulkhead struct {
+	name  string
+	slots chan struct{}
+	wait  time.Duration
+
+	served   atomic.Int64
+	rejected atomic.Int64
+}
+
+// New returns a bulkhead of limit slots, a call waits up to wait for a free slot. A limit of zero disables the bulkhead.
+func New(name string, limit int, wait time.Duration) *Bulkhead {
+	b := &Bulkhead{name: name, wait: wait}
+	if limit > 0 {
+		b.slots = make(chan struct{}, limit)
+	}
+
+	return b
+}
+
+// Acquire takes a slot, the returned release gives it back and must be called once the call is done
+func (b *Bulkhead) Acquire(ctx context.Context) (release func(), err error) {
+	if b.slots == nil {
+		b.served.Add(1)
+		return func() {}, nil
+	}
+
+	select {
+	case b.slots <- struct{}{}:
+	default:
+		if err = b.waitForSlot(ctx); err != nil {
+			b.rejected.Add(1)
+			return nil, err
+		}
+	}
+	b.served.Add(1)
+
+	var once sync.Once
+	release = func() {
+		once.Do(func() {
+			<-b.slots
+		})
+	}
+
+	return
+}
+
+func (b *Bulkhead) waitForSlot(ctx context.Context) error {
+	if b.wait <= 0 {
+		return ErrFull
+	}
+
+	timer := time.NewTimer(b.wait)
+	defer timer.Stop()
+
+	select {
+	case b.slots <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return ErrFull
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *Bulkhead) Stat() Stat {
+	return Stat{
+		Name:     b.name,
+		Limit:    cap(b.slots),
+		Active:   len(b.slots),
+		Served:   b.served.Load(),
+		Rejected: b.rejected.Load(),
+	}
+}
+
+// Group keeps the bulkheads of the service to report their saturation
+type Group struct {
+	sync.Mutex
+
+	bulkheads []*Bulkhead
+}
+
+func NewGroup() *Group {
+	return &Group{}
+}
+
+// New returns a bulkhead that is reported with the group
+func (g *Group) New(name string, limit int, wait time.Duration) *Bulkhead {
+	g.Lock()
+	defer g.Unlock()
+
+	b := New(name, limit, wait)
+	g.bulkheads = append(g.bulkheads, b)
+
+	return b
+}
+
+// Report returns the saturation of the bulkheads by name
+func (g *Group) Report() (dest []Stat) {
+	g.Lock()
+	defer g.Unlock()
+
+	dest = make([]Stat, 0, len(g.bulkheads))
+	for _, b := range g.bulkheads {
+		dest = append(dest, b.Stat())
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].Name < dest[j].Name
+	})
+
+	return
+}
+
+// Transport is a http.RoundTripper that holds a slot of the Bulkhead until the response body is closed
+type Transport struct {
+	Bulkhead *Bulkhead
+	Next     http.RoundTripper
+}
+
+func (t Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	if t.Bulkhead == nil {
+		return next.RoundTrip(req)
+	}
+
+	release, err := t.Bulkhead.Acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := next.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	res.Body = &releasingBody{ReadCloser: res.Body, release: release}
+
+	return res, nil
+}
+
+type releasingBody struct {
+	io.ReadCloser
+
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	defer b.release()
+
+	return b.ReadCloser.Close()
+}
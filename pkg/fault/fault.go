@@ -0,0 +1,57 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// ErrInjected is returned by calls the Injector decided to fail
+var ErrInjected = errors.New("fault: injected failure")
+
+// Injector introduces artificial latency and failures into calls to outbound adapters
+type Injector struct {
+	Latency   time.Duration
+	ErrorRate float64
+}
+
+// Inject waits for the configured latency and then fails with the configured probability
+func (i Injector) Inject(ctx context.Context) error {
+	if i.Latency > 0 {
+		timer := time.NewTimer(i.Latency)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if i.ErrorRate > 0 && rand.Float64() < i.ErrorRate {
+		return ErrInjected
+	}
+
+	return nil
+}
+
+// Transport is a http.RoundTripper that injects faults before passing requests to Next
+type Transport struct {
+	Injector Injector
+	Next     http.RoundTripper
+}
+
+func (t Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.Injector.Inject(req.Context()); err != nil {
+		return nil, err
+	}
+
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return next.RoundTrip(req)
+}
@@ -0,0 +1,149 @@
+// Package fixture serves the recorded responses of a third party api to the tests of its client. The requests
+// are checked against the exchanges the api was recorded with, so that a request the api would refuse, or
+// answer otherwise, fails the test instead of passing against a fake that takes anything.
+package fixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// Exchange is a request the api was recorded with and its response
+type Exchange struct {
+	Method string
+	Path   string
+
+	// Query, Header and Form are the values the request must have, the others are ignored.
+	// Form holds the fields of an urlencoded or a multipart body.
+	Query  url.Values
+	Header http.Header
+	Form   url.Values
+
+	// JSON is the file in testdata/fixtures the json body of the request must be equal to
+	JSON string
+
+	// Once makes the exchange answer a single request, e.g. an expired token before the fresh one
+	Once bool
+
+	// Status is 200 by default, the body is the file in testdata/fixtures with the content type
+	// of its extension, or Body as is
+	Status int
+	File   string
+	Body   string
+}
+
+// Server answers the requests with the first exchange they match, a request that matches none fails the test.
+// Once the test is done, every exchange has to have been used.
+func Server(t testing.TB, exchanges ...Exchange) *httptest.Server {
+	t.Helper()
+
+	responses := make([][]byte, len(exchanges))
+	for i, exchange := range exchanges {
+		responses[i] = []byte(exchange.Body)
+		if exchange.File != "" {
+			responses[i] = read(t, exchange.File)
+		}
+	}
+
+	var mu sync.Mutex
+	used := make([]int, len(exchanges))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		for i, exchange := range exchanges {
+			if exchange.Once && used[i] > 0 || !exchange.matches(t, r, body) {
+				continue
+			}
+			used[i]++
+
+			if exchange.File != "" {
+				w.Header().Set("Content-Type", mime.TypeByExtension(filepath.Ext(exchange.File)))
+			}
+			if exchange.Status != 0 {
+				w.WriteHeader(exchange.Status)
+			}
+			w.Write(responses[i])
+			return
+		}
+
+		t.Errorf("fixture: no exchange matches %s %s %s\n%s", r.Method, r.URL.RequestURI(), r.Header, body)
+		http.NotFound(w, r)
+	}))
+
+	t.Cleanup(func() {
+		server.Close()
+
+		for i, exchange := range exchanges {
+			if used[i] == 0 {
+				t.Errorf("fixture: %s %s was never requested", exchange.Method, exchange.Path)
+			}
+		}
+	})
+
+	return server
+}
+
+func (e Exchange) matches(t testing.TB, r *http.Request, body []byte) bool {
+	if r.Method != e.Method || r.URL.Path != e.Path {
+		return false
+	}
+
+	if !subset(e.Query, r.URL.Query().Get) || !subset(url.Values(e.Header), r.Header.Get) {
+		return false
+	}
+
+	if e.Form != nil {
+		form := r.Clone(r.Context())
+		form.Body = io.NopCloser(bytes.NewReader(body))
+		if err := form.ParseMultipartForm(1 << 20); err != nil && err != http.ErrNotMultipart {
+			return false
+		}
+		if !subset(e.Form, form.Form.Get) {
+			return false
+		}
+	}
+
+	if e.JSON != "" {
+		var got, want interface{}
+		if json.Unmarshal(body, &got) != nil || json.Unmarshal(read(t, e.JSON), &want) != nil {
+			return false
+		}
+		return reflect.DeepEqual(got, want)
+	}
+
+	return true
+}
+
+// subset reports whether got has the values of want, a key repeated in want with several values isn't supported
+func subset(want url.Values, got func(key string) string) bool {
+	for key := range want {
+		if got(key) != want.Get(key) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func read(t testing.TB, name string) []byte {
+	data, err := os.ReadFile(filepath.Join("testdata", "fixtures", name))
+	if err != nil {
+		t.Errorf("fixture: %v", err)
+	}
+
+	return data
+}
@@ -0,0 +1,103 @@
+// Package golden compares the output of a test with a file kept in the testdata of its package,
+// so that a change of a wire format shows up in review as a change of the file. Run the tests of
+// the package with -update to rewrite the files, e.g. go test ./internal/handler -update
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files with the output of the tests")
+
+var (
+	uuids = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+	times = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})`)
+	signs = regexp.MustCompile(`(expires=)\d+|(signature=)[0-9a-f]+`)
+)
+
+// Assert compares got with testdata/<name>, the file is written instead with -update
+func Assert(t testing.TB, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%s: %v, run the tests with -update to create it", path, err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s: the output changed, run the tests with -update if the change is intended\n%s", path, diff(want, got))
+	}
+}
+
+// JSON compares the indented body with testdata/<name>, see Scrub for the values that differ between runs
+func JSON(t testing.TB, name string, body []byte) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, body, "", "  "); err != nil {
+		t.Fatalf("%s: %v\n%s", name, err, body)
+	}
+	buf.WriteByte('\n')
+
+	Assert(t, name, Scrub(buf.Bytes()))
+}
+
+// Scrub replaces the values that differ between runs: the uuids become <uuid-1>, <uuid-2>... in the order
+// they first appear so that the references between them are kept, the times become <time> and the expiry
+// and the signature of the signed urls are blanked
+func Scrub(data []byte) []byte {
+	seen := make(map[string]string)
+	data = uuids.ReplaceAllFunc(data, func(id []byte) []byte {
+		name, ok := seen[string(id)]
+		if !ok {
+			name = fmt.Sprintf("<uuid-%d>", len(seen)+1)
+			seen[string(id)] = name
+		}
+		return []byte(name)
+	})
+
+	data = times.ReplaceAll(data, []byte("<time>"))
+
+	return signs.ReplaceAll(data, []byte("$1$2<signed>"))
+}
+
+// diff lists the first lines that differ, enough to see what changed without a diff tool
+func diff(want, got []byte) string {
+	wants, gots := bytes.Split(want, []byte("\n")), bytes.Split(got, []byte("\n"))
+
+	var buf bytes.Buffer
+	for i, shown := 0, 0; (i < len(wants) || i < len(gots)) && shown < 10; i++ {
+		var w, g []byte
+		if i < len(wants) {
+			w = wants[i]
+		}
+		if i < len(gots) {
+			g = gots[i]
+		}
+
+		if !bytes.Equal(w, g) {
+			fmt.Fprintf(&buf, "line %d\n  - %s\n  + %s\n", i+1, w, g)
+			shown++
+		}
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,185 @@
+// Package lru is an in-memory cache split into shards, each with its own lock,
+// least recently used order and timing wheel expiring the entries.
+package lru
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	shardCount = 16
+	tick       = time.Second
+)
+
+// Cache holds up to its capacity of entries, the least recently used entry of
+// a full shard is evicted and every entry expires after its time to live
+type Cache struct {
+	shards [shardCount]*shard
+	ttl    time.Duration
+
+	stop chan struct{}
+	once sync.Once
+}
+
+type entry struct {
+	key     string
+	value   any
+	expires time.Time
+
+	element *list.Element
+	// slot is the slot of the wheel the entry waits in, position tells the slots apart
+	slot     map[*entry]struct{}
+	position int
+}
+
+type shard struct {
+	sync.Mutex
+
+	capacity int
+	items    map[string]*entry
+	order    *list.List
+	wheel    *wheel
+}
+
+// New returns a cache of at least the capacity, the ttl applies to the entries set with Set.
+// Close stops the expiry of the cache once it isn't used anymore.
+func New(capacity int, ttl time.Duration) *Cache {
+	perShard := (capacity + shardCount - 1) / shardCount
+	if perShard < 1 {
+		perShard = 1
+	}
+
+	c := &Cache{
+		ttl:  ttl,
+		stop: make(chan struct{}),
+	}
+
+	now := time.Now()
+	for i := range c.shards {
+		c.shards[i] = &shard{
+			capacity: perShard,
+			items:    make(map[string]*entry, perShard),
+			order:    list.New(),
+			wheel:    newWheel(now, tick),
+		}
+	}
+	go c.expire()
+
+	return c
+}
+
+// Get returns the value of the key and marks it as recently used
+func (c *Cache) Get(key string) (value any, found bool) {
+	s := c.shard(key)
+	s.Lock()
+	defer s.Unlock()
+
+	e, ok := s.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	if !e.expires.After(time.Now()) {
+		s.remove(e)
+		return nil, false
+	}
+	s.order.MoveToFront(e.element)
+
+	return e.value, true
+}
+
+// Set stores the value under the key for the ttl of the cache
+func (c *Cache) Set(key string, value any) {
+	c.SetWithTTL(key, value, c.ttl)
+}
+
+// SetWithTTL stores the value under the key for the ttl
+func (c *Cache) SetWithTTL(key string, value any, ttl time.Duration) {
+	s := c.shard(key)
+	s.Lock()
+	defer s.Unlock()
+
+	expires := time.Now().Add(ttl)
+	if e, ok := s.items[key]; ok {
+		e.value, e.expires = value, expires
+		s.wheel.move(e)
+		s.order.MoveToFront(e.element)
+		return
+	}
+
+	e := &entry{key: key, value: value, expires: expires}
+	e.element = s.order.PushFront(e)
+	s.items[key] = e
+	s.wheel.add(e)
+
+	if s.order.Len() > s.capacity {
+		s.remove(s.order.Back().Value.(*entry))
+	}
+}
+
+// Delete removes the key from the cache
+func (c *Cache) Delete(key string) {
+	s := c.shard(key)
+	s.Lock()
+	defer s.Unlock()
+
+	if e, ok := s.items[key]; ok {
+		s.remove(e)
+	}
+}
+
+// Len counts the entries of the cache, expired ones the wheel hasn't reached yet included
+func (c *Cache) Len() (n int) {
+	for _, s := range c.shards {
+		s.Lock()
+		n += len(s.items)
+		s.Unlock()
+	}
+
+	return
+}
+
+// Close stops expiring the entries in the background, Get still skips the expired ones
+func (c *Cache) Close() {
+	c.once.Do(func() {
+		close(c.stop)
+	})
+}
+
+// expire advances the wheel of every shard once a tick, a shard is only locked while its own wheel turns
+func (c *Cache) expire() {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case now := <-ticker.C:
+			for _, s := range c.shards {
+				s.Lock()
+				s.wheel.advance(now, s.remove)
+				s.Unlock()
+			}
+		}
+	}
+}
+
+func (s *shard) remove(e *entry) {
+	s.wheel.remove(e)
+	s.order.Remove(e.element)
+	delete(s.items, e.key)
+}
+
+// shard picks the shard of the key by its FNV-1a hash
+func (c *Cache) shard(key string) *shard {
+	hash := uint32(2166136261)
+	for i := 0; i < len(key); i++ {
+		hash ^= uint32(key[i])
+		hash *= 16777619
+	}
+
+	return c.shards[hash%shardCount]
+}
@@ -0,0 +1,229 @@
+package lru
+
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+)
+
+// sameShard returns n keys of the shard of the first one, so that they compete for its capacity
+func sameShard(c *Cache, n int) (dest []string) {
+	for i := 0; len(dest) < n; i++ {
+		key := "book:" + strconv.Itoa(i)
+		if len(dest) == 0 || c.shard(key) == c.shard(dest[0]) {
+			dest = append(dest, key)
+		}
+	}
+
+	return
+}
+
+func TestEviction(t *testing.T) {
+	// every step is an operation on one of the keys a, b, c and d of the same shard, which holds three of them
+	tests := []struct {
+		name  string
+		steps []string
+		want  string
+	}{
+		{"least recently set", []string{"set a", "set b", "set c", "set d"}, "bcd"},
+		{"get refreshes", []string{"set a", "set b", "set c", "get a", "set d"}, "acd"},
+		{"set refreshes", []string{"set a", "set b", "set c", "set a", "set d"}, "acd"},
+		{"get of a missing key", []string{"set a", "set b", "set c", "get d", "set d"}, "bcd"},
+		{"delete frees", []string{"set a", "set b", "set c", "del b", "set d"}, "acd"},
+		{"delete of a missing key", []string{"set a", "set b", "del d", "set c", "set d"}, "bcd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(3*shardCount, time.Minute)
+			defer c.Close()
+
+			names := sameShard(c, 4)
+			key := func(name string) string { return names[name[0]-'a'] }
+
+			for _, step := range tt.steps {
+				op, name := step[:3], step[4:]
+				switch op {
+				case "set":
+					c.Set(key(name), name)
+				case "get":
+					c.Get(key(name))
+				case "del":
+					c.Delete(key(name))
+				}
+			}
+
+			for _, name := range []string{"a", "b", "c", "d"} {
+				value, found := c.Get(key(name))
+				if want := strings.Contains(tt.want, name); found != want || found && value != name {
+					t.Errorf("Get(%s) = %v, %t, want it found %t", name, value, found, want)
+				}
+			}
+			if got := c.Len(); got != len(tt.want) {
+				t.Errorf("Len() = %d, want %d", got, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestGetExpired(t *testing.T) {
+	c := New(shardCount, time.Minute)
+	defer c.Close()
+
+	c.SetWithTTL("book:1", 1, -time.Second)
+	if _, found := c.Get("book:1"); found {
+		t.Error("an expired entry was found")
+	}
+
+	// the expired entry is removed by the Get, before the wheel reaches it
+	s := c.shard("book:1")
+	if len(s.items) != 0 || s.order.Len() != 0 || slots(s.wheel) != 0 {
+		t.Errorf("the expired entry is still held, %d items, %d in order and %d in the wheel", len(s.items), s.order.Len(), slots(s.wheel))
+	}
+}
+
+func TestDelete(t *testing.T) {
+	c := New(shardCount, time.Minute)
+	defer c.Close()
+
+	c.Set("book:1", 1)
+	e := c.shard("book:1").items["book:1"]
+	slot := e.slot
+
+	c.Delete("book:1")
+	if _, found := c.Get("book:1"); found {
+		t.Error("a deleted entry was found")
+	}
+	if _, ok := slot[e]; ok || e.slot != nil {
+		t.Error("the deleted entry is still in its slot of the wheel")
+	}
+}
+
+func TestSetWithTTL(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to time.Duration
+		moved    bool
+	}{
+		{"to a later level", 5 * time.Second, 2 * time.Hour, true},
+		{"to an earlier level", 2 * time.Hour, 5 * time.Second, true},
+		{"to another slot of the level", 5 * time.Second, 30 * time.Second, true},
+		{"within the slot", 2 * time.Hour, 2*time.Hour + time.Second, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(shardCount, time.Minute)
+			defer c.Close()
+
+			c.SetWithTTL("book:1", 1, tt.from)
+			e := c.shard("book:1").items["book:1"]
+			slot, position := e.slot, e.position
+
+			c.SetWithTTL("book:1", 2, tt.to)
+			if moved := e.position != position; moved != tt.moved {
+				t.Fatalf("moved from %d to %d, want moved %t", position, e.position, tt.moved)
+			}
+			if _, ok := slot[e]; ok == tt.moved {
+				t.Errorf("the entry is in its old slot %t, want %t", ok, !tt.moved)
+			}
+			if _, ok := e.slot[e]; !ok {
+				t.Error("the entry isn't in its new slot")
+			}
+			if value, _ := c.Get("book:1"); value != 2 {
+				t.Errorf("Get() = %v, want 2", value)
+			}
+		})
+	}
+}
+
+// the benchmarks compare the cache with go-cache, which the memory caches used before, under parallel load.
+// The shards only pay off once the goroutines run on cores of their own, so run them with -cpu on a machine
+// with at least that many cores:
+//
+//	go test ./pkg/lru -run - -bench . -cpu 1,4,16
+//
+// The capacity leaves room for the keys that hash to the same shard, so that nothing is evicted
+// and both caches do the same work.
+const (
+	benchKeys     = 1 << 14
+	benchCapacity = 2 * benchKeys
+	benchTTL      = 5 * time.Minute
+)
+
+var keys = func() (dest []string) {
+	dest = make([]string, benchKeys)
+	for i := range dest {
+		dest[i] = "book:" + strconv.Itoa(i)
+	}
+	return
+}()
+
+// store is what the benchmarks need of both caches
+type store interface {
+	Get(key string) (any, bool)
+	Set(key string, value any)
+}
+
+type goCache struct {
+	*cache.Cache
+}
+
+func (c goCache) Set(key string, value any) {
+	c.Cache.Set(key, value, cache.DefaultExpiration)
+}
+
+func newLRU(b *testing.B) store {
+	c := New(benchCapacity, benchTTL)
+	b.Cleanup(c.Close)
+	return c
+}
+
+func newGoCache(b *testing.B) store {
+	return goCache{cache.New(benchTTL, 10*time.Minute)}
+}
+
+// run fills the cache and has every goroutine read one key in reads and write one otherwise,
+// each goroutine starts at another key so that they don't walk over the same ones in step
+func run(b *testing.B, c store, reads int) {
+	for _, key := range keys {
+		c.Set(key, key)
+	}
+
+	var seed int64
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := int(atomic.AddInt64(&seed, 7919))
+		for pb.Next() {
+			key := keys[i%benchKeys]
+			if i%10 < reads {
+				c.Get(key)
+			} else {
+				c.Set(key, key)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkGet(b *testing.B) {
+	b.Run("lru", func(b *testing.B) { run(b, newLRU(b), 10) })
+	b.Run("go-cache", func(b *testing.B) { run(b, newGoCache(b), 10) })
+}
+
+func BenchmarkSet(b *testing.B) {
+	b.Run("lru", func(b *testing.B) { run(b, newLRU(b), 0) })
+	b.Run("go-cache", func(b *testing.B) { run(b, newGoCache(b), 0) })
+}
+
+// BenchmarkMixed reads nine keys for every one it writes, about the load of the book cache
+func BenchmarkMixed(b *testing.B) {
+	b.Run("lru", func(b *testing.B) { run(b, newLRU(b), 9) })
+	b.Run("go-cache", func(b *testing.B) { run(b, newGoCache(b), 9) })
+}
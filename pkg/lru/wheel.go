@@ -0,0 +1,123 @@
+package lru
+
+import (
+	"time"
+)
+
+const (
+	wheelBits   = 6
+	wheelSlots  = 1 << wheelBits
+	wheelMask   = wheelSlots - 1
+	wheelLevels = 4
+)
+
+// wheel is a hierarchical timing wheel, every level has 64 slots each as long as all of the
+// slots of the level below. With a one second tick the levels span about a minute, an hour,
+// three days and half a year, later expiries wait in the last slot of the top level.
+// Entries are moved down a level once the wheel reaches their slot and expire in level 0.
+type wheel struct {
+	start time.Time
+	tick  time.Duration
+
+	// current is the number of ticks since start the wheel has advanced to
+	current uint64
+	levels  [wheelLevels][wheelSlots]map[*entry]struct{}
+}
+
+func newWheel(start time.Time, tick time.Duration) *wheel {
+	w := &wheel{start: start, tick: tick}
+	for l := range w.levels {
+		for i := range w.levels[l] {
+			w.levels[l][i] = make(map[*entry]struct{})
+		}
+	}
+
+	return w
+}
+
+// add puts the entry in the slot of its expiry
+func (w *wheel) add(e *entry) {
+	w.put(e, w.current+1)
+}
+
+// put puts the entry in the slot of its expiry, but not before the earliest tick
+func (w *wheel) put(e *entry, earliest uint64) {
+	level, index := w.slotOf(e.expires, earliest)
+	e.slot, e.position = w.levels[level][index], level<<wheelBits|index
+	e.slot[e] = struct{}{}
+}
+
+// move puts the entry in the slot of its new expiry, it stays where it is when that is the same slot
+func (w *wheel) move(e *entry) {
+	if level, index := w.slotOf(e.expires, w.current+1); e.slot != nil && e.position == level<<wheelBits|index {
+		return
+	}
+
+	w.remove(e)
+	w.add(e)
+}
+
+// slotOf returns the level and the index of the slot of the expiry, an expiry that is due before the earliest
+// tick goes in the slot of that tick. Added entries go no earlier than the next slot, the current one is done.
+func (w *wheel) slotOf(expires time.Time, earliest uint64) (level, index int) {
+	ticks := earliest
+	if expires.After(w.start) {
+		if due := uint64(expires.Sub(w.start) / w.tick); due > ticks {
+			ticks = due
+		}
+	}
+
+	for delta := ticks - w.current; level < wheelLevels-1 && delta >= 1<<(wheelBits*(level+1)); level++ {
+	}
+
+	if limit := w.current + 1<<(wheelBits*wheelLevels) - 1; ticks > limit {
+		ticks = limit
+	}
+
+	return level, int((ticks >> (wheelBits * level)) & wheelMask)
+}
+
+func (w *wheel) remove(e *entry) {
+	if e.slot != nil {
+		delete(e.slot, e)
+		e.slot = nil
+	}
+}
+
+// advance turns the wheel to now, expired entries are passed to expire and the rest of the
+// entries of the upper slots reached on the way are moved down
+func (w *wheel) advance(now time.Time, expire func(e *entry)) {
+	if !now.After(w.start) {
+		return
+	}
+	target := uint64(now.Sub(w.start) / w.tick)
+
+	for w.current < target {
+		w.current++
+
+		for level := wheelLevels - 1; level > 0; level-- {
+			if w.current&(1<<(wheelBits*level)-1) != 0 {
+				continue
+			}
+
+			// an entry due at this very tick goes in the current slot of level 0, which is expired next
+			slot := w.levels[level][(w.current>>(wheelBits*level))&wheelMask]
+			for e := range slot {
+				delete(slot, e)
+				e.slot = nil
+				w.put(e, w.current)
+			}
+		}
+
+		slot := w.levels[0][w.current&wheelMask]
+		for e := range slot {
+			if e.expires.After(now) {
+				delete(slot, e)
+				e.slot = nil
+				w.add(e)
+				continue
+			}
+			expire(e)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package lru
+
+import (
+	"testing"
+	"time"
+)
+
+// slots counts the entries waiting in the slots of the wheel
+func slots(w *wheel) (n int) {
+	for l := range w.levels {
+		for i := range w.levels[l] {
+			n += len(w.levels[l][i])
+		}
+	}
+
+	return
+}
+
+func TestWheelAdvance(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// the entry is added to the level and waits in the other one a tick before its expiry, it is moved
+	// down on the way and expires in level 0, right as it is moved down when it is due at the boundary
+	tests := []struct {
+		name    string
+		ttl     time.Duration
+		level   int
+		waiting int
+	}{
+		{"next tick", time.Second, 0, 0},
+		{"last slot of level 0", 63 * time.Second, 0, 0},
+		{"first slot of level 1", 64 * time.Second, 1, 1},
+		{"within level 1", 100 * time.Second, 1, 0},
+		{"level 2", 2 * time.Hour, 2, 0},
+		{"level 3", 4 * 24 * time.Hour, 3, 1},
+	}
+
+	for _, tt := range tests {
+		for _, steps := range []string{"by the tick", "at once"} {
+			t.Run(tt.name+" "+steps, func(t *testing.T) {
+				w := newWheel(start, time.Second)
+				e := &entry{key: "book:1", expires: start.Add(tt.ttl)}
+				w.add(e)
+				if level := e.position >> wheelBits; level != tt.level {
+					t.Fatalf("added to level %d, want %d", level, tt.level)
+				}
+
+				var expired []*entry
+				expire := func(e *entry) {
+					w.remove(e)
+					expired = append(expired, e)
+				}
+
+				// nothing expires until the tick before the expiry, the entry is still waiting by then
+				due := start.Add(tt.ttl - time.Second)
+				if steps == "by the tick" {
+					for now := start.Add(time.Second); !now.After(due); now = now.Add(time.Second) {
+						w.advance(now, expire)
+					}
+				} else {
+					w.advance(due, expire)
+				}
+				if len(expired) != 0 {
+					t.Fatalf("expired %s early", tt.ttl-time.Second)
+				}
+				if e.slot == nil || e.position>>wheelBits != tt.waiting {
+					t.Fatalf("waits at level %d a tick before its expiry, want %d", e.position>>wheelBits, tt.waiting)
+				}
+
+				w.advance(start.Add(tt.ttl), expire)
+				if len(expired) != 1 || expired[0] != e {
+					t.Fatalf("got %d expired, want the entry", len(expired))
+				}
+				if n := slots(w); n != 0 {
+					t.Errorf("%d entries are left in the wheel", n)
+				}
+			})
+		}
+	}
+}
+
+func TestWheelAdvanceMany(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	w := newWheel(start, time.Second)
+
+	// the entries expire in the order of their expiry whatever level they were added to
+	ttls := []time.Duration{2 * time.Hour, 5 * time.Second, 90 * time.Second, time.Second, 65 * time.Minute, 64 * time.Second}
+	for _, ttl := range ttls {
+		w.add(&entry{expires: start.Add(ttl)})
+	}
+
+	var expired []time.Duration
+	for now := start; len(expired) < len(ttls) && now.Before(start.Add(3*time.Hour)); now = now.Add(time.Second) {
+		w.advance(now, func(e *entry) {
+			w.remove(e)
+			if e.expires.After(now) {
+				t.Errorf("expired at %s, before its expiry %s", now.Sub(start), e.expires.Sub(start))
+			}
+			if now.Sub(e.expires) >= time.Second {
+				t.Errorf("expired at %s, a tick after its expiry %s", now.Sub(start), e.expires.Sub(start))
+			}
+			expired = append(expired, e.expires.Sub(start))
+		})
+	}
+
+	want := []time.Duration{time.Second, 5 * time.Second, 64 * time.Second, 90 * time.Second, 65 * time.Minute, 2 * time.Hour}
+	if len(expired) != len(want) {
+		t.Fatalf("got %v expired, want %v", expired, want)
+	}
+	for i := range want {
+		if expired[i] != want[i] {
+			t.Fatalf("got %v expired, want %v", expired, want)
+		}
+	}
+}
+
+func TestWheelExpiredOnAdd(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	w := newWheel(start, time.Second)
+	w.advance(start.Add(10*time.Second), func(*entry) {})
+
+	// an entry that is already due waits in the next slot instead of one the wheel has passed
+	e := &entry{expires: start}
+	w.add(e)
+
+	var expired int
+	w.advance(start.Add(11*time.Second), func(e *entry) {
+		w.remove(e)
+		expired++
+	})
+	if expired != 1 {
+		t.Errorf("got %d expired, want the entry due before it was added", expired)
+	}
+}
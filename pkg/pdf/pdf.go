@@ -0,0 +1,157 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"io"
+	"strings"
+)
+
+// MM is the number of points in a millimeter, the unit of the page coordinates
+const MM = 72 / 25.4
+
+// Document is a PDF of pages with grayscale images and lines of Helvetica text,
+// enough for printable labels without a layout engine
+type Document struct {
+	pages []*Page
+}
+
+// Page has its origin at the bottom left corner and is measured in points
+type Page struct {
+	width, height float64
+
+	content bytes.Buffer
+	images  []*image.Gray
+}
+
+func New() *Document {
+	return &Document{}
+}
+
+func (d *Document) AddPage(width, height float64) *Page {
+	page := &Page{width: width, height: height}
+	d.pages = append(d.pages, page)
+
+	return page
+}
+
+// Image draws the image stretched to the rectangle with its bottom left corner at x, y
+func (p *Page) Image(img *image.Gray, x, y, width, height float64) {
+	p.images = append(p.images, img)
+	fmt.Fprintf(&p.content, "q %.2f 0 0 %.2f %.2f %.2f cm /I%d Do Q\n", width, height, x, y, len(p.images)-1)
+}
+
+// Text draws a line of text starting at x on the baseline y, the font only has the latin1
+// characters so the others are replaced with a question mark
+func (p *Page) Text(x, y, size float64, text string) {
+	fmt.Fprintf(&p.content, "BT /F1 %.2f Tf %.2f %.2f Td (%s) Tj ET\n", size, x, y, escape(text))
+}
+
+// Write renders the document, the images are compressed with deflate
+func (d *Document) Write(w io.Writer) (err error) {
+	var buf bytes.Buffer
+	var offsets []int
+
+	object := func(body string, stream []byte) int {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\n", len(offsets), body)
+		if stream != nil {
+			buf.WriteString("stream\n")
+			buf.Write(stream)
+			buf.WriteString("\nendstream\n")
+		}
+		buf.WriteString("endobj\n")
+
+		return len(offsets)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// the catalog, the page tree and the font come first so the pages can refer to them
+	object("<< /Type /Catalog /Pages 2 0 R >>", nil)
+	offsets = append(offsets, 0)
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>", nil)
+
+	kids := make([]string, len(d.pages))
+	for i, page := range d.pages {
+		xobjects := make([]string, len(page.images))
+		for j, img := range page.images {
+			data, err := deflate(gray(img))
+			if err != nil {
+				return err
+			}
+
+			id := object(fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceGray /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>",
+				img.Rect.Dx(), img.Rect.Dy(), len(data)), data)
+			xobjects[j] = fmt.Sprintf("/I%d %d 0 R", j, id)
+		}
+
+		content := object(fmt.Sprintf("<< /Length %d >>", page.content.Len()), page.content.Bytes())
+		id := object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R >> /XObject << %s >> >> /Contents %d 0 R >>",
+			page.width, page.height, strings.Join(xobjects, " "), content), nil)
+		kids[i] = fmt.Sprintf("%d 0 R", id)
+	}
+
+	// the page tree is written last now that the ids of the pages are known
+	tree := buf.Len()
+	fmt.Fprintf(&buf, "2 0 obj\n<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n", strings.Join(kids, " "), len(kids))
+	offsets[1] = tree
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err = w.Write(buf.Bytes())
+	return
+}
+
+// gray returns the rows of the image without the padding of its stride
+func gray(img *image.Gray) []byte {
+	width, height := img.Rect.Dx(), img.Rect.Dy()
+	if img.Stride == width {
+		return img.Pix[:width*height]
+	}
+
+	data := make([]byte, 0, width*height)
+	for y := 0; y < height; y++ {
+		data = append(data, img.Pix[y*img.Stride:y*img.Stride+width]...)
+	}
+
+	return data
+}
+
+func deflate(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32 || r > 255:
+			b.WriteByte('?')
+		default:
+			b.WriteByte(byte(r))
+		}
+	}
+
+	return b.String()
+}
@@ -43,6 +43,16 @@ func NotFound(w http.ResponseWriter, r *http.Request, err error) {
 	render.JSON(w, r, v)
 }
 
+func Forbidden(w http.ResponseWriter, r *http.Request, err error) {
+	render.Status(r, http.StatusForbidden)
+
+	v := Object{
+		Success: false,
+		Message: err.Error(),
+	}
+	render.JSON(w, r, v)
+}
+
 func InternalServerError(w http.ResponseWriter, r *http.Request, err error) {
 	render.Status(r, http.StatusInternalServerError)
 
@@ -52,3 +62,13 @@ func InternalServerError(w http.ResponseWriter, r *http.Request, err error) {
 	}
 	render.JSON(w, r, v)
 }
+
+func ServiceUnavailable(w http.ResponseWriter, r *http.Request, err error) {
+	render.Status(r, http.StatusServiceUnavailable)
+
+	v := Object{
+		Success: false,
+		Message: err.Error(),
+	}
+	render.JSON(w, r, v)
+}
@@ -0,0 +1,196 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Problem is an RFC 7807 problem details body
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// DeadlineStat counts how often a route ran out of its latency budget
+type DeadlineStat struct {
+	Route    string        `json:"route" validate:"required"`
+	Budget   time.Duration `json:"budget" swaggertype:"integer" validate:"required"`
+	Requests int           `json:"requests" validate:"required"`
+	Exceeded int           `json:"exceeded" validate:"required"`
+}
+
+// DeadlineMetrics records the requests served under a latency budget per route
+type DeadlineMetrics struct {
+	sync.Mutex
+
+	stats map[string]*DeadlineStat
+}
+
+func NewDeadlineMetrics() *DeadlineMetrics {
+	return &DeadlineMetrics{
+		stats: make(map[string]*DeadlineStat),
+	}
+}
+
+func (m *DeadlineMetrics) record(route string, budget time.Duration, exceeded bool) {
+	m.Lock()
+	defer m.Unlock()
+
+	stat, ok := m.stats[route]
+	if !ok {
+		stat = &DeadlineStat{Route: route}
+		m.stats[route] = stat
+	}
+	stat.Budget = budget
+	stat.Requests++
+	if exceeded {
+		stat.Exceeded++
+	}
+}
+
+// Report returns the routes that exceeded their budget most often first
+func (m *DeadlineMetrics) Report() (dest []DeadlineStat) {
+	m.Lock()
+	defer m.Unlock()
+
+	dest = make([]DeadlineStat, 0, len(m.stats))
+	for _, stat := range m.stats {
+		dest = append(dest, *stat)
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		if dest[i].Exceeded != dest[j].Exceeded {
+			return dest[i].Exceeded > dest[j].Exceeded
+		}
+		return dest[i].Route < dest[j].Route
+	})
+
+	return
+}
+
+// Budget puts a deadline on the request context that repository and gateway calls inherit.
+// The budget of the longest path prefix in budgets applies, fallback is used otherwise and
+// a budget of zero disables the deadline. Once the deadline is exceeded the response is
+// replaced with 504 and a problem+json body, unless the handler already started writing it.
+func Budget(fallback time.Duration, budgets map[string]time.Duration, m *DeadlineMetrics) func(next http.Handler) http.Handler {
+	prefixes := make([]string, 0, len(budgets))
+	for prefix := range budgets {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		return len(prefixes[i]) > len(prefixes[j])
+	})
+
+	lookup := func(path string) time.Duration {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(path, prefix) {
+				return budgets[prefix]
+			}
+		}
+		return fallback
+	}
+
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			budget := lookup(r.URL.Path)
+			if budget <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), budget)
+			defer cancel()
+
+			bw := &budgetWriter{ResponseWriter: w, ctx: ctx, budget: budget, instance: r.URL.Path}
+			next.ServeHTTP(bw, r.WithContext(ctx))
+
+			exceeded := errors.Is(ctx.Err(), context.DeadlineExceeded)
+			if exceeded && !bw.wroteHeader {
+				bw.writeProblem()
+			}
+
+			if pattern := chi.RouteContext(r.Context()).RoutePattern(); m != nil && pattern != "" {
+				m.record(r.Method+" "+pattern, budget, exceeded)
+			}
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// budgetWriter swaps the response of a handler that failed because its deadline was exceeded
+type budgetWriter struct {
+	http.ResponseWriter
+
+	ctx      context.Context
+	budget   time.Duration
+	instance string
+
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (w *budgetWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+
+	if errors.Is(w.ctx.Err(), context.DeadlineExceeded) {
+		w.writeProblem()
+		return
+	}
+
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *budgetWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.timedOut {
+		return len(data), nil
+	}
+
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *budgetWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok && !w.timedOut {
+		if !w.wroteHeader {
+			w.WriteHeader(http.StatusOK)
+		}
+		f.Flush()
+	}
+}
+
+func (w *budgetWriter) writeProblem() {
+	w.wroteHeader = true
+	w.timedOut = true
+
+	body, _ := json.Marshal(Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(http.StatusGatewayTimeout),
+		Status:   http.StatusGatewayTimeout,
+		Detail:   "request exceeded its latency budget of " + w.budget.String(),
+		Instance: w.instance,
+	})
+
+	header := w.ResponseWriter.Header()
+	header.Del("Content-Length")
+	header.Set("Content-Type", "application/problem+json")
+	w.ResponseWriter.WriteHeader(http.StatusGatewayTimeout)
+	w.ResponseWriter.Write(body)
+}
@@ -0,0 +1,164 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"go.uber.org/zap"
+
+	"library-service/pkg/log"
+)
+
+const redactedValue = "xxxxx"
+
+// Capture logs the request and response bodies of the routes under the path prefixes for
+// debugging integrations. Bodies are cut at limit bytes and the values of the redacted fields
+// are masked in json and form bodies. Nothing is captured unless the logger is at debug level.
+func Capture(prefixes []string, limit int, redact []string) func(next http.Handler) http.Handler {
+	sanitizer := newSanitizer(redact)
+
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			logger := log.LoggerFromContext(r.Context())
+			if !logger.Core().Enabled(zap.DebugLevel) || !hasPrefix(prefixes, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			req := &cappedBuffer{limit: limit}
+			r.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.TeeReader(r.Body, req), r.Body}
+
+			res := &cappedBuffer{limit: limit}
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(res)
+
+			next.ServeHTTP(ww, r)
+
+			logger.Named("Capture").Debug("captured bodies",
+				zap.String("request_id", middleware.GetReqID(r.Context())),
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+				zap.Int("status", ww.Status()),
+				zap.String("request", sanitizer.sanitize(req, r.Header.Get("Content-Type"))),
+				zap.Bool("request_truncated", req.truncated),
+				zap.String("response", sanitizer.sanitize(res, ww.Header().Get("Content-Type"))),
+				zap.Bool("response_truncated", res.truncated))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+func hasPrefix(prefixes []string, path string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cappedBuffer keeps the first limit bytes written to it
+type cappedBuffer struct {
+	bytes.Buffer
+
+	limit     int
+	truncated bool
+}
+
+func (b *cappedBuffer) Write(data []byte) (int, error) {
+	if room := b.limit - b.Len(); len(data) > room {
+		b.truncated = true
+		if room > 0 {
+			b.Buffer.Write(data[:room])
+		}
+		return len(data), nil
+	}
+
+	return b.Buffer.Write(data)
+}
+
+// sanitizer masks the values of the redacted fields, a complete json body is redacted field by field
+// while a truncated one, like a form body, is matched with patterns
+type sanitizer struct {
+	fields map[string]bool
+
+	jsonPattern *regexp.Regexp
+	formPattern *regexp.Regexp
+}
+
+func newSanitizer(redact []string) *sanitizer {
+	s := &sanitizer{fields: make(map[string]bool, len(redact))}
+	if len(redact) == 0 {
+		return s
+	}
+
+	names := make([]string, len(redact))
+	for i, field := range redact {
+		s.fields[strings.ToLower(field)] = true
+		names[i] = regexp.QuoteMeta(field)
+	}
+	alternation := strings.Join(names, "|")
+
+	s.jsonPattern = regexp.MustCompile(`("(?i:` + alternation + `)"\s*:\s*)("(?:[^"\\]|\\.)*"?|[^,}\]\s]*)`)
+	s.formPattern = regexp.MustCompile(`((?:^|&)(?i:` + alternation + `)=)[^&]*`)
+
+	return s
+}
+
+func (s *sanitizer) sanitize(body *cappedBuffer, contentType string) string {
+	data := body.Bytes()
+	if len(data) == 0 || len(s.fields) == 0 {
+		return string(data)
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if values, err := url.ParseQuery(string(data)); err == nil && !body.truncated {
+			for key := range values {
+				if s.fields[strings.ToLower(key)] {
+					values.Set(key, redactedValue)
+				}
+			}
+			return values.Encode()
+		}
+		return s.formPattern.ReplaceAllString(string(data), "${1}"+redactedValue)
+	}
+
+	var value any
+	if !body.truncated && json.Unmarshal(data, &value) == nil {
+		if out, err := json.Marshal(s.redact(value)); err == nil {
+			return string(out)
+		}
+	}
+
+	return s.jsonPattern.ReplaceAllString(string(data), `${1}"`+redactedValue+`"`)
+}
+
+func (s *sanitizer) redact(value any) any {
+	switch data := value.(type) {
+	case map[string]any:
+		for key, object := range data {
+			if s.fields[strings.ToLower(key)] {
+				data[key] = redactedValue
+				continue
+			}
+			data[key] = s.redact(object)
+		}
+	case []any:
+		for i, object := range data {
+			data[i] = s.redact(object)
+		}
+	}
+
+	return value
+}
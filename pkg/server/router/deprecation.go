@@ -0,0 +1,110 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Deprecation describes a route that is scheduled for removal
+type Deprecation struct {
+	// Since is when the route was deprecated and Sunset is when it stops being served
+	Since  time.Time
+	Sunset time.Time
+
+	// Link points clients to the successor route or a migration guide
+	Link string
+}
+
+// DeprecationCall is the usage of a deprecated route by a single client
+type DeprecationCall struct {
+	Route    string    `json:"route" validate:"required"`
+	Client   string    `json:"client" validate:"required"`
+	Count    int       `json:"count" validate:"required"`
+	LastSeen time.Time `json:"lastSeen" validate:"required" format:"date-time"`
+}
+
+// DeprecationUsage records who keeps calling deprecated routes
+type DeprecationUsage struct {
+	sync.Mutex
+
+	identify func(r *http.Request) string
+	calls    map[string]*DeprecationCall
+}
+
+// NewDeprecationUsage takes a function that names the client behind a request
+func NewDeprecationUsage(identify func(r *http.Request) string) *DeprecationUsage {
+	return &DeprecationUsage{
+		identify: identify,
+		calls:    make(map[string]*DeprecationCall),
+	}
+}
+
+func (u *DeprecationUsage) record(route, client string) {
+	u.Lock()
+	defer u.Unlock()
+
+	key := route + "|" + client
+	call, ok := u.calls[key]
+	if !ok {
+		call = &DeprecationCall{Route: route, Client: client}
+		u.calls[key] = call
+	}
+	call.Count++
+	call.LastSeen = time.Now()
+}
+
+// Report returns the recorded usage ordered by route and client
+func (u *DeprecationUsage) Report() (dest []DeprecationCall) {
+	u.Lock()
+	defer u.Unlock()
+
+	dest = make([]DeprecationCall, 0, len(u.calls))
+	for _, call := range u.calls {
+		dest = append(dest, *call)
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		if dest[i].Route != dest[j].Route {
+			return dest[i].Route < dest[j].Route
+		}
+		return dest[i].Client < dest[j].Client
+	})
+
+	return
+}
+
+// Deprecated stamps the Deprecation, Sunset and Link headers on responses of the route
+// and records the calling client in the usage, e.g. r.With(router.Deprecated(d, u)).Get(...)
+func Deprecated(d Deprecation, u *DeprecationUsage) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			if d.Since.IsZero() {
+				w.Header().Set("Deprecation", "true")
+			} else {
+				w.Header().Set("Deprecation", fmt.Sprintf("@%d", d.Since.Unix()))
+			}
+
+			if !d.Sunset.IsZero() {
+				w.Header().Set("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+			}
+
+			if d.Link != "" {
+				w.Header().Add("Link", fmt.Sprintf("<%s>; rel=\"deprecation\"", d.Link))
+			}
+
+			next.ServeHTTP(w, r)
+
+			if u != nil {
+				route := r.Method + " " + chi.RouteContext(r.Context()).RoutePattern()
+				u.record(route, u.identify(r))
+			}
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
@@ -0,0 +1,192 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Priority int
+
+const (
+	PriorityLow Priority = iota
+	PriorityNormal
+	PriorityHigh
+)
+
+// Priorities names the priorities from the lowest, the low ones are shed first
+var Priorities = []string{"low", "normal", "high"}
+
+// shares is the part of the concurrency limit the requests of each priority may take
+var shares = []float64{0.5, 0.8, 1}
+
+func ParsePriority(name string) (Priority, error) {
+	for i, v := range Priorities {
+		if v == name {
+			return Priority(i), nil
+		}
+	}
+
+	return 0, fmt.Errorf("priority %q must be one of %s", name, strings.Join(Priorities, ", "))
+}
+
+func (p Priority) String() string {
+	return Priorities[p]
+}
+
+// ShedStat is the state of the Shedder and the requests it shed per priority
+type ShedStat struct {
+	Limit    int              `json:"limit" validate:"required"`
+	Inflight int              `json:"inflight" validate:"required"`
+	Latency  time.Duration    `json:"latency" swaggertype:"integer" validate:"required"`
+	Shed     map[string]int64 `json:"shed" validate:"required"`
+}
+
+// Shedder adapts a concurrency limit to the latency of the requests, the limit is cut by
+// a tenth whenever the average latency goes above the target and grows back by one per
+// limit worth of requests served under it. A priority only gets its share of the limit,
+// so low priority requests are shed well before the high priority ones.
+type Shedder struct {
+	sync.Mutex
+
+	min, max int
+	target   time.Duration
+
+	limit     float64
+	inflight  int
+	latency   time.Duration
+	decreased time.Time
+	shed      []int64
+}
+
+func NewShedder(limit int, target time.Duration) *Shedder {
+	return &Shedder{
+		min:    int(math.Max(1, float64(limit/8))),
+		max:    limit,
+		target: target,
+		limit:  float64(limit),
+		shed:   make([]int64, len(Priorities)),
+	}
+}
+
+// admit takes a slot of the limit for the request unless its priority is out of share
+func (s *Shedder) admit(p Priority) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	if float64(s.inflight) >= math.Max(1, s.limit*shares[p]) {
+		s.shed[p]++
+		return false
+	}
+	s.inflight++
+
+	return true
+}
+
+// done gives the slot back and adapts the limit to the latency of the request
+func (s *Shedder) done(latency time.Duration) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.inflight--
+	if s.latency == 0 {
+		s.latency = latency
+	}
+	s.latency += (latency - s.latency) / 5
+
+	switch {
+	case s.latency > s.target:
+		// the requests already in flight finish slow too, so the limit is cut once per latency
+		if time.Since(s.decreased) > s.latency {
+			s.limit = math.Max(float64(s.min), s.limit*0.9)
+			s.decreased = time.Now()
+		}
+	default:
+		s.limit = math.Min(float64(s.max), s.limit+1/s.limit)
+	}
+}
+
+func (s *Shedder) Stat() ShedStat {
+	s.Lock()
+	defer s.Unlock()
+
+	res := ShedStat{
+		Limit:    int(s.limit),
+		Inflight: s.inflight,
+		Latency:  s.latency,
+		Shed:     make(map[string]int64, len(Priorities)),
+	}
+	for i, name := range Priorities {
+		res.Shed[name] = s.shed[i]
+	}
+
+	return res
+}
+
+// Shed answers 503 with a Retry-After header to the requests the Shedder has no room for.
+// The priority of the longest route in priorities that matches applies, fallback otherwise.
+// A route is a path prefix optionally preceded by a method, e.g. "GET /books".
+func Shed(s *Shedder, priorities map[string]Priority, fallback Priority, retryAfter time.Duration) func(next http.Handler) http.Handler {
+	routes := make([]string, 0, len(priorities))
+	for route := range priorities {
+		routes = append(routes, route)
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		return len(routes[i]) > len(routes[j])
+	})
+
+	lookup := func(r *http.Request) Priority {
+		for _, route := range routes {
+			path := route
+			if method, prefix, ok := strings.Cut(route, " "); ok {
+				if method != r.Method {
+					continue
+				}
+				path = prefix
+			}
+
+			if strings.HasPrefix(r.URL.Path, path) {
+				return priorities[route]
+			}
+		}
+		return fallback
+	}
+
+	seconds := strconv.Itoa(int(math.Max(1, math.Ceil(retryAfter.Seconds()))))
+
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			priority := lookup(r)
+			if !s.admit(priority) {
+				body, _ := json.Marshal(Problem{
+					Type:     "about:blank",
+					Title:    http.StatusText(http.StatusServiceUnavailable),
+					Status:   http.StatusServiceUnavailable,
+					Detail:   "server is overloaded, requests of " + priority.String() + " priority are shed",
+					Instance: r.URL.Path,
+				})
+
+				w.Header().Set("Content-Type", "application/problem+json")
+				w.Header().Set("Retry-After", seconds)
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write(body)
+				return
+			}
+
+			start := time.Now()
+			defer func() {
+				s.done(time.Since(start))
+			}()
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrorSignatureInvalid = errors.New("storage: invalid signature")
+	ErrorSignatureExpired = errors.New("storage: signature expired")
+)
+
+// URLSigner issues expiring links to stored files without requiring a token
+type URLSigner struct {
+	secret  []byte
+	baseURL string
+	expires time.Duration
+}
+
+// NewURLSigner takes the url the files are served under, e.g. /api/v1/files
+func NewURLSigner(secret, baseURL string, expires time.Duration) *URLSigner {
+	return &URLSigner{
+		secret:  []byte(secret),
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		expires: expires,
+	}
+}
+
+// URL returns the link that authorizes downloading the key until it expires, between one and two periods from now
+func (s *URLSigner) URL(key string) string {
+	// the expiry is rounded up to a whole period, so that a link stays the same for the period and
+	// the client keeps the file it points at cached instead of downloading it with every response
+	expires := strconv.FormatInt(time.Now().Add(s.expires).Truncate(s.expires).Add(s.expires).Unix(), 10)
+
+	values := url.Values{}
+	values.Set("expires", expires)
+	values.Set("signature", s.signature(key, expires))
+
+	return s.baseURL + "/" + key + "?" + values.Encode()
+}
+
+// Verify checks the query string of a link produced by URL for the key
+func (s *URLSigner) Verify(key string, values url.Values) error {
+	expires := values.Get("expires")
+	signature, err := hex.DecodeString(values.Get("signature"))
+	if err != nil || expires == "" {
+		return ErrorSignatureInvalid
+	}
+
+	expected, _ := hex.DecodeString(s.signature(key, expires))
+	if !hmac.Equal(signature, expected) {
+		return ErrorSignatureInvalid
+	}
+
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrorSignatureInvalid
+	}
+
+	if time.Now().After(time.Unix(unix, 0)) {
+		return ErrorSignatureExpired
+	}
+
+	return nil
+}
+
+func (s *URLSigner) signature(key, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "\n" + expires))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	ErrorNotFound   = errors.New("storage: file not found")
+	ErrorInvalidKey = errors.New("storage: invalid key")
+)
+
+// Storage keeps files addressed by slash separated keys, e.g. exports/<id>.csv
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Prune(ctx context.Context, prefix string, before time.Time) (int, error)
+}
+
+// Local is a Storage backed by a directory on the local disk
+type Local struct {
+	dir string
+}
+
+func NewLocal(dir string) (s *Local, err error) {
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return
+	}
+	s = &Local{dir: dir}
+
+	return
+}
+
+func (s *Local) path(key string) (string, error) {
+	key = filepath.Clean("/" + key)
+	if key == "/" || strings.Contains(key, "..") {
+		return "", ErrorInvalidKey
+	}
+
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// Put writes the file to a temporary name first so readers never see a partial file
+func (s *Local) Put(ctx context.Context, key string, r io.Reader) (err error) {
+	path, err := s.path(key)
+	if err != nil {
+		return
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return
+	}
+
+	if err = tmp.Close(); err != nil {
+		return
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *Local) Open(ctx context.Context, key string) (dest io.ReadCloser, err error) {
+	path, err := s.path(key)
+	if err != nil {
+		return
+	}
+
+	dest, err = os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		err = ErrorNotFound
+	}
+
+	return
+}
+
+// Prune removes the files under the prefix last written before the time, along with the temporary
+// files of the uploads that never finished, and returns how many it removed
+func (s *Local) Prune(ctx context.Context, prefix string, before time.Time) (count int, err error) {
+	path, err := s.path(prefix)
+	if err != nil {
+		return
+	}
+
+	err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		if err = ctx.Err(); err != nil || entry.IsDir() {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		if !info.ModTime().Before(before) {
+			return nil
+		}
+
+		if err = os.Remove(file); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		count++
+
+		return nil
+	})
+
+	return
+}
@@ -6,17 +6,17 @@ import (
 	"strings"
 
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/source"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
 
 func Migrate(dataSourceName string) (err error) {
-	if !strings.Contains(dataSourceName, "://") {
-		err = errors.New("store: undefined data source name " + dataSourceName)
+	sourceURL, err := migrationsOf(dataSourceName)
+	if err != nil {
 		return
 	}
-	driverName := strings.ToLower(strings.Split(dataSourceName, "://")[0])
 
-	migrations, err := migrate.New(fmt.Sprintf("file://migrations/%s", driverName), dataSourceName)
+	migrations, err := migrate.New(sourceURL, dataSourceName)
 	if err != nil {
 		return
 	}
@@ -29,3 +29,45 @@ func Migrate(dataSourceName string) (err error) {
 
 	return
 }
+
+// MigrationVersion returns the version the schema of the store is migrated to, zero when it never was,
+// whether the last migration failed halfway and the latest version of the migrations
+func MigrationVersion(dataSourceName string) (current, latest uint, dirty bool, err error) {
+	sourceURL, err := migrationsOf(dataSourceName)
+	if err != nil {
+		return
+	}
+
+	files, err := source.Open(sourceURL)
+	if err != nil {
+		return
+	}
+	defer files.Close()
+
+	for version, err := files.First(); err == nil; version, err = files.Next(version) {
+		latest = version
+	}
+
+	migrations, err := migrate.New(sourceURL, dataSourceName)
+	if err != nil {
+		return
+	}
+	defer migrations.Close()
+
+	if current, dirty, err = migrations.Version(); errors.Is(err, migrate.ErrNilVersion) {
+		err = nil
+	}
+
+	return
+}
+
+// migrationsOf returns the url of the migrations of the driver of the data source name
+func migrationsOf(dataSourceName string) (sourceURL string, err error) {
+	if !strings.Contains(dataSourceName, "://") {
+		err = errors.New("store: undefined data source name " + dataSourceName)
+		return
+	}
+	driverName := strings.ToLower(strings.Split(dataSourceName, "://")[0])
+
+	return fmt.Sprintf("file://migrations/%s", driverName), nil
+}
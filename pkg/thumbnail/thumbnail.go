@@ -0,0 +1,63 @@
+package thumbnail
+
+import (
+	"image"
+	"image/color"
+)
+
+// Fit scales the image down to fit within width x height keeping its aspect ratio,
+// each pixel is the average of the source pixels it covers. Smaller images are only copied.
+func Fit(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+
+	dw, dh := sw, sh
+	if dw > width {
+		dw, dh = width, sh*width/sw
+	}
+	if dh > height {
+		dw, dh = dw*height/dh, height
+	}
+	if dw < 1 {
+		dw = 1
+	}
+	if dh < 1 {
+		dh = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := bounds.Min.Y+y*sh/dh, bounds.Min.Y+(y+1)*sh/dh
+		if y1 == y0 {
+			y1++
+		}
+
+		for x := 0; x < dw; x++ {
+			x0, x1 := bounds.Min.X+x*sw/dw, bounds.Min.X+(x+1)*sw/dw
+			if x1 == x0 {
+				x1++
+			}
+			dst.SetRGBA(x, y, average(src, x0, y0, x1, y1))
+		}
+	}
+
+	return dst
+}
+
+func average(src image.Image, x0, y0, x1, y1 int) color.RGBA {
+	var r, g, b, a, n uint64
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			cr, cg, cb, ca := src.At(x, y).RGBA()
+			r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+			n++
+		}
+	}
+
+	return color.RGBA{
+		R: uint8(r / n >> 8),
+		G: uint8(g / n >> 8),
+		B: uint8(b / n >> 8),
+		A: uint8(a / n >> 8),
+	}
+}
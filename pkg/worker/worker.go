@@ -0,0 +1,178 @@
+package worker
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Stat is the state of a worker, Processed and Errors count its runs.
+// A worker is Stuck when its current run has taken longer than its deadline,
+// and Stalled when it hasn't run for two intervals, e.g. because its goroutine died.
+type Stat struct {
+	Name        string     `json:"name" validate:"required"`
+	Interval    string     `json:"interval" validate:"required"`
+	Running     bool       `json:"running" validate:"required"`
+	Processed   int64      `json:"processed" validate:"required"`
+	Errors      int64      `json:"errors" validate:"required"`
+	LastError   string     `json:"lastError,omitempty"`
+	HeartbeatAt *time.Time `json:"heartbeatAt,omitempty" format:"date-time"`
+	StartedAt   *time.Time `json:"startedAt,omitempty" format:"date-time"`
+	Stuck       bool       `json:"stuck" validate:"required"`
+	Stalled     bool       `json:"stalled" validate:"required"`
+}
+
+// Healthy reports whether the worker is neither stuck nor stalled
+func (s Stat) Healthy() bool {
+	return !s.Stuck && !s.Stalled
+}
+
+// Worker records the heartbeats and the runs of a background loop that runs every interval,
+// a run is expected to take no longer than the deadline
+type Worker struct {
+	sync.Mutex
+
+	name     string
+	interval time.Duration
+	deadline time.Duration
+	created  time.Time
+
+	heartbeat time.Time
+	started   time.Time
+	processed int64
+	errors    int64
+	lastError string
+}
+
+func New(name string, interval, deadline time.Duration) *Worker {
+	return &Worker{
+		name:     name,
+		interval: interval,
+		deadline: deadline,
+		created:  time.Now(),
+	}
+}
+
+// Run beats the heart of the worker and calls fn, a run that returns an error counts as one
+func (w *Worker) Run(fn func() error) error {
+	w.Lock()
+	w.heartbeat, w.started = time.Now(), time.Now()
+	w.Unlock()
+
+	err := fn()
+
+	w.Lock()
+	defer w.Unlock()
+
+	w.heartbeat, w.started = time.Now(), time.Time{}
+	w.processed++
+	if err != nil {
+		w.errors++
+		w.lastError = err.Error()
+	}
+
+	return err
+}
+
+func (w *Worker) Stat() Stat {
+	return w.stat(time.Now())
+}
+
+func (w *Worker) stat(now time.Time) (dest Stat) {
+	w.Lock()
+	defer w.Unlock()
+
+	dest = Stat{
+		Name:      w.name,
+		Interval:  w.interval.String(),
+		Running:   !w.started.IsZero(),
+		Processed: w.processed,
+		Errors:    w.errors,
+		LastError: w.lastError,
+	}
+
+	if !w.heartbeat.IsZero() {
+		heartbeat := w.heartbeat
+		dest.HeartbeatAt = &heartbeat
+	}
+
+	if dest.Running {
+		started := w.started
+		dest.StartedAt = &started
+		dest.Stuck = w.deadline > 0 && now.Sub(w.started) > w.deadline
+		return
+	}
+
+	// a worker that never ran is counted from when it was added
+	last := w.heartbeat
+	if last.IsZero() {
+		last = w.created
+	}
+	dest.Stalled = w.interval > 0 && now.Sub(last) > 2*w.interval
+
+	return
+}
+
+// Group keeps the workers of the service to report their state and watch over them
+type Group struct {
+	sync.Mutex
+
+	workers []*Worker
+}
+
+func NewGroup() *Group {
+	return &Group{}
+}
+
+// New returns a worker that is reported with the group
+func (g *Group) New(name string, interval, deadline time.Duration) *Worker {
+	g.Lock()
+	defer g.Unlock()
+
+	w := New(name, interval, deadline)
+	g.workers = append(g.workers, w)
+
+	return w
+}
+
+// Report returns the state of the workers by name
+func (g *Group) Report() (dest []Stat) {
+	g.Lock()
+	defer g.Unlock()
+
+	dest = make([]Stat, 0, len(g.workers))
+	for _, w := range g.workers {
+		dest = append(dest, w.Stat())
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].Name < dest[j].Name
+	})
+
+	return
+}
+
+// Watch checks the workers every interval until the context is done and calls alert with a worker
+// once it gets stuck or stalls, and once more when it is healthy again
+func (g *Group) Watch(ctx context.Context, interval time.Duration, alert func(data Stat)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	unhealthy := make(map[string]bool)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for _, data := range g.Report() {
+			if data.Healthy() == !unhealthy[data.Name] {
+				continue
+			}
+			unhealthy[data.Name] = !data.Healthy()
+			alert(data)
+		}
+	}
+}